@@ -68,3 +68,26 @@ func (s *BinLayoutsStore) Get(ctx context.Context, ID string) (chronograf.Layout
 		Error("Layout not found")
 	return chronograf.Layout{}, chronograf.ErrLayoutNotFound
 }
+
+// Add is not supported, the embedded layouts are read-only
+func (s *BinLayoutsStore) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	return chronograf.Layout{}, chronograf.ErrLayoutReadOnly
+}
+
+// Delete is not supported, the embedded layouts are read-only
+func (s *BinLayoutsStore) Delete(ctx context.Context, layout chronograf.Layout) error {
+	return s.readOnly(ctx, layout.ID)
+}
+
+// Update is not supported, the embedded layouts are read-only
+func (s *BinLayoutsStore) Update(ctx context.Context, layout chronograf.Layout) error {
+	return s.readOnly(ctx, layout.ID)
+}
+
+// readOnly returns chronograf.ErrLayoutReadOnly if the layout exists
+func (s *BinLayoutsStore) readOnly(ctx context.Context, ID string) error {
+	if _, err := s.Get(ctx, ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
@@ -0,0 +1,263 @@
+// Package catalog reads packages of protoboards and layouts from a catalog.
+//
+// A catalog is an index.json file listing the versions of packages and the
+// location of each version relative to the index:
+//
+//	{
+//	  "packages": [{
+//	    "name": "system",
+//	    "description": "CPU, memory and disk of hosts",
+//	    "versions": [
+//	      {"version": "1.0.0", "path": "system/1.0.0.json", "sha256": "..."}
+//	    ]
+//	  }]
+//	}
+//
+// A package is a JSON file with a manifest and the protoboards and layouts of
+// the version:
+//
+//	{
+//	  "manifest": {"name": "system", "version": "1.0.0"},
+//	  "protoboards": [...],
+//	  "layouts": [...]
+//	}
+//
+// Catalogs are served from any static HTTP location or read from a local
+// directory.
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+)
+
+// maxFileSize limits the size of the index and the packages of a catalog
+const maxFileSize = 32 << 20
+
+// Index lists the packages of a catalog
+type Index struct {
+	Packages []IndexPackage `json:"packages"`
+}
+
+// IndexPackage lists the versions of a package
+type IndexPackage struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Versions    []IndexVersion `json:"versions"`
+}
+
+// IndexVersion locates a version of a package
+type IndexVersion struct {
+	Version string `json:"version"`
+	Path    string `json:"path"`             // Path is the location of the package relative to the index
+	SHA256  string `json:"sha256,omitempty"` // SHA256 is the optional hex encoded checksum of the package
+}
+
+// Package returns the package with name
+func (idx Index) Package(name string) (IndexPackage, bool) {
+	for _, p := range idx.Packages {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return IndexPackage{}, false
+}
+
+// Sorted returns the valid versions of the package, the latest first
+func (p IndexPackage) Sorted() []IndexVersion {
+	type parsed struct {
+		IndexVersion
+		v Version
+	}
+	versions := []parsed{}
+	for _, iv := range p.Versions {
+		if v, err := ParseVersion(iv.Version); err == nil {
+			versions = append(versions, parsed{iv, v})
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].v.Compare(versions[j].v) > 0
+	})
+	sorted := make([]IndexVersion, len(versions))
+	for i, v := range versions {
+		sorted[i] = v.IndexVersion
+	}
+	return sorted
+}
+
+// Latest returns the latest release of the package, or its latest
+// pre-release if it has no release
+func (p IndexPackage) Latest() (IndexVersion, bool) {
+	sorted := p.Sorted()
+	for _, iv := range sorted {
+		if v, _ := ParseVersion(iv.Version); len(v.Pre) == 0 {
+			return iv, true
+		}
+	}
+	if len(sorted) > 0 {
+		return sorted[0], true
+	}
+	return IndexVersion{}, false
+}
+
+// Version returns the version of the package. An empty version is the latest.
+func (p IndexPackage) Version(version string) (IndexVersion, bool) {
+	if version == "" {
+		return p.Latest()
+	}
+	want, err := ParseVersion(version)
+	if err != nil {
+		return IndexVersion{}, false
+	}
+	for _, iv := range p.Versions {
+		if v, err := ParseVersion(iv.Version); err == nil && v.Compare(want) == 0 {
+			return iv, true
+		}
+	}
+	return IndexVersion{}, false
+}
+
+// Client reads a catalog from a static HTTP location or a local directory
+type Client struct {
+	// Location is the URL or the path of the index of the catalog, or of the
+	// directory of an index.json
+	Location string
+	HTTP     *http.Client
+}
+
+// Index returns the index of the catalog
+func (c *Client) Index(ctx context.Context) (Index, error) {
+	b, err := c.read(ctx, c.indexLocation())
+	if err != nil {
+		return Index{}, err
+	}
+	var idx Index
+	if err := json.Unmarshal(b, &idx); err != nil {
+		return Index{}, fmt.Errorf("invalid catalog index: %v", err)
+	}
+	return idx, nil
+}
+
+// Package returns a version of a package of the catalog. An empty version is
+// the latest version of the package.
+func (c *Client) Package(ctx context.Context, name, version string) (chronograf.Package, error) {
+	idx, err := c.Index(ctx)
+	if err != nil {
+		return chronograf.Package{}, err
+	}
+	p, ok := idx.Package(name)
+	if !ok {
+		return chronograf.Package{}, chronograf.ErrPackageNotFound
+	}
+	iv, ok := p.Version(version)
+	if !ok {
+		return chronograf.Package{}, chronograf.ErrPackageNotFound
+	}
+
+	loc, err := c.resolve(iv.Path)
+	if err != nil {
+		return chronograf.Package{}, err
+	}
+	b, err := c.read(ctx, loc)
+	if err != nil {
+		return chronograf.Package{}, err
+	}
+	if iv.SHA256 != "" {
+		sum := sha256.Sum256(b)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), iv.SHA256) {
+			return chronograf.Package{}, fmt.Errorf("checksum of package %s %s does not match the catalog", name, iv.Version)
+		}
+	}
+
+	var pkg chronograf.Package
+	if err := json.Unmarshal(b, &pkg); err != nil {
+		return chronograf.Package{}, fmt.Errorf("invalid package %s %s: %v", name, iv.Version, err)
+	}
+	if pkg.Manifest.Name != name || pkg.Manifest.Version != iv.Version {
+		return chronograf.Package{}, fmt.Errorf("manifest of package %s %s is %s %s", name, iv.Version, pkg.Manifest.Name, pkg.Manifest.Version)
+	}
+	if err := Validate(&pkg); err != nil {
+		return chronograf.Package{}, err
+	}
+	return pkg, nil
+}
+
+// isURL is true if the location of the catalog is an HTTP URL
+func (c *Client) isURL() bool {
+	return strings.HasPrefix(c.Location, "http://") || strings.HasPrefix(c.Location, "https://")
+}
+
+func (c *Client) indexLocation() string {
+	loc := strings.TrimPrefix(c.Location, "file://")
+	if strings.HasSuffix(loc, ".json") {
+		return loc
+	}
+	if c.isURL() {
+		return strings.TrimSuffix(loc, "/") + "/index.json"
+	}
+	return filepath.Join(loc, "index.json")
+}
+
+// resolve returns the location of a package relative to the index
+func (c *Client) resolve(p string) (string, error) {
+	if c.isURL() {
+		base, err := url.Parse(c.indexLocation())
+		if err != nil {
+			return "", err
+		}
+		ref, err := url.Parse(p)
+		if err != nil {
+			return "", fmt.Errorf("invalid package path %q: %v", p, err)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	// packages of a directory catalog stay within the directory
+	clean := path.Clean("/" + filepath.ToSlash(p))
+	if clean != "/"+filepath.ToSlash(p) {
+		return "", fmt.Errorf("invalid package path %q", p)
+	}
+	return filepath.Join(filepath.Dir(c.indexLocation()), filepath.FromSlash(clean)), nil
+}
+
+func (c *Client) read(ctx context.Context, loc string) ([]byte, error) {
+	if !c.isURL() {
+		f, err := os.Open(loc)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ioutil.ReadAll(io.LimitReader(f, maxFileSize))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", loc, nil)
+	if err != nil {
+		return nil, err
+	}
+	cli := c.HTTP
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read %s: %s", loc, resp.Status)
+	}
+	return ioutil.ReadAll(io.LimitReader(resp.Body, maxFileSize))
+}
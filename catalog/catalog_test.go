@@ -0,0 +1,205 @@
+package catalog_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
+)
+
+const systemV1 = `{
+	"manifest": {"name": "system", "version": "1.0.0"},
+	"protoboards": [{"id": "system", "meta": {"name": "System", "measurements": ["cpu"]}}],
+	"layouts": [{"id": "cpu", "app": "system", "measurement": "cpu"}]
+}`
+
+const systemV2 = `{
+	"manifest": {"name": "system", "version": "1.1.0"},
+	"protoboards": [{"id": "system", "meta": {"name": "System", "version": "1.1.0", "measurements": ["cpu", "mem"]}}]
+}`
+
+func catalogFiles() map[string]string {
+	sum := sha256.Sum256([]byte(systemV1))
+	return map[string]string{
+		"index.json": `{"packages": [
+			{"name": "system", "description": "Hosts", "versions": [
+				{"version": "1.0.0", "path": "system/1.0.0.json", "sha256": "` + hex.EncodeToString(sum[:]) + `"},
+				{"version": "1.1.0", "path": "system/1.1.0.json"},
+				{"version": "2.0.0-rc.1", "path": "system/2.0.0-rc.1.json"},
+				{"version": "1.0.1", "path": "system/1.0.1.json", "sha256": "00"},
+				{"version": "0.1.0", "path": "../secret.json"}
+			]}
+		]}`,
+		"system/1.0.0.json": systemV1,
+		"system/1.1.0.json": systemV2,
+		"system/1.0.1.json": systemV1,
+	}
+}
+
+func TestClient(t *testing.T) {
+	files := catalogFiles()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path[len("/catalog/"):]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(b))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, location := range []string{ts.URL + "/catalog/", ts.URL + "/catalog/index.json", dir, "file://" + dir} {
+		t.Run(location, func(t *testing.T) {
+			c := &catalog.Client{Location: location}
+			ctx := context.Background()
+
+			idx, err := c.Index(ctx)
+			if err != nil {
+				t.Fatalf("Client.Index() error = %v", err)
+			}
+			p, ok := idx.Package("system")
+			if !ok {
+				t.Fatal("Index.Package() did not find the system package")
+			}
+			if latest, _ := p.Latest(); latest.Version != "1.1.0" {
+				t.Errorf("IndexPackage.Latest() = %s, want 1.1.0", latest.Version)
+			}
+			var sorted []string
+			for _, v := range p.Sorted() {
+				sorted = append(sorted, v.Version)
+			}
+			if want := []string{"2.0.0-rc.1", "1.1.0", "1.0.1", "1.0.0", "0.1.0"}; !reflect.DeepEqual(sorted, want) {
+				t.Errorf("IndexPackage.Sorted() = %v, want %v", sorted, want)
+			}
+
+			pkg, err := c.Package(ctx, "system", "1.0.0")
+			if err != nil {
+				t.Fatalf("Client.Package() error = %v", err)
+			}
+			if pkg.Manifest.Version != "1.0.0" || len(pkg.Layouts) != 1 || pkg.Protoboards[0].Meta.Version != "1.0.0" {
+				t.Errorf("Client.Package() = %+v", pkg)
+			}
+			if pkg, err := c.Package(ctx, "system", ""); err != nil || pkg.Manifest.Version != "1.1.0" {
+				t.Errorf("Client.Package() latest = %s, %v, want 1.1.0", pkg.Manifest.Version, err)
+			}
+			if _, err := c.Package(ctx, "system", "1.0.1"); err == nil {
+				t.Error("Client.Package() expected a checksum error")
+			}
+			if _, err := c.Package(ctx, "system", "9.9.9"); err != chronograf.ErrPackageNotFound {
+				t.Errorf("Client.Package() error = %v, want %v", err, chronograf.ErrPackageNotFound)
+			}
+			if _, err := c.Package(ctx, "system", "2.0.0-rc.1"); err == nil {
+				t.Error("Client.Package() expected an error for a missing package file")
+			}
+		})
+	}
+
+	if _, err := (&catalog.Client{Location: dir}).Package(context.Background(), "system", "0.1.0"); err == nil {
+		t.Error("Client.Package() read a package outside of the catalog directory")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkg     chronograf.Package
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			pkg: chronograf.Package{
+				Manifest:    chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+				Protoboards: []chronograf.Protoboard{{ID: "a"}, {ID: "b"}},
+				Layouts:     []chronograf.Layout{{ID: "a", Application: "system", Measurement: "cpu"}},
+			},
+		},
+		{name: "invalid name", pkg: chronograf.Package{Manifest: chronograf.PackageManifest{Name: "../system", Version: "1.0.0"}}, wantErr: true},
+		{name: "invalid version", pkg: chronograf.Package{Manifest: chronograf.PackageManifest{Name: "system", Version: "latest"}}, wantErr: true},
+		{
+			name: "duplicate protoboard",
+			pkg: chronograf.Package{
+				Manifest:    chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+				Protoboards: []chronograf.Protoboard{{ID: "a"}, {ID: "a"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "layout without measurement",
+			pkg: chronograf.Package{
+				Manifest: chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+				Layouts:  []chronograf.Layout{{ID: "a", Application: "system"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		if err := catalog.Validate(&tt.pkg); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewDiff(t *testing.T) {
+	from := chronograf.Package{
+		Manifest: chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+		Protoboards: []chronograf.Protoboard{
+			{ID: "system", Meta: chronograf.ProtoboardMeta{Name: "System"}},
+			{ID: "disk", Meta: chronograf.ProtoboardMeta{Name: "Disk"}},
+			{ID: "net", Meta: chronograf.ProtoboardMeta{Name: "Net"}},
+		},
+		Layouts: []chronograf.Layout{
+			{ID: "cpu", Application: "system", Measurement: "cpu"},
+		},
+	}
+	to := chronograf.Package{
+		Manifest: chronograf.PackageManifest{Name: "system", Version: "1.1.0"},
+		Protoboards: []chronograf.Protoboard{
+			{ID: "system", Meta: chronograf.ProtoboardMeta{Name: "System", Measurements: []string{"cpu"}}, Data: chronograf.ProtoboardData{Cells: []chronograf.ProtoboardCell{{Name: "CPU"}}}},
+			{ID: "net", Meta: chronograf.ProtoboardMeta{Name: "Net"}},
+			{ID: "mem", Meta: chronograf.ProtoboardMeta{Name: "Memory"}},
+		},
+		Layouts: []chronograf.Layout{
+			{ID: "cpu", Application: "system", Measurement: "cpu", Autoflow: true},
+		},
+	}
+	want := catalog.Diff{
+		Name: "system",
+		From: "1.0.0",
+		To:   "1.1.0",
+		Protoboards: []catalog.Change{
+			{ID: "system", Name: "System", Type: "modified", Fields: []string{"meta", "cells"}},
+			{ID: "mem", Name: "Memory", Type: "added"},
+			{ID: "disk", Name: "Disk", Type: "removed"},
+		},
+		Layouts: []catalog.Change{
+			{ID: "cpu", Name: "cpu", Type: "modified", Fields: []string{"autoflow"}},
+		},
+	}
+	if got := catalog.NewDiff(from, to); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDiff() = %+v, want %+v", got, want)
+	}
+
+	installed := catalog.NewDiff(chronograf.Package{}, to)
+	if installed.From != "" || len(installed.Protoboards) != 3 || installed.Layouts[0].Type != "added" {
+		t.Errorf("NewDiff() of a new package = %+v", installed)
+	}
+}
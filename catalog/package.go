@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/influxdata/chronograf"
+)
+
+// validName matches the names of packages, which are used in URLs and keys
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Validate checks the manifest of the package and that its protoboards and
+// layouts have unique IDs. Protoboards without a version are given the
+// version of the package.
+func Validate(pkg *chronograf.Package) error {
+	if !validName.MatchString(pkg.Manifest.Name) {
+		return fmt.Errorf("%v: invalid name %q", chronograf.ErrPackageInvalid, pkg.Manifest.Name)
+	}
+	if _, err := ParseVersion(pkg.Manifest.Version); err != nil {
+		return fmt.Errorf("%v: %v", chronograf.ErrPackageInvalid, err)
+	}
+
+	ids := map[string]bool{}
+	for i, pb := range pkg.Protoboards {
+		if pb.ID == "" || ids[pb.ID] {
+			return fmt.Errorf("%v: protoboard %d must have a unique ID", chronograf.ErrPackageInvalid, i)
+		}
+		ids[pb.ID] = true
+		if pb.Meta.Version == "" {
+			pkg.Protoboards[i].Meta.Version = pkg.Manifest.Version
+		}
+	}
+	ids = map[string]bool{}
+	for i, l := range pkg.Layouts {
+		if l.ID == "" || ids[l.ID] {
+			return fmt.Errorf("%v: layout %d must have a unique ID", chronograf.ErrPackageInvalid, i)
+		}
+		ids[l.ID] = true
+		if l.Application == "" || l.Measurement == "" {
+			return fmt.Errorf("%v: layout %s must have an app and a measurement", chronograf.ErrPackageInvalid, l.ID)
+		}
+	}
+	return nil
+}
+
+// Change is the change of a protoboard or a layout between two versions of a
+// package
+type Change struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`             // Type is added, removed or modified
+	Fields []string `json:"fields,omitempty"` // Fields are the modified fields
+}
+
+// Diff is the difference between two versions of a package
+type Diff struct {
+	Name        string   `json:"name"`
+	From        string   `json:"from"` // From is the version changed, empty if the package is not installed
+	To          string   `json:"to"`
+	Protoboards []Change `json:"protoboards"`
+	Layouts     []Change `json:"layouts"`
+}
+
+// NewDiff returns the protoboards and layouts added, removed or modified by
+// changing from one version of a package to another
+func NewDiff(from, to chronograf.Package) Diff {
+	d := Diff{
+		Name:        to.Manifest.Name,
+		From:        from.Manifest.Version,
+		To:          to.Manifest.Version,
+		Protoboards: []Change{},
+		Layouts:     []Change{},
+	}
+
+	old := map[string]chronograf.Protoboard{}
+	for _, pb := range from.Protoboards {
+		old[pb.ID] = pb
+	}
+	for _, pb := range to.Protoboards {
+		prev, ok := old[pb.ID]
+		delete(old, pb.ID)
+		if !ok {
+			d.Protoboards = append(d.Protoboards, Change{ID: pb.ID, Name: pb.Meta.Name, Type: "added"})
+			continue
+		}
+		if fields := changedFields(map[string][2]interface{}{
+			"meta":      {prev.Meta, pb.Meta},
+			"cells":     {prev.Data.Cells, pb.Data.Cells},
+			"templates": {prev.Data.Templates, pb.Data.Templates},
+		}); len(fields) > 0 {
+			d.Protoboards = append(d.Protoboards, Change{ID: pb.ID, Name: pb.Meta.Name, Type: "modified", Fields: fields})
+		}
+	}
+	for _, pb := range from.Protoboards {
+		if _, ok := old[pb.ID]; ok {
+			d.Protoboards = append(d.Protoboards, Change{ID: pb.ID, Name: pb.Meta.Name, Type: "removed"})
+		}
+	}
+
+	oldLayouts := map[string]chronograf.Layout{}
+	for _, l := range from.Layouts {
+		oldLayouts[l.ID] = l
+	}
+	for _, l := range to.Layouts {
+		prev, ok := oldLayouts[l.ID]
+		delete(oldLayouts, l.ID)
+		if !ok {
+			d.Layouts = append(d.Layouts, Change{ID: l.ID, Name: l.Measurement, Type: "added"})
+			continue
+		}
+		if fields := changedFields(map[string][2]interface{}{
+			"app":         {prev.Application, l.Application},
+			"measurement": {prev.Measurement, l.Measurement},
+			"autoflow":    {prev.Autoflow, l.Autoflow},
+			"cells":       {prev.Cells, l.Cells},
+		}); len(fields) > 0 {
+			d.Layouts = append(d.Layouts, Change{ID: l.ID, Name: l.Measurement, Type: "modified", Fields: fields})
+		}
+	}
+	for _, l := range from.Layouts {
+		if _, ok := oldLayouts[l.ID]; ok {
+			d.Layouts = append(d.Layouts, Change{ID: l.ID, Name: l.Measurement, Type: "removed"})
+		}
+	}
+	return d
+}
+
+// changedFields returns the names of the fields whose values differ, sorted
+func changedFields(fields map[string][2]interface{}) []string {
+	changed := []string{}
+	for _, name := range []string{"app", "measurement", "autoflow", "meta", "cells", "templates"} {
+		if v, ok := fields[name]; ok && !reflect.DeepEqual(v[0], v[1]) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
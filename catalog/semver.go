@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version such as 1.2.3 or 2.0.0-beta.1
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 []string // Pre are the dot separated identifiers of the pre-release
+}
+
+// ParseVersion parses a semantic version. A leading v is accepted and build
+// metadata after a + is ignored.
+func ParseVersion(s string) (Version, error) {
+	v := Version{}
+	str := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(str, '+'); i >= 0 {
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '-'); i >= 0 {
+		for _, id := range strings.Split(str[i+1:], ".") {
+			if id == "" {
+				return Version{}, fmt.Errorf("invalid version %q: empty pre-release identifier", s)
+			}
+			v.Pre = append(v.Pre, id)
+		}
+		str = str[:i]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid version %q: must be MAJOR.MINOR.PATCH", s)
+	}
+	for i, n := range []*int{&v.Major, &v.Minor, &v.Patch} {
+		num, err := strconv.Atoi(parts[i])
+		if err != nil || num < 0 || (len(parts[i]) > 1 && parts[i][0] == '0') {
+			return Version{}, fmt.Errorf("invalid version %q: %q is not a number", s, parts[i])
+		}
+		*n = num
+	}
+	return v, nil
+}
+
+// String returns the version without a leading v
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Pre) > 0 {
+		s += "-" + strings.Join(v.Pre, ".")
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 if v precedes, equals or follows o
+func (v Version) Compare(o Version) int {
+	for _, d := range [][2]int{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c := compareInts(d[0], d[1]); c != 0 {
+			return c
+		}
+	}
+
+	// a pre-release precedes its release
+	switch {
+	case len(v.Pre) == 0 && len(o.Pre) == 0:
+		return 0
+	case len(v.Pre) == 0:
+		return 1
+	case len(o.Pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Pre) && i < len(o.Pre); i++ {
+		if c := comparePre(v.Pre[i], o.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(v.Pre), len(o.Pre))
+}
+
+// comparePre compares pre-release identifiers. Numeric identifiers are
+// compared numerically and precede alphanumeric ones.
+func comparePre(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package catalog_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/chronograf/catalog"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "1.2.3", want: "1.2.3"},
+		{in: "v0.10.0", want: "0.10.0"},
+		{in: "2.0.0-beta.1+build.5", want: "2.0.0-beta.1"},
+		{in: "1.2", wantErr: true},
+		{in: "1.02.3", wantErr: true},
+		{in: "1.2.x", wantErr: true},
+		{in: "1.2.3-", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := catalog.ParseVersion(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseVersion(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseVersion(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// ordered as in the semantic versioning specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, _ := catalog.ParseVersion(ordered[i])
+			b, _ := catalog.ParseVersion(ordered[j])
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+			if got := a.Compare(b); got != want {
+				t.Errorf("%s.Compare(%s) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+)
+
+// Ensure the stores of installed packages implement the chronograf stores.
+var (
+	_ chronograf.ProtoboardsStore = &ProtoboardsStore{}
+	_ chronograf.LayoutsStore     = &LayoutsStore{}
+)
+
+// ProtoboardsStore serves the protoboards of the packages installed in an
+// organization
+type ProtoboardsStore struct {
+	Packages     chronograf.PackagesStore
+	Organization string
+}
+
+// All returns the protoboards of the installed packages
+func (s *ProtoboardsStore) All(ctx context.Context) ([]chronograf.Protoboard, error) {
+	pkgs, err := s.Packages.All(ctx, s.Organization)
+	if err != nil {
+		return nil, err
+	}
+	protoboards := []chronograf.Protoboard{}
+	for _, pkg := range pkgs {
+		protoboards = append(protoboards, pkg.Protoboards...)
+	}
+	return protoboards, nil
+}
+
+// Get returns the protoboard with ID of the installed packages
+func (s *ProtoboardsStore) Get(ctx context.Context, ID string) (chronograf.Protoboard, error) {
+	protoboards, err := s.All(ctx)
+	if err != nil {
+		return chronograf.Protoboard{}, err
+	}
+	for _, pb := range protoboards {
+		if pb.ID == ID {
+			return pb, nil
+		}
+	}
+	return chronograf.Protoboard{}, chronograf.ErrProtoboardNotFound
+}
+
+// LayoutsStore serves the layouts of the packages installed in an
+// organization. The layouts are changed by changing the installed packages.
+type LayoutsStore struct {
+	Packages     chronograf.PackagesStore
+	Organization string
+}
+
+// All returns the layouts of the installed packages
+func (s *LayoutsStore) All(ctx context.Context) ([]chronograf.Layout, error) {
+	pkgs, err := s.Packages.All(ctx, s.Organization)
+	if err != nil {
+		return nil, err
+	}
+	layouts := []chronograf.Layout{}
+	for _, pkg := range pkgs {
+		layouts = append(layouts, pkg.Layouts...)
+	}
+	return layouts, nil
+}
+
+// Add is not supported, layouts of packages are read-only
+func (s *LayoutsStore) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	return chronograf.Layout{}, chronograf.ErrLayoutReadOnly
+}
+
+// Delete is not supported, layouts of packages are read-only
+func (s *LayoutsStore) Delete(ctx context.Context, layout chronograf.Layout) error {
+	return s.readOnly(ctx, layout.ID)
+}
+
+// Get returns the layout with ID of the installed packages
+func (s *LayoutsStore) Get(ctx context.Context, ID string) (chronograf.Layout, error) {
+	layouts, err := s.All(ctx)
+	if err != nil {
+		return chronograf.Layout{}, err
+	}
+	for _, l := range layouts {
+		if l.ID == ID {
+			return l, nil
+		}
+	}
+	return chronograf.Layout{}, chronograf.ErrLayoutNotFound
+}
+
+// Update is not supported, layouts of packages are read-only
+func (s *LayoutsStore) Update(ctx context.Context, layout chronograf.Layout) error {
+	return s.readOnly(ctx, layout.ID)
+}
+
+func (s *LayoutsStore) readOnly(ctx context.Context, ID string) error {
+	if _, err := s.Get(ctx, ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
@@ -16,6 +16,7 @@ import (
 // General errors.
 const (
 	ErrUpstreamTimeout                 = Error("request to backend timed out")
+	ErrResponseTooLarge                = Error("response of backend exceeds the maximum size")
 	ErrSourceNotFound                  = Error("source not found")
 	ErrServerNotFound                  = Error("server not found")
 	ErrLayoutNotFound                  = Error("layout not found")
@@ -23,7 +24,10 @@ const (
 	ErrDashboardNotFound               = Error("dashboard not found")
 	ErrUserNotFound                    = Error("user not found")
 	ErrLayoutInvalid                   = Error("layout is invalid")
+	ErrLayoutReadOnly                  = Error("layout is read-only")
 	ErrProtoboardInvalid               = Error("protoboard is invalid")
+	ErrPackageNotFound                 = Error("package not found")
+	ErrPackageInvalid                  = Error("package is invalid")
 	ErrDashboardInvalid                = Error("dashboard is invalid")
 	ErrSourceInvalid                   = Error("source is invalid")
 	ErrServerInvalid                   = Error("server is invalid")
@@ -220,15 +224,17 @@ type Template struct {
 
 // Query retrieves a Response from a TimeSeries.
 type Query struct {
-	Command  string   `json:"query"`              // Command is the query itself
-	DB       string   `json:"db,omitempty"`       // DB is optional and if empty will not be used.
-	RP       string   `json:"rp,omitempty"`       // RP is a retention policy and optional; if empty will not be used.
-	Epoch    string   `json:"epoch,omitempty"`    // Epoch is the time format for the return results
-	Wheres   []string `json:"wheres,omitempty"`   // Wheres restricts the query to certain attributes
-	GroupBys []string `json:"groupbys,omitempty"` // GroupBys collate the query by these tags
-	Label    string   `json:"label,omitempty"`    // Label is the Y-Axis label for the data
-	Range    *Range   `json:"range,omitempty"`    // Range is the default Y-Axis range for the data
-	UUID     string   `json:"uuid,omitempty"`     // Indentifier from client to be added to the result
+	Command   string   `json:"query"`               // Command is the query itself
+	DB        string   `json:"db,omitempty"`        // DB is optional and if empty will not be used.
+	RP        string   `json:"rp,omitempty"`        // RP is a retention policy and optional; if empty will not be used.
+	Epoch     string   `json:"epoch,omitempty"`     // Epoch is the time format for the return results
+	Wheres    []string `json:"wheres,omitempty"`    // Wheres restricts the query to certain attributes
+	GroupBys  []string `json:"groupbys,omitempty"`  // GroupBys collate the query by these tags
+	Label     string   `json:"label,omitempty"`     // Label is the Y-Axis label for the data
+	Range     *Range   `json:"range,omitempty"`     // Range is the default Y-Axis range for the data
+	UUID      string   `json:"uuid,omitempty"`      // Indentifier from client to be added to the result
+	Chunked   bool     `json:"chunked,omitempty"`   // Chunked streams the results in chunks as they are read
+	ChunkSize int      `json:"chunkSize,omitempty"` // ChunkSize is the maximum number of points of a chunk; zero uses the default of the source
 }
 
 // DashboardQuery includes state for the query builder.  This is a transition
@@ -261,27 +267,30 @@ type Response interface {
 
 // Source is connection information to a time-series data store.
 type Source struct {
-	ID                 int    `json:"id,string"`                    // ID is the unique ID of the source
-	Name               string `json:"name"`                         // Name is the user-defined name for the source
-	Type               string `json:"type,omitempty"`               // Type specifies which kinds of source (enterprise vs oss)
-	Username           string `json:"username,omitempty"`           // Username is the username to connect to the source
-	Password           string `json:"password,omitempty"`           // Password is in CLEARTEXT
-	SharedSecret       string `json:"sharedSecret,omitempty"`       // ShareSecret is the optional signing secret for Influx JWT authorization
-	ClusterID          string `json:"clusterId,omitempty"`          // ClusterID is the cluster ID for InfluxDB Cloud Dedicated sources
-	AccountID          string `json:"accountId,omitempty"`          // AccountID is the account ID for InfluxDB Cloud Dedicated sources
-	ManagementToken    string `json:"managementToken,omitempty"`    // ManagementToken is the management token for InfluxDB Cloud Dedicated sources
-	DatabaseToken      string `json:"databaseToken,omitempty"`      // DatabaseToken is the database token for InfluxDB Cloud Dedicated or other InfluxDB 3 sources
-	TagsCSVPath        string `json:"tagsCSVPath,omitempty"`        // TagsCSVPath is the path to a directory containing CSV files (per db) with tags for InfluxDB Cloud Dedicated sources
-	URL                string `json:"url"`                          // URL are the connections to the source
-	MetaURL            string `json:"metaUrl,omitempty"`            // MetaURL is the url for the meta node
-	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"` // InsecureSkipVerify as true means any certificate presented by the source is accepted.
-	Default            bool   `json:"default"`                      // Default specifies the default source for the application
-	Telegraf           string `json:"telegraf"`                     // Telegraf is the db telegraf is written to.  By default it is "telegraf"
-	Organization       string `json:"organization"`                 // Organization is the organization ID that resource belongs to
-	Role               string `json:"role,omitempty"`               // Not Currently Used. Role is the name of the minimum role that a user must possess to access the resource.
-	DefaultRP          string `json:"defaultRP"`                    // DefaultRP is the default retention policy used in database queries to this source
-	DefaultDB          string `json:"defaultDB,omitempty"`          // DefaultDB is the default database used in queries for InfluxDB Cloud Dedicated when database list is not available
-	Version            string `json:"version,omitempty"`            // Version of influxdb
+	ID                   int    `json:"id,string"`                      // ID is the unique ID of the source
+	Name                 string `json:"name"`                           // Name is the user-defined name for the source
+	Type                 string `json:"type,omitempty"`                 // Type specifies which kinds of source (enterprise vs oss)
+	Username             string `json:"username,omitempty"`             // Username is the username to connect to the source
+	Password             string `json:"password,omitempty"`             // Password is in CLEARTEXT
+	SharedSecret         string `json:"sharedSecret,omitempty"`         // ShareSecret is the optional signing secret for Influx JWT authorization
+	ClusterID            string `json:"clusterId,omitempty"`            // ClusterID is the cluster ID for InfluxDB Cloud Dedicated sources
+	AccountID            string `json:"accountId,omitempty"`            // AccountID is the account ID for InfluxDB Cloud Dedicated sources
+	ManagementToken      string `json:"managementToken,omitempty"`      // ManagementToken is the management token for InfluxDB Cloud Dedicated sources
+	DatabaseToken        string `json:"databaseToken,omitempty"`        // DatabaseToken is the database token for InfluxDB Cloud Dedicated or other InfluxDB 3 sources
+	TagsCSVPath          string `json:"tagsCSVPath,omitempty"`          // TagsCSVPath is the path to a directory containing CSV files (per db) with tags for InfluxDB Cloud Dedicated sources
+	URL                  string `json:"url"`                            // URL are the connections to the source
+	MetaURL              string `json:"metaUrl,omitempty"`              // MetaURL is the url for the meta node
+	InsecureSkipVerify   bool   `json:"insecureSkipVerify,omitempty"`   // InsecureSkipVerify as true means any certificate presented by the source is accepted.
+	Default              bool   `json:"default"`                        // Default specifies the default source for the application
+	Telegraf             string `json:"telegraf"`                       // Telegraf is the db telegraf is written to.  By default it is "telegraf"
+	Organization         string `json:"organization"`                   // Organization is the organization ID that resource belongs to
+	Role                 string `json:"role,omitempty"`                 // Not Currently Used. Role is the name of the minimum role that a user must possess to access the resource.
+	DefaultRP            string `json:"defaultRP"`                      // DefaultRP is the default retention policy used in database queries to this source
+	DefaultDB            string `json:"defaultDB,omitempty"`            // DefaultDB is the default database used in queries for InfluxDB Cloud Dedicated when database list is not available
+	Version              string `json:"version,omitempty"`              // Version of influxdb
+	QueryTimeout         int    `json:"queryTimeout,omitempty"`         // QueryTimeout is the maximum duration of queries to the source in seconds; zero uses the server default
+	MaxConcurrentQueries int    `json:"maxConcurrentQueries,omitempty"` // MaxConcurrentQueries is the maximum number of in-flight queries to the source; zero uses the server default
+	MaxResponseBytes     int64  `json:"maxResponseBytes,omitempty"`     // MaxResponseBytes is the maximum size of the responses to queries of the source; zero uses the server default
 }
 
 // SourcesStore stores connection information for a `TimeSeries`
@@ -530,6 +539,22 @@ type Measurement struct {
 	Name string `json:"name"` // a unique string identifier for the measurement
 }
 
+// ContinuousQuery represents a continuous query of a database in a time series source
+type ContinuousQuery struct {
+	Name          string `json:"name"`                    // a unique string identifier for the continuous query within its database
+	Query         string `json:"query"`                   // the SELECT ... INTO statement run by the continuous query
+	ResampleEvery string `json:"resampleEvery,omitempty"` // the optional interval at which the continuous query runs
+	ResampleFor   string `json:"resampleFor,omitempty"`   // the optional time range covered by each run
+}
+
+// Subscription represents a subscription to the writes of a retention policy in a time series source
+type Subscription struct {
+	Name            string   `json:"name"`            // a unique string identifier for the subscription within its retention policy
+	RetentionPolicy string   `json:"retentionPolicy"` // the retention policy whose writes are sent to the destinations
+	Mode            string   `json:"mode"`            // ALL sends writes to all destinations, ANY to one of them
+	Destinations    []string `json:"destinations"`    // the URLs the writes are sent to
+}
+
 // Databases represents a databases in a time series source
 type Databases interface {
 	// AllDB lists all databases in the current data source
@@ -552,6 +577,20 @@ type Databases interface {
 
 	// GetMeasurements lists measurements in the current data source
 	GetMeasurements(ctx context.Context, db string, limit, offset int) ([]Measurement, error)
+
+	// AllCQ lists all continuous queries of a database in the current data source
+	AllCQ(context.Context, string) ([]ContinuousQuery, error)
+	// CreateCQ creates a continuous query of a database in the current data source
+	CreateCQ(context.Context, string, *ContinuousQuery) (*ContinuousQuery, error)
+	// DropCQ drops a continuous query of a database in the current data source
+	DropCQ(context.Context, string, string) error
+
+	// AllSubscriptions lists all subscriptions of a database in the current data source
+	AllSubscriptions(context.Context, string) ([]Subscription, error)
+	// CreateSubscription creates a subscription of a database in the current data source
+	CreateSubscription(context.Context, string, *Subscription) (*Subscription, error)
+	// DropSubscription drops a subscription of a retention policy of a database in the current data source
+	DropSubscription(ctx context.Context, db, rp, name string) error
 }
 
 // AnnotationTags describes a set of user-defined tags associated with an Annotation
@@ -626,11 +665,67 @@ type DashboardID int
 
 // Dashboard represents all visual and query data for a dashboard
 type Dashboard struct {
-	ID           DashboardID     `json:"id"`
-	Cells        []DashboardCell `json:"cells"`
-	Templates    []Template      `json:"templates"`
-	Name         string          `json:"name"`
-	Organization string          `json:"organization"` // Organization is the organization ID that resource belongs to
+	ID           DashboardID      `json:"id"`
+	Cells        []DashboardCell  `json:"cells"`
+	Templates    []Template       `json:"templates"`
+	Name         string           `json:"name"`
+	Organization string           `json:"organization"` // Organization is the organization ID that resource belongs to
+	ACL          *DashboardACL    `json:"-"`            // ACL optionally restricts who may view or edit the dashboard; managed through its own endpoint
+	Shares       []DashboardShare `json:"-"`            // Shares grant anonymous read-only access to the dashboard; managed through their own endpoint
+	Managed      *DashboardSync   `json:"-"`            // Managed is set if the dashboard is synced from a definition file
+}
+
+// Dashboard access levels granted by a DashboardACL
+const (
+	// DashboardAccessView allows reading the dashboard, its cells and templates
+	DashboardAccessView = "view"
+	// DashboardAccessEdit allows reading and changing the dashboard
+	DashboardAccessEdit = "edit"
+)
+
+// DashboardACL restricts access to a dashboard to its owner and the users
+// and roles that were explicitly granted access. Organization admins and
+// super admins are never restricted by an ACL.
+type DashboardACL struct {
+	Owner uint64                `json:"owner,string,omitempty"` // Owner is the ID of the user that owns the dashboard; the owner always has edit access
+	Users []DashboardUserAccess `json:"users"`                  // Users are access grants to specific users
+	Roles []DashboardRoleAccess `json:"roles"`                  // Roles are access grants to every user with at least the role in the organization
+}
+
+// DashboardUserAccess grants a user access to a dashboard
+type DashboardUserAccess struct {
+	ID     uint64 `json:"id,string"` // ID is the ID of the chronograf user
+	Access string `json:"access"`    // Access is either view or edit
+}
+
+// DashboardRoleAccess grants the users with a role access to a dashboard
+type DashboardRoleAccess struct {
+	Name   string `json:"name"`   // Name is the name of the chronograf role, e.g. viewer
+	Access string `json:"access"` // Access is either view or edit
+}
+
+// DashboardShare grants anonymous read-only access to a dashboard and the
+// queries of its cells through a signed token. A share stops working when it
+// expires or when it is removed from the dashboard.
+type DashboardShare struct {
+	ID        string    `json:"id"`                         // ID is the unique ID of the share within the dashboard
+	SourceID  string    `json:"sourceID"`                   // SourceID is the source queried by cells that do not specify one
+	Lower     string    `json:"lower"`                      // Lower is the lower bound of the shared time range, e.g. now() - 1h
+	Upper     string    `json:"upper,omitempty"`            // Upper is the upper bound of the shared time range; empty means now()
+	CreatedBy uint64    `json:"createdBy,string,omitempty"` // CreatedBy is the ID of the user that created the share
+	CreatedAt time.Time `json:"createdAt"`                  // CreatedAt is the time the share was created
+	ExpiresAt time.Time `json:"expiresAt"`                  // ExpiresAt is the time after which the share is no longer valid
+}
+
+// DashboardSync records the definition file a managed dashboard is synced
+// from. Changes made to a managed dashboard outside of its definition are
+// either refused or reported as drift.
+type DashboardSync struct {
+	Slug           string    `json:"slug"`     // Slug is the stable name of the dashboard within its organization
+	File           string    `json:"file"`     // File is the definition file relative to the sync directory
+	DefinitionHash string    `json:"-"`        // DefinitionHash is the hash of the definition last synced
+	ContentHash    string    `json:"-"`        // ContentHash is the hash of the dashboard content as last synced
+	SyncedAt       time.Time `json:"syncedAt"` // SyncedAt is the time the dashboard was last synced from its definition
 }
 
 // UnmarshalJSON unmarshals a string ID into a DashboardID (int).
@@ -784,8 +879,14 @@ type Layout struct {
 type LayoutsStore interface {
 	// All returns all dashboards in the store
 	All(context.Context) ([]Layout, error)
+	// Add creates a new layout in the LayoutsStore
+	Add(context.Context, Layout) (Layout, error)
+	// Delete the layout from the store
+	Delete(context.Context, Layout) error
 	// Get retrieves Layout if `ID` exists
 	Get(ctx context.Context, ID string) (Layout, error)
+	// Update the layout in the store.
+	Update(context.Context, Layout) error
 }
 
 // ProtoboardMeta is the metadata of a Protoboard
@@ -842,6 +943,42 @@ type ProtoboardsStore interface {
 	Get(ctx context.Context, ID string) (Protoboard, error)
 }
 
+// PackageManifest describes a version of a package of protoboards and layouts
+type PackageManifest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"` // Version is the semantic version of the package
+	Description string `json:"description,omitempty"`
+	Author      string `json:"author,omitempty"`
+	License     string `json:"license,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Package is a version of a set of protoboards and layouts distributed through a catalog
+type Package struct {
+	Manifest    PackageManifest `json:"manifest"`
+	Protoboards []Protoboard    `json:"protoboards"`
+	Layouts     []Layout        `json:"layouts"`
+}
+
+// InstalledPackage is a package installed in an organization
+type InstalledPackage struct {
+	Package
+	Organization string    `json:"organization"`
+	InstalledAt  time.Time `json:"installedAt"`
+}
+
+// PackagesStore stores the packages installed in organizations
+type PackagesStore interface {
+	// All returns the packages installed in the organization
+	All(ctx context.Context, orgID string) ([]InstalledPackage, error)
+	// Get returns the package with name installed in the organization
+	Get(ctx context.Context, orgID, name string) (InstalledPackage, error)
+	// Put installs the package in its organization, replacing an installed version
+	Put(ctx context.Context, pkg InstalledPackage) error
+	// Delete uninstalls the package from its organization
+	Delete(ctx context.Context, pkg InstalledPackage) error
+}
+
 // MappingWildcard is the wildcard value for mappings
 const MappingWildcard string = "*"
 
@@ -1,9 +1,10 @@
 package enterprise
 
 import (
-	"container/ring"
+	"io"
 	"net/url"
 	"strings"
+	"sync"
 
 	"context"
 
@@ -12,6 +13,7 @@ import (
 )
 
 var _ chronograf.TimeSeries = &Client{}
+var _ influx.QueryStreamer = &Client{}
 
 // Ctrl represents administrative controls over an Influx Enterprise cluster
 type Ctrl interface {
@@ -39,14 +41,23 @@ type Ctrl interface {
 // Client is a device for retrieving time series data from an Influx Enterprise
 // cluster. It is configured using the addresses of one or more meta node URLs.
 // Data node URLs are retrieved automatically from the meta nodes and queries
-// are appropriately load balanced across the cluster.
+// are appropriately load balanced across the healthy data nodes of the
+// cluster. Unhealthy data nodes are ejected until a health check, or a query
+// that reaches them anyway, succeeds again.
 type Client struct {
 	Ctrl
 	UsersStore chronograf.UsersStore
 	RolesStore chronograf.RolesStore
 	Logger     chronograf.Logger
+	// Fallback receives queries and writes when no data node is healthy
+	Fallback chronograf.TimeSeries
+	// Limits are the default limits of the queries to the data nodes
+	Limits influx.QueryLimits
 
-	dataNodes *ring.Ring
+	mu        sync.Mutex
+	src       *chronograf.Source
+	dataNodes []*dataNode
+	next      int
 	opened    bool
 }
 
@@ -70,11 +81,9 @@ func NewClientWithTimeSeries(lg chronograf.Logger, mu string, authorizer influx.
 		},
 	}
 
-	c.dataNodes = ring.New(len(series))
-
-	for _, s := range series {
-		c.dataNodes.Value = s
-		c.dataNodes = c.dataNodes.Next()
+	c.dataNodes = make([]*dataNode, len(series))
+	for i, s := range series {
+		c.dataNodes[i] = &dataNode{ts: s, healthy: true}
 	}
 
 	return c, nil
@@ -106,50 +115,162 @@ func NewClientWithURL(mu string, authorizer influx.Authorizer, tls bool, insecur
 	}, nil
 }
 
-// Connect prepares a Client to process queries. It must be called prior to calling Query
+// Connect prepares a Client to process queries. It must be called prior to
+// calling Query. The data nodes are discovered by the first call only; they
+// are updated by Refresh.
 func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
+	c.mu.Lock()
 	c.opened = true
 	// return early if we already have dataNodes
-	if c.dataNodes != nil {
+	if c.dataNodes != nil || c.src != nil {
+		c.mu.Unlock()
 		return nil
 	}
-	cluster, err := c.Ctrl.ShowCluster(ctx)
-	if err != nil {
-		return err
+	c.src = &chronograf.Source{}
+	*c.src = *src
+	c.mu.Unlock()
+	return c.Refresh(ctx)
+}
+
+// Query retrieves timeseries information pertaining to a specified query. It
+// can be cancelled by using a provided context. Queries that do not change
+// data are retried on the next healthy data node when a data node cannot be
+// reached.
+func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+	if !c.isOpened() {
+		return nil, chronograf.ErrUninitialized
+	}
+	retry := isIdempotent(q.Command)
+	tried := map[*dataNode]bool{}
+	var err error
+	for node := c.nextDataNode(tried); node != nil; node = c.nextDataNode(tried) {
+		var res chronograf.Response
+		res, err = node.ts.Query(ctx, q)
+		if !c.observe(ctx, node, err) || !retry {
+			return res, err
+		}
+		tried[node] = true
 	}
 
-	c.dataNodes = ring.New(len(cluster.DataNodes))
-	for _, dn := range cluster.DataNodes {
-		cl := &influx.Client{
-			Logger: c.Logger,
+	if c.Fallback != nil {
+		return c.Fallback.Query(ctx, q)
+	}
+	if err == nil {
+		err = ErrNoDataNodes
+	}
+	return nil, err
+}
+
+// QueryStream streams a chunked query from the next healthy data node. Like
+// Query, queries that do not change data are retried on the next healthy data
+// node when a data node cannot be reached.
+func (c *Client) QueryStream(ctx context.Context, q chronograf.Query) (io.ReadCloser, error) {
+	if !c.isOpened() {
+		return nil, chronograf.ErrUninitialized
+	}
+	retry := isIdempotent(q.Command)
+	tried := map[*dataNode]bool{}
+	var err error
+	for node := c.nextDataNode(tried); node != nil; node = c.nextDataNode(tried) {
+		st, ok := node.ts.(influx.QueryStreamer)
+		if !ok {
+			return nil, influx.ErrStreamingUnsupported
+		}
+		var body io.ReadCloser
+		body, err = st.QueryStream(ctx, q)
+		if !c.observe(ctx, node, err) || !retry {
+			return body, err
 		}
-		dataSrc := &chronograf.Source{}
-		*dataSrc = *src
-		dataSrc.URL = dn.HTTPAddr
-		if err := cl.Connect(ctx, dataSrc); err != nil {
+		tried[node] = true
+	}
+
+	if st, ok := c.Fallback.(influx.QueryStreamer); ok {
+		return st.QueryStream(ctx, q)
+	} else if c.Fallback != nil {
+		return nil, influx.ErrStreamingUnsupported
+	}
+	if err == nil {
+		err = ErrNoDataNodes
+	}
+	return nil, err
+}
+
+// DataNodeResponse is the response of a data node of the cluster to a query
+type DataNodeResponse struct {
+	ID       uint64
+	URL      string
+	Response chronograf.Response
+	Err      error
+}
+
+// QueryDataNodes executes the query on every data node of the cluster in
+// parallel, whatever their health. The responses are in the order of the
+// data nodes.
+func (c *Client) QueryDataNodes(ctx context.Context, q chronograf.Query) []DataNodeResponse {
+	c.mu.Lock()
+	nodes := append([]*dataNode{}, c.dataNodes...)
+	c.mu.Unlock()
+
+	responses := make([]DataNodeResponse, len(nodes))
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		responses[i] = DataNodeResponse{ID: n.id, URL: n.url}
+		if n.ts == nil {
+			responses[i].Err = n.err
+			if n.err == nil {
+				responses[i].Err = ErrNoDataNodes
+			}
 			continue
 		}
-		c.dataNodes.Value = cl
-		c.dataNodes = c.dataNodes.Next()
+		wg.Add(1)
+		go func(i int, n *dataNode) {
+			defer wg.Done()
+			responses[i].Response, responses[i].Err = n.ts.Query(ctx, q)
+			c.observe(ctx, n, responses[i].Err)
+		}(i, n)
 	}
-	return nil
+	wg.Wait()
+	return responses
 }
 
-// Query retrieves timeseries information pertaining to a specified query. It
-// can be cancelled by using a provided context.
-func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
-	if !c.opened {
-		return nil, chronograf.ErrUninitialized
+// QueryDataNode executes the query on the data node with the ID, whatever
+// its health
+func (c *Client) QueryDataNode(ctx context.Context, id uint64, q chronograf.Query) (chronograf.Response, error) {
+	c.mu.Lock()
+	var node *dataNode
+	for _, n := range c.dataNodes {
+		if n.id == id {
+			node = n
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	if node == nil {
+		return nil, ErrDataNodeNotFound
 	}
-	return c.nextDataNode().Query(ctx, q)
+	if node.ts == nil {
+		return nil, node.err
+	}
+	res, err := node.ts.Query(ctx, q)
+	c.observe(ctx, node, err)
+	return res, err
 }
 
-// Write records points into a time series
+// Write records points into a time series. Writes are not retried.
 func (c *Client) Write(ctx context.Context, points []chronograf.Point) error {
-	if !c.opened {
+	if !c.isOpened() {
 		return chronograf.ErrUninitialized
 	}
-	return c.nextDataNode().Write(ctx, points)
+	node := c.nextDataNode(nil)
+	if node == nil && c.Fallback != nil {
+		return c.Fallback.Write(ctx, points)
+	} else if node == nil {
+		return ErrNoDataNodes
+	}
+	err := node.ts.Write(ctx, points)
+	c.observe(ctx, node, err)
+	return err
 }
 
 // Users is the interface to the users within Influx Enterprise
@@ -204,12 +325,6 @@ func (c *Client) Permissions(context.Context) chronograf.Permissions {
 	}
 }
 
-// nextDataNode retrieves the next available data node
-func (c *Client) nextDataNode() chronograf.TimeSeries {
-	c.dataNodes = c.dataNodes.Next()
-	return c.dataNodes.Value.(chronograf.TimeSeries)
-}
-
 // parseMetaURL constructs a url from either a host:port combination or a
 // scheme://host:port combo. The optional TLS parameter takes precedence over
 // any TLS preference found in the provided URL
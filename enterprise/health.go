@@ -0,0 +1,302 @@
+package enterprise
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/influxql"
+)
+
+// ErrNoDataNodes is returned when a cluster has no data node to send a
+// request to
+var ErrNoDataNodes = errors.New("no data nodes available")
+
+// ErrDataNodeNotFound is returned when a cluster has no data node with the
+// requested ID
+var ErrDataNodeNotFound = errors.New("data node not found")
+
+const (
+	// DefaultHealthCheckInterval is the default interval of data node health checks
+	DefaultHealthCheckInterval = 10 * time.Second
+	// DefaultRefreshInterval is the default interval at which the data nodes of
+	// a cluster are refreshed from its meta nodes
+	DefaultRefreshInterval = time.Minute
+)
+
+// pinger is implemented by time series that can be health checked
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// dataNode is a data node of the cluster and its health
+type dataNode struct {
+	id        uint64
+	url       string
+	ts        chronograf.TimeSeries // ts is nil if the data node could not be connected
+	healthy   bool
+	err       error
+	lastCheck time.Time
+}
+
+// DataNodeHealth is the health of a data node as last observed by a Client
+type DataNodeHealth struct {
+	ID        uint64    `json:"id,omitempty"`
+	URL       string    `json:"url"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	LastCheck time.Time `json:"lastCheck"`
+}
+
+// DataNodes returns the health of the data nodes of the cluster
+func (c *Client) DataNodes() []DataNodeHealth {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nodes := make([]DataNodeHealth, len(c.dataNodes))
+	for i, n := range c.dataNodes {
+		nodes[i] = DataNodeHealth{
+			ID:        n.id,
+			URL:       n.url,
+			Healthy:   n.healthy,
+			LastCheck: n.lastCheck,
+		}
+		if n.err != nil {
+			nodes[i].Error = n.err.Error()
+		}
+	}
+	return nodes
+}
+
+// Monitor checks the health of the data nodes every checkInterval and
+// refreshes the data nodes of the cluster every refreshInterval until ctx is
+// done. Intervals that are not positive are replaced with their default.
+func (c *Client) Monitor(ctx context.Context, checkInterval, refreshInterval time.Duration) {
+	if checkInterval <= 0 {
+		checkInterval = DefaultHealthCheckInterval
+	}
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultRefreshInterval
+	}
+	check := time.NewTicker(checkInterval)
+	defer check.Stop()
+	refresh := time.NewTicker(refreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-check.C:
+			c.HealthCheck(ctx)
+		case <-refresh.C:
+			if err := c.Refresh(ctx); err != nil {
+				c.log().
+					WithField("component", "enterprise").
+					Error("Unable to refresh data nodes: ", err)
+			}
+		}
+	}
+}
+
+// Refresh replaces the data nodes with those currently reported by the meta
+// nodes. Known data nodes keep their health; data nodes that cannot be
+// connected are kept unhealthy so that they are retried by health checks.
+func (c *Client) Refresh(ctx context.Context) error {
+	cluster, err := c.Ctrl.ShowCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	known := map[string]*dataNode{}
+	for _, n := range c.dataNodes {
+		known[n.url] = n
+	}
+	nodes := make([]*dataNode, 0, len(cluster.DataNodes))
+	for _, dn := range cluster.DataNodes {
+		u := dataNodeURL(dn)
+		if n, ok := known[u]; ok && n.ts != nil {
+			n.id = dn.ID
+			nodes = append(nodes, n)
+			continue
+		}
+		n := &dataNode{id: dn.ID, url: u, healthy: true}
+		if n.ts, n.err = c.connectDataNode(ctx, u); n.err != nil {
+			n.healthy = false
+			c.log().
+				WithField("component", "enterprise").
+				WithField("data_node", u).
+				Error("Unable to connect to data node: ", n.err)
+		}
+		nodes = append(nodes, n)
+	}
+	c.dataNodes = nodes
+	if c.next >= len(nodes) {
+		c.next = 0
+	}
+	return nil
+}
+
+// HealthCheck pings all data nodes, ejecting those that fail and re-admitting
+// those that recovered.
+func (c *Client) HealthCheck(ctx context.Context) {
+	c.mu.Lock()
+	nodes := append([]*dataNode{}, c.dataNodes...)
+	c.mu.Unlock()
+
+	for _, n := range nodes {
+		var err error
+		c.mu.Lock()
+		ts := n.ts
+		if ts == nil {
+			ts, err = c.connectDataNode(ctx, n.url)
+			n.ts = ts
+		}
+		c.mu.Unlock()
+
+		if p, ok := ts.(pinger); ok && err == nil {
+			err = p.Ping(ctx)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.mu.Lock()
+		n.lastCheck = time.Now().UTC()
+		c.setHealth(n, err)
+		c.mu.Unlock()
+	}
+}
+
+// observe updates the health of a data node from the outcome of a request it
+// served. It returns true if the data node was ejected.
+func (c *Client) observe(ctx context.Context, n *dataNode, err error) bool {
+	if err != nil && !isNodeFailure(ctx, err) {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setHealth(n, err)
+	return err != nil
+}
+
+// setHealth ejects a data node if err is set or re-admits it otherwise.
+// c.mu must be held.
+func (c *Client) setHealth(n *dataNode, err error) {
+	log := c.log().
+		WithField("component", "enterprise").
+		WithField("data_node", n.url)
+	switch {
+	case err != nil && n.healthy:
+		log.Error("Ejecting unhealthy data node: ", err)
+	case err == nil && !n.healthy:
+		log.Info("Re-admitting healthy data node")
+	}
+	n.healthy = err == nil
+	n.err = err
+}
+
+// nextDataNode retrieves the next healthy data node that was not yet tried.
+// Without a Fallback, unhealthy data nodes are tried when no healthy data node
+// is left. It returns nil if there is no data node to try.
+func (c *Client) nextDataNode(tried map[*dataNode]bool) *dataNode {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count := len(c.dataNodes)
+	pick := func(healthyOnly bool) *dataNode {
+		for i := 0; i < count; i++ {
+			idx := (c.next + i) % count
+			n := c.dataNodes[idx]
+			if n.ts == nil || tried[n] || (healthyOnly && !n.healthy) {
+				continue
+			}
+			c.next = (idx + 1) % count
+			return n
+		}
+		return nil
+	}
+	if n := pick(true); n != nil || c.Fallback != nil {
+		return n
+	}
+	return pick(false)
+}
+
+func (c *Client) isOpened() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.opened
+}
+
+func (c *Client) connectDataNode(ctx context.Context, u string) (chronograf.TimeSeries, error) {
+	cl := &influx.Client{
+		Logger: c.Logger,
+		Limits: c.Limits,
+	}
+	dataSrc := &chronograf.Source{}
+	if c.src != nil {
+		*dataSrc = *c.src
+	}
+	dataSrc.URL = u
+	if err := cl.Connect(ctx, dataSrc); err != nil {
+		return nil, err
+	}
+	return cl, nil
+}
+
+func (c *Client) log() chronograf.Logger {
+	if c.Logger == nil {
+		return log.New(log.ErrorLevel)
+	}
+	return c.Logger
+}
+
+// dataNodeURL is the URL of the HTTP API of a data node
+func dataNodeURL(dn DataNode) string {
+	if strings.Contains(dn.HTTPAddr, "://") {
+		return dn.HTTPAddr
+	}
+	scheme := dn.HTTPScheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	return scheme + "://" + dn.HTTPAddr
+}
+
+// isNodeFailure returns true if err means that the data node could not be
+// reached rather than that it refused the request.
+func isNodeFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// isIdempotent returns true if the command only reads data, so that it can be
+// retried safely.
+func isIdempotent(command string) bool {
+	q, err := influxql.ParseQuery(command)
+	if err != nil || len(q.Statements) == 0 {
+		return false
+	}
+	for _, stmt := range q.Statements {
+		switch s := stmt.(type) {
+		case *influxql.SelectStatement:
+			if s.Target != nil {
+				return false
+			}
+		case *influxql.ExplainStatement:
+		default:
+			if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(stmt.String())), "SHOW ") {
+				return false
+			}
+		}
+	}
+	return true
+}
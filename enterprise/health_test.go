@@ -0,0 +1,283 @@
+package enterprise_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+)
+
+func newHealthTestClient(t *testing.T, series ...chronograf.TimeSeries) *enterprise.Client {
+	t.Helper()
+	cl, err := enterprise.NewClientWithTimeSeries(log.New(log.DebugLevel), "http://meta.example.com:8091", &influx.NoAuthorization{}, false, false, series...)
+	if err != nil {
+		t.Fatal("Unexpected error while initializing client: err:", err)
+	}
+	if err := cl.Connect(context.Background(), &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error while connecting client: err:", err)
+	}
+	return cl
+}
+
+func healthy(cl *enterprise.Client) []bool {
+	res := []bool{}
+	for _, n := range cl.DataNodes() {
+		res = append(res, n.Healthy)
+	}
+	return res
+}
+
+func Test_Enterprise_RetriesIdempotentQueries(t *testing.T) {
+	unreachable := &url.Error{Op: "Post", URL: "http://host-1.example.com:8086/query", Err: errors.New("connection refused")}
+	tests := []struct {
+		name        string
+		command     string
+		err1        error
+		wantErr     bool
+		wantCalls   [2]int
+		wantHealthy []bool
+	}{
+		{
+			name:        "select is retried on the next data node",
+			command:     `SELECT mean("usage_idle") FROM "cpu"`,
+			err1:        unreachable,
+			wantCalls:   [2]int{1, 1},
+			wantHealthy: []bool{false, true},
+		},
+		{
+			name:        "show is retried on the next data node",
+			command:     `SHOW DATABASES`,
+			err1:        unreachable,
+			wantCalls:   [2]int{1, 1},
+			wantHealthy: []bool{false, true},
+		},
+		{
+			name:        "select into is not retried",
+			command:     `SELECT * INTO "copy" FROM "cpu"`,
+			err1:        unreachable,
+			wantErr:     true,
+			wantCalls:   [2]int{1, 0},
+			wantHealthy: []bool{false, true},
+		},
+		{
+			name:        "drop is not retried",
+			command:     `DROP DATABASE "telegraf"`,
+			err1:        unreachable,
+			wantErr:     true,
+			wantCalls:   [2]int{1, 0},
+			wantHealthy: []bool{false, true},
+		},
+		{
+			name:        "query errors do not eject the data node",
+			command:     `SELECT nope FROM`,
+			err1:        errors.New("received status code 400 from server"),
+			wantErr:     true,
+			wantCalls:   [2]int{1, 0},
+			wantHealthy: []bool{true, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m1 := NewMockTimeSeries("http://host-1.example.com:8086")
+			m2 := NewMockTimeSeries("http://host-2.example.com:8086")
+			m1.QueryErr = tt.err1
+			cl := newHealthTestClient(t, m1, m2)
+
+			_, err := cl.Query(context.Background(), chronograf.Query{Command: tt.command})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Query() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := [2]int{m1.QueryCtr, m2.QueryCtr}; got != tt.wantCalls {
+				t.Errorf("Query() calls = %v, want %v", got, tt.wantCalls)
+			}
+			if got := healthy(cl); !equalBools(got, tt.wantHealthy) {
+				t.Errorf("DataNodes() healthy = %v, want %v", got, tt.wantHealthy)
+			}
+		})
+	}
+}
+
+func Test_Enterprise_EjectsAndReadmitsDataNodes(t *testing.T) {
+	m1 := NewMockTimeSeries("http://host-1.example.com:8086")
+	m2 := NewMockTimeSeries("http://host-2.example.com:8086")
+	cl := newHealthTestClient(t, m1, m2)
+	ctx := context.Background()
+
+	m1.PingErr = errors.New("connection refused")
+	cl.HealthCheck(ctx)
+	if got := healthy(cl); !equalBools(got, []bool{false, true}) {
+		t.Fatalf("DataNodes() healthy after failed check = %v", got)
+	}
+	if nodes := cl.DataNodes(); nodes[0].Error != "connection refused" || nodes[0].LastCheck.IsZero() {
+		t.Errorf("DataNodes() = %+v", nodes)
+	}
+
+	// ejected data nodes receive no queries
+	for i := 0; i < 4; i++ {
+		if _, err := cl.Query(ctx, chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+			t.Fatal("Unexpected error while issuing query: err:", err)
+		}
+	}
+	if m1.QueryCtr != 0 || m2.QueryCtr != 4 {
+		t.Errorf("Expected all queries on m2, got m1 %d and m2 %d", m1.QueryCtr, m2.QueryCtr)
+	}
+
+	m1.PingErr = nil
+	cl.HealthCheck(ctx)
+	if got := healthy(cl); !equalBools(got, []bool{true, true}) {
+		t.Fatalf("DataNodes() healthy after successful check = %v", got)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cl.Query(ctx, chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+			t.Fatal("Unexpected error while issuing query: err:", err)
+		}
+	}
+	if m1.QueryCtr != 1 || m2.QueryCtr != 5 {
+		t.Errorf("Expected re-admitted m1 to be queried, got m1 %d and m2 %d", m1.QueryCtr, m2.QueryCtr)
+	}
+}
+
+func Test_Enterprise_FallsBackWithoutHealthyDataNodes(t *testing.T) {
+	m1 := NewMockTimeSeries("http://host-1.example.com:8086")
+	m1.PingErr = errors.New("connection refused")
+	cl := newHealthTestClient(t, m1)
+	ctx := context.Background()
+	cl.HealthCheck(ctx)
+
+	// without a fallback the unhealthy data node is tried anyway
+	if _, err := cl.Query(ctx, chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+		t.Fatal("Unexpected error while issuing query: err:", err)
+	}
+	if m1.QueryCtr != 1 {
+		t.Errorf("Expected m1 to be queried once, was %d", m1.QueryCtr)
+	}
+	if got := healthy(cl); !equalBools(got, []bool{true}) {
+		t.Errorf("Expected successful query to re-admit m1, got %v", got)
+	}
+
+	fallback := NewMockTimeSeries("http://source.example.com:8086")
+	cl.Fallback = fallback
+	cl.HealthCheck(ctx)
+	if _, err := cl.Query(ctx, chronograf.Query{Command: "SHOW DATABASES"}); err != nil {
+		t.Fatal("Unexpected error while issuing query: err:", err)
+	}
+	if m1.QueryCtr != 1 || fallback.QueryCtr != 1 {
+		t.Errorf("Expected fallback to be queried, got m1 %d and fallback %d", m1.QueryCtr, fallback.QueryCtr)
+	}
+}
+
+func Test_Enterprise_RefreshesDataNodes(t *testing.T) {
+	ctrl := &ControlClient{
+		Cluster: &enterprise.Cluster{
+			DataNodes: []enterprise.DataNode{
+				{ID: 1, HTTPAddr: "host-1.example.com:8086"},
+				{ID: 2, HTTPAddr: "host-2.example.com:8086", HTTPScheme: "https"},
+			},
+		},
+	}
+	cl := &enterprise.Client{
+		Ctrl:   ctrl,
+		Logger: log.New(log.DebugLevel),
+	}
+	ctx := context.Background()
+	if err := cl.Connect(ctx, &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error while connecting client: err:", err)
+	}
+	nodes := cl.DataNodes()
+	if len(nodes) != 2 || nodes[0].URL != "http://host-1.example.com:8086" || nodes[1].URL != "https://host-2.example.com:8086" {
+		t.Fatalf("DataNodes() = %+v", nodes)
+	}
+
+	ctrl.Cluster.DataNodes = []enterprise.DataNode{
+		{ID: 2, HTTPAddr: "host-2.example.com:8086", HTTPScheme: "https"},
+		{ID: 3, HTTPAddr: "host-3.example.com:8086"},
+	}
+	if err := cl.Refresh(ctx); err != nil {
+		t.Fatal("Unexpected error while refreshing client: err:", err)
+	}
+	ids := []uint64{}
+	for _, n := range cl.DataNodes() {
+		ids = append(ids, n.ID)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("DataNodes() after refresh have IDs %v, want [2 3]", ids)
+	}
+}
+
+func Test_Enterprise_QueriesDataNodes(t *testing.T) {
+	var mu sync.Mutex
+	commands := map[string][]string{}
+	newDataNode := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			commands[name] = append(commands[name], r.URL.Query().Get("q"))
+			mu.Unlock()
+			rw.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"` + name + `","columns":["qid"],"values":[[1]]}]}]}`))
+		}))
+	}
+	dn1 := newDataNode("dn1")
+	defer dn1.Close()
+	dn2 := newDataNode("dn2")
+	defer dn2.Close()
+
+	cl := &enterprise.Client{
+		Ctrl: &ControlClient{
+			Cluster: &enterprise.Cluster{
+				DataNodes: []enterprise.DataNode{
+					{ID: 1, HTTPAddr: strings.TrimPrefix(dn1.URL, "http://")},
+					{ID: 2, HTTPAddr: strings.TrimPrefix(dn2.URL, "http://")},
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+	ctx := context.Background()
+	if err := cl.Connect(ctx, &chronograf.Source{}); err != nil {
+		t.Fatal("Unexpected error while connecting client: err:", err)
+	}
+
+	responses := cl.QueryDataNodes(ctx, chronograf.Query{Command: "SHOW QUERIES"})
+	if len(responses) != 2 || responses[0].ID != 1 || responses[1].ID != 2 || responses[1].URL != dn2.URL {
+		t.Fatalf("QueryDataNodes() = %+v", responses)
+	}
+	for i, res := range responses {
+		if res.Err != nil {
+			t.Fatalf("QueryDataNodes() error of data node %d = %v", res.ID, res.Err)
+		}
+		octets, _ := res.Response.MarshalJSON()
+		if want := []string{"dn1", "dn2"}[i]; !strings.Contains(string(octets), want) {
+			t.Errorf("QueryDataNodes() response of data node %d = %s", res.ID, octets)
+		}
+	}
+
+	if _, err := cl.QueryDataNode(ctx, 2, chronograf.Query{Command: "KILL QUERY 1"}); err != nil {
+		t.Fatal("Unexpected error while querying data node: err:", err)
+	}
+	if got := commands["dn2"]; len(got) != 2 || got[1] != "KILL QUERY 1" || len(commands["dn1"]) != 1 {
+		t.Errorf("QueryDataNode() sent %v", commands)
+	}
+	if _, err := cl.QueryDataNode(ctx, 3, chronograf.Query{Command: "KILL QUERY 1"}); err != enterprise.ErrDataNodeNotFound {
+		t.Errorf("QueryDataNode() of unknown data node error = %v", err)
+	}
+}
+
+func equalBools(a, b []bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -123,6 +123,52 @@ func (m *MetaClient) ShowCluster(ctx context.Context) (*Cluster, error) {
 	return out, nil
 }
 
+// ShowShards returns all shards of the cluster and their owners
+func (m *MetaClient) ShowShards(ctx context.Context) ([]Shard, error) {
+	res, err := m.Do(ctx, "/show-shards", "GET", m.authorizer, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	dec := json.NewDecoder(res.Body)
+	shards := []Shard{}
+	err = dec.Decode(&shards)
+	if err != nil {
+		return nil, err
+	}
+	return shards, nil
+}
+
+// CopyShard copies a shard from the data node with TCP address src to the
+// data node with TCP address dest
+func (m *MetaClient) CopyShard(ctx context.Context, src, dest string, shardID uint64) error {
+	a := &CopyShardAction{
+		Src:   src,
+		Dest:  dest,
+		Shard: shardID,
+	}
+	return m.Post(ctx, "/copy-shard", a, nil)
+}
+
+// RemoveShard removes a shard from the data node with TCP address src
+func (m *MetaClient) RemoveShard(ctx context.Context, src string, shardID uint64) error {
+	a := &RemoveShardAction{
+		Src:   src,
+		Shard: shardID,
+	}
+	return m.Post(ctx, "/remove-shard", a, nil)
+}
+
+// TruncateShards truncates the hot shards after delay, so that new writes go
+// to new shards
+func (m *MetaClient) TruncateShards(ctx context.Context, delay time.Duration) error {
+	a := &TruncateShardsAction{
+		Delay: int64(delay),
+	}
+	return m.Post(ctx, "/truncate-shards", a, nil)
+}
+
 // Users gets all the users.  If name is not nil it filters for a single user
 func (m *MetaClient) Users(ctx context.Context, name *string) (*Users, error) {
 	params := map[string]string{}
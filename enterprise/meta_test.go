@@ -1415,3 +1415,89 @@ func Test_defaultClient_Do(t *testing.T) {
 		})
 	}
 }
+
+func TestMetaClient_ShowShards(t *testing.T) {
+	client := NewMockClient(
+		http.StatusOK,
+		[]byte(`[{"id":"1","database":"telegraf","retention-policy":"autogen","replica-n":2,"shard-group-id":"3","start-time":"2026-10-12T00:00:00Z","end-time":"2026-10-19T00:00:00Z","expire-time":"0001-01-01T00:00:00Z","truncated-at":"0001-01-01T00:00:00Z","owners":[{"id":"4"},{"id":"5"}]}]`),
+		nil,
+		nil,
+	)
+	m := &MetaClient{
+		client: client,
+	}
+	got, err := m.ShowShards(context.Background())
+	if err != nil {
+		t.Fatalf("MetaClient.ShowShards() error = %v", err)
+	}
+	want := []Shard{
+		{
+			ID:              1,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			ReplicaN:        2,
+			ShardGroupID:    3,
+			StartTime:       time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+			EndTime:         time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			Owners:          []ShardOwner{{ID: 4}, {ID: 5}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MetaClient.ShowShards() = %+v, want %+v", got, want)
+	}
+	if req := client.Requests[0]; req.Method != "GET" || req.URL.Path != "/show-shards" {
+		t.Errorf("MetaClient.ShowShards() requested %s %s", req.Method, req.URL.Path)
+	}
+}
+
+func TestMetaClient_ShardActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   func(m *MetaClient) error
+		wantPath string
+		want     string
+	}{
+		{
+			name: "Copy Shard",
+			action: func(m *MetaClient) error {
+				return m.CopyShard(context.Background(), "data-1:8088", "data-2:8088", 7)
+			},
+			wantPath: "/copy-shard",
+			want:     `{"src":"data-1:8088","dest":"data-2:8088","shard":"7"}`,
+		},
+		{
+			name: "Remove Shard",
+			action: func(m *MetaClient) error {
+				return m.RemoveShard(context.Background(), "data-1:8088", 7)
+			},
+			wantPath: "/remove-shard",
+			want:     `{"src":"data-1:8088","shard":"7"}`,
+		},
+		{
+			name: "Truncate Shards",
+			action: func(m *MetaClient) error {
+				return m.TruncateShards(context.Background(), time.Second)
+			},
+			wantPath: "/truncate-shards",
+			want:     `{"delay":1000000000}`,
+		},
+	}
+	for _, tt := range tests {
+		client := NewMockClient(http.StatusOK, nil, nil, nil)
+		m := &MetaClient{
+			client: client,
+		}
+		if err := tt.action(m); err != nil {
+			t.Errorf("%q. error = %v", tt.name, err)
+			continue
+		}
+		req := client.Requests[0]
+		if req.Method != "POST" || req.URL.Path != tt.wantPath {
+			t.Errorf("%q. requested %s %s, want POST %s", tt.name, req.Method, req.URL.Path, tt.wantPath)
+		}
+		got, _ := ioutil.ReadAll(req.Body)
+		if string(got) != tt.want {
+			t.Errorf("%q. = %v, want %v", tt.name, string(got), tt.want)
+		}
+	}
+}
@@ -99,6 +99,8 @@ func (cc *ControlClient) RemoveRoleUsers(ctx context.Context, name string, users
 type TimeSeries struct {
 	URLs     []string
 	Response Response
+	QueryErr error
+	PingErr  error
 
 	QueryCtr int
 }
@@ -111,9 +113,16 @@ func (r *Response) MarshalJSON() ([]byte, error) {
 
 func (ts *TimeSeries) Query(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
 	ts.QueryCtr++
+	if ts.QueryErr != nil {
+		return nil, ts.QueryErr
+	}
 	return &Response{}, nil
 }
 
+func (ts *TimeSeries) Ping(ctx context.Context) error {
+	return ts.PingErr
+}
+
 func (ts *TimeSeries) Connect(ctx context.Context, src *chronograf.Source) error {
 	return nil
 }
@@ -1,5 +1,7 @@
 package enterprise
 
+import "time"
+
 // Cluster is a collection of data nodes and non-data nodes within a
 // Plutonium cluster.
 type Cluster struct {
@@ -24,6 +26,44 @@ type Node struct {
 	TCPAddr    string `json:"tcpAddr"`
 }
 
+// Shard is a shard of an Influx Enterprise cluster as reported by the meta
+// nodes
+type Shard struct {
+	ID              uint64       `json:"id,string"`
+	Database        string       `json:"database"`
+	RetentionPolicy string       `json:"retention-policy"`
+	ReplicaN        int          `json:"replica-n"`
+	ShardGroupID    uint64       `json:"shard-group-id,string"`
+	StartTime       time.Time    `json:"start-time"`
+	EndTime         time.Time    `json:"end-time"`
+	ExpireTime      time.Time    `json:"expire-time"`
+	TruncatedAt     time.Time    `json:"truncated-at"`
+	Owners          []ShardOwner `json:"owners"`
+}
+
+// ShardOwner is a data node that stores a copy of a shard
+type ShardOwner struct {
+	ID uint64 `json:"id,string"` // ID of the data node
+}
+
+// CopyShardAction copies a shard from one data node to another
+type CopyShardAction struct {
+	Src   string `json:"src"`          // Src is the TCP address of the data node the shard is copied from
+	Dest  string `json:"dest"`         // Dest is the TCP address of the data node the shard is copied to
+	Shard uint64 `json:"shard,string"` // Shard is the ID of the shard
+}
+
+// RemoveShardAction removes a shard from a data node
+type RemoveShardAction struct {
+	Src   string `json:"src"`          // Src is the TCP address of the data node the shard is removed from
+	Shard uint64 `json:"shard,string"` // Shard is the ID of the shard
+}
+
+// TruncateShardsAction truncates the hot shards of all retention policies
+type TruncateShardsAction struct {
+	Delay int64 `json:"delay"` // Delay in nanoseconds after which the shards are truncated
+}
+
 // Permissions maps resources to a set of permissions.
 // Specifically, it maps a database to a set of permissions
 type Permissions map[string][]string
@@ -95,6 +95,27 @@ func (a *Apps) Get(ctx context.Context, ID string) (chronograf.Layout, error) {
 	return l, nil
 }
 
+// Add is not supported, the layouts of the directory are read-only
+func (a *Apps) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	return chronograf.Layout{}, chronograf.ErrLayoutReadOnly
+}
+
+// Delete is not supported, the layouts of the directory are read-only
+func (a *Apps) Delete(ctx context.Context, layout chronograf.Layout) error {
+	if _, _, err := a.idToFile(layout.ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
+
+// Update is not supported, the layouts of the directory are read-only
+func (a *Apps) Update(ctx context.Context, layout chronograf.Layout) error {
+	if _, _, err := a.idToFile(layout.ID); err != nil {
+		return err
+	}
+	return chronograf.ErrLayoutReadOnly
+}
+
 // idToFile takes an id and finds the associated filename
 func (a *Apps) idToFile(ID string) (chronograf.Layout, string, error) {
 	// Because the entire layout information is not known at this point, we need
@@ -0,0 +1,184 @@
+package filestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/influxdata/chronograf"
+	"sigs.k8s.io/yaml"
+)
+
+// DashboardDefinitionExts are the extensions of dashboard definition files
+var DashboardDefinitionExts = []string{".yaml", ".yml", ".json"}
+
+// slugPattern matches valid dashboard slugs, e.g. system-overview
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// DashboardDefinition is a dashboard declared in a file of a dashboards
+// sync directory. Definitions in the directory itself belong to the default
+// organization, definitions within a sub-directory to the organization whose
+// ID is the name of the sub-directory.
+type DashboardDefinition struct {
+	Slug         string               // Slug is the stable name of the dashboard within its organization
+	Organization string               // Organization is the ID of the organization; empty means the default organization
+	File         string               // File is the definition file relative to the sync directory
+	Hash         string               // Hash identifies the content of the definition
+	Dashboard    chronograf.Dashboard // Dashboard is the declared dashboard
+}
+
+// DashboardDefinitionError is a definition file that could not be loaded
+type DashboardDefinitionError struct {
+	File string `json:"file"`  // File is the definition file relative to the sync directory
+	Err  string `json:"error"` // Err describes why the file could not be loaded
+}
+
+func (e DashboardDefinitionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.File, e.Err)
+}
+
+// dashboardDefinitionSlug is the field a definition file has in addition to
+// those of a dashboard
+type dashboardDefinitionSlug struct {
+	Slug string `json:"slug"`
+}
+
+// LoadDashboardDefinitions reads all YAML and JSON dashboard definitions
+// within dir and its organization sub-directories. A definition is a
+// dashboard with an additional slug field; cells and templates without ID get
+// one derived from the slug. Like other resource files, definitions may refer
+// to environment variables using text templates. The slug of a definition
+// defaults to its file name. Files that cannot be loaded, and definitions
+// whose slug is already used within the organization, are returned as errors.
+func LoadDashboardDefinitions(dir string) ([]DashboardDefinition, []DashboardDefinitionError) {
+	defs := []DashboardDefinition{}
+	errs := []DashboardDefinitionError{}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return defs, append(errs, DashboardDefinitionError{File: ".", Err: err.Error()})
+	}
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+			continue
+		}
+		orgEntries, err := ioutil.ReadDir(path.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, DashboardDefinitionError{File: entry.Name(), Err: err.Error()})
+			continue
+		}
+		for _, orgEntry := range orgEntries {
+			if !orgEntry.IsDir() {
+				files = append(files, path.Join(entry.Name(), orgEntry.Name()))
+			}
+		}
+	}
+	sort.Strings(files)
+
+	slugs := map[string]string{}
+	for _, file := range files {
+		if !isDashboardDefinition(file) {
+			continue
+		}
+		def, err := loadDashboardDefinition(dir, file)
+		if err != nil {
+			errs = append(errs, DashboardDefinitionError{File: file, Err: err.Error()})
+			continue
+		}
+		key := def.Organization + "/" + def.Slug
+		if other, ok := slugs[key]; ok {
+			errs = append(errs, DashboardDefinitionError{File: file, Err: fmt.Sprintf("slug %q is already defined by %s", def.Slug, other)})
+			continue
+		}
+		slugs[key] = file
+		defs = append(defs, def)
+	}
+	return defs, errs
+}
+
+func isDashboardDefinition(file string) bool {
+	ext := path.Ext(file)
+	for _, e := range DashboardDefinitionExts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func loadDashboardDefinition(dir, file string) (DashboardDefinition, error) {
+	octets, err := textTemplated(environ(), path.Join(dir, file))
+	if err != nil {
+		return DashboardDefinition{}, err
+	}
+	// YAML is a superset of JSON, so JSON definitions are read the same way
+	octets, err = yaml.YAMLToJSON(octets)
+	if err != nil {
+		return DashboardDefinition{}, err
+	}
+	var slug dashboardDefinitionSlug
+	if err := json.Unmarshal(octets, &slug); err != nil {
+		return DashboardDefinition{}, err
+	}
+	var dashboard chronograf.Dashboard
+	if err := json.Unmarshal(octets, &dashboard); err != nil {
+		return DashboardDefinition{}, err
+	}
+
+	def := DashboardDefinition{
+		Slug:      slug.Slug,
+		File:      file,
+		Dashboard: dashboard,
+	}
+	if d := path.Dir(file); d != "." {
+		def.Organization = d
+	}
+	if def.Slug == "" {
+		def.Slug = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	if !slugPattern.MatchString(def.Slug) {
+		return DashboardDefinition{}, fmt.Errorf("invalid slug %q: must consist of lower case letters, digits and dashes", def.Slug)
+	}
+	if def.Dashboard.Name == "" {
+		return DashboardDefinition{}, fmt.Errorf("dashboard must have a name")
+	}
+
+	// the store assigns the ID and the directory determines the organization
+	def.Dashboard.ID = 0
+	def.Dashboard.Organization = def.Organization
+	for i := range def.Dashboard.Cells {
+		if def.Dashboard.Cells[i].ID == "" {
+			def.Dashboard.Cells[i].ID = definitionID(def, "cells", i)
+		}
+	}
+	for i := range def.Dashboard.Templates {
+		if def.Dashboard.Templates[i].ID == "" {
+			def.Dashboard.Templates[i].ID = chronograf.TemplateID(definitionID(def, "templates", i))
+		}
+	}
+
+	content, err := json.Marshal([]interface{}{def.Slug, def.Dashboard})
+	if err != nil {
+		return DashboardDefinition{}, err
+	}
+	sum := sha256.Sum256(content)
+	def.Hash = hex.EncodeToString(sum[:])
+	return def, nil
+}
+
+// definitionID derives a stable ID for a cell or template of a definition so
+// that syncing an unchanged definition yields an unchanged dashboard.
+func definitionID(def DashboardDefinition, kind string, index int) string {
+	name := "chronograf/dashboards/" + def.Organization + "/" + def.Slug + "/" + kind + "/" + strconv.Itoa(index)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
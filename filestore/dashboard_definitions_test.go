@@ -0,0 +1,91 @@
+package filestore_test
+
+import (
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf/filestore"
+)
+
+func TestLoadDashboardDefinitions(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"system.yaml": `
+name: System
+cells:
+  - name: CPU
+    w: 6
+    h: 4
+    queries:
+      - query: SELECT mean("usage_idle") FROM "cpu"
+        type: influxql
+`,
+		"renamed.yml": `
+slug: memory
+name: Memory
+`,
+		"2/cpu.json":         `{"slug": "cpu", "name": "CPU", "cells": [{"i": "c1", "name": "Load"}]}`,
+		"2/duplicate.json":   `{"slug": "cpu", "name": "Other CPU"}`,
+		"Invalid Slug.yaml":  `name: Invalid`,
+		"nameless.yaml":      `cells: []`,
+		"README.md":          `# not a definition`,
+		"2/nested/deep.yaml": `name: Ignored`,
+	}
+	for name, content := range files {
+		file := path.Join(dir, name)
+		if err := os.MkdirAll(path.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	defs, errs := filestore.LoadDashboardDefinitions(dir)
+
+	got := map[string]filestore.DashboardDefinition{}
+	for _, def := range defs {
+		got[def.Organization+"/"+def.Slug] = def
+	}
+	wantKeys := []string{"/system", "/memory", "2/cpu"}
+	if len(got) != len(wantKeys) {
+		t.Fatalf("LoadDashboardDefinitions() loaded %d definitions, want %d: %+v", len(got), len(wantKeys), defs)
+	}
+	for _, key := range wantKeys {
+		if _, ok := got[key]; !ok {
+			t.Errorf("LoadDashboardDefinitions() did not load %s", key)
+		}
+	}
+
+	system := got["/system"]
+	if system.File != "system.yaml" || len(system.Dashboard.Cells) != 1 {
+		t.Fatalf("system definition = %+v", system)
+	}
+	cell := system.Dashboard.Cells[0]
+	if cell.ID == "" || cell.W != 6 || cell.Queries[0].Command != `SELECT mean("usage_idle") FROM "cpu"` {
+		t.Errorf("system cell = %+v", cell)
+	}
+	if cpu := got["2/cpu"]; cpu.Dashboard.Organization != "2" || cpu.Dashboard.Cells[0].ID != "c1" {
+		t.Errorf("cpu definition = %+v", cpu)
+	}
+
+	errFiles := []string{}
+	for _, err := range errs {
+		errFiles = append(errFiles, err.File)
+	}
+	wantErrs := []string{"2/duplicate.json", "Invalid Slug.yaml", "nameless.yaml"}
+	if !reflect.DeepEqual(errFiles, wantErrs) {
+		t.Errorf("LoadDashboardDefinitions() errors = %v, want %v", errs, wantErrs)
+	}
+
+	// loading unchanged definitions yields the same IDs and hashes
+	again, _ := filestore.LoadDashboardDefinitions(dir)
+	for _, def := range again {
+		prev := got[def.Organization+"/"+def.Slug]
+		if def.Hash != prev.Hash || !reflect.DeepEqual(def.Dashboard, prev.Dashboard) {
+			t.Errorf("definition %s changed between loads", def.Slug)
+		}
+	}
+}
@@ -16,6 +16,9 @@
 //	Protoboards   - _R__
 //	Sources       - _RUD
 //
+// Dashboard definitions (--dashboards-sync-dir) are read-only as well; they
+// are not served from the filestore but synced into the database.
+//
 // Caution should be taken when editing resources provided via the filestore,
 // especially in a distributed environment as unexpected behavior may occur.
 package filestore
@@ -1,6 +1,12 @@
 package flux
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -15,11 +21,19 @@ var (
 	defaultTransport    = util.CreateTransport(false)
 )
 
+// Authorizer adds authorization to requests
+type Authorizer interface {
+	// Set may manipulate the request by adding the Authorization header
+	Set(req *http.Request) error
+}
+
 // Client is how we interact with Flux.
 type Client struct {
 	URL                *url.URL
 	InsecureSkipVerify bool
 	Timeout            time.Duration
+	Org                string     // Org is the v2 organization queries are executed in
+	Authorizer         Authorizer // Authorizer optionally authorizes queries
 }
 
 // FluxEnabled returns true if the server has flux querying enabled.
@@ -53,3 +67,264 @@ func (c *Client) FluxEnabled() (bool, error) {
 	// {"code":"unauthorized","message":"unauthorized access"} is received
 	return strings.HasPrefix(contentType, "application/json"), nil
 }
+
+// Values executes the flux query and returns the values of the _value column
+// of the first table in its result.
+func (c *Client) Values(ctx context.Context, query string) ([]string, error) {
+	body, err := c.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return firstTableValues(body)
+}
+
+// Rows executes the flux query and returns the rows of all tables in its
+// result by column name, without the result and table columns.
+func (c *Client) Rows(ctx context.Context, query string) ([]map[string]string, error) {
+	body, err := c.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	rows, err := ReadRows(body)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		delete(row, "result")
+		delete(row, "table")
+	}
+	return rows, nil
+}
+
+// Query executes the flux query and returns its annotated CSV result, which
+// the caller must close.
+func (c *Client) Query(ctx context.Context, query string) (io.ReadCloser, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query": query,
+		"dialect": map[string]interface{}{
+			"annotations": []string{"group", "datatype", "default"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	u := util.AppendPath(c.URL, "/api/v2/query")
+	if c.Org != "" {
+		params := u.Query()
+		params.Set("org", c.Org)
+		u.RawQuery = params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Authorizer != nil {
+		if err := c.Authorizer.Set(req); err != nil {
+			return nil, err
+		}
+	}
+
+	hc := &http.Client{
+		Timeout: c.Timeout,
+	}
+	if c.InsecureSkipVerify {
+		hc.Transport = skipVerifyTransport
+	} else {
+		hc.Transport = defaultTransport
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("received status code %d from server: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
+
+// firstTableValues reads the _value column of the first table of an
+// annotated CSV response.
+func firstTableValues(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	values := []string{}
+	var header []string
+	expectHeader := true
+	firstTable := ""
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > 0 && strings.HasPrefix(record[0], "#") {
+			expectHeader = true
+			continue
+		}
+		if expectHeader {
+			header = record
+			expectHeader = false
+			if len(header) > 1 && header[1] == "error" {
+				record, err := reader.Read()
+				if err == nil && len(record) > 1 {
+					return nil, fmt.Errorf("%s", record[1])
+				}
+				return nil, fmt.Errorf("flux query failed")
+			}
+			continue
+		}
+
+		row := map[string]string{}
+		for i, name := range header {
+			if i < len(record) {
+				row[name] = record[i]
+			}
+		}
+		if firstTable == "" {
+			firstTable = row["result"] + "/" + row["table"]
+		}
+		if row["result"]+"/"+row["table"] != firstTable {
+			return values, nil
+		}
+		if v, ok := row["_value"]; ok {
+			values = append(values, v)
+		}
+	}
+}
+
+// ReadRows reads the rows of all tables of an annotated CSV result by column
+// name, including the result and table columns.
+func ReadRows(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	rows := []map[string]string{}
+	var header, defaults []string
+	expectHeader := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > 0 && strings.HasPrefix(record[0], "#") {
+			if record[0] == "#default" {
+				defaults = record
+			}
+			expectHeader = true
+			continue
+		}
+		if expectHeader {
+			header = record
+			expectHeader = false
+			if len(header) > 1 && header[1] == "error" {
+				record, err := reader.Read()
+				if err == nil && len(record) > 1 {
+					return nil, fmt.Errorf("%s", record[1])
+				}
+				return nil, fmt.Errorf("flux query failed")
+			}
+			continue
+		}
+
+		row := map[string]string{}
+		for i, name := range header {
+			if i >= len(record) || name == "" {
+				continue
+			}
+			row[name] = record[i]
+			if record[i] == "" && i < len(defaults) {
+				row[name] = defaults[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// Column is a column of a table of an annotated CSV result
+type Column struct {
+	Name     string // Name is the label of the column
+	DataType string // DataType is the #datatype annotation, such as double or dateTime:RFC3339
+	Group    bool   // Group is true if the column is part of the group key
+}
+
+// ReadTables reads the records of all tables of an annotated CSV result and
+// calls fn with the columns of the table and the values of each record,
+// defaults applied. The result and table columns are included. Reading stops
+// at the first error of fn.
+func ReadTables(r io.Reader, fn func(columns []Column, record []string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var columns []Column
+	var datatypes, groups, defaults []string
+	expectHeader := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(record) > 0 && strings.HasPrefix(record[0], "#") {
+			// the first cell of annotations is the name of the annotation
+			annotation := append([]string{""}, record[1:]...)
+			switch record[0] {
+			case "#datatype":
+				datatypes = annotation
+			case "#group":
+				groups = annotation
+			case "#default":
+				defaults = annotation
+			}
+			expectHeader = true
+			continue
+		}
+		if expectHeader {
+			expectHeader = false
+			if len(record) > 1 && record[1] == "error" {
+				record, err := reader.Read()
+				if err == nil && len(record) > 1 {
+					return fmt.Errorf("%s", record[1])
+				}
+				return fmt.Errorf("flux query failed")
+			}
+			columns = make([]Column, len(record))
+			for i, name := range record {
+				columns[i].Name = name
+				if i < len(datatypes) {
+					columns[i].DataType = datatypes[i]
+				}
+				if i < len(groups) {
+					columns[i].Group = groups[i] == "true"
+				}
+			}
+			continue
+		}
+
+		values := make([]string, len(columns))
+		for i := range columns {
+			if i < len(record) {
+				values[i] = record[i]
+			}
+			if values[i] == "" && i < len(defaults) {
+				values[i] = defaults[i]
+			}
+		}
+		if err := fn(columns, values); err != nil {
+			return err
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package flux_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -54,3 +56,124 @@ func Test_FluxEnabled(t *testing.T) {
 		t.Errorf("Client.FluxEnabled() expected true value")
 	}
 }
+
+func Test_Values(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("org") != "my-org" {
+			t.Error("Expected the org query parameter, got", r.URL.RawQuery)
+		}
+		rw.Header().Add("Content-Type", "text/csv")
+		rw.Write([]byte("#group,false,false,false\r\n" +
+			"#datatype,string,long,string\r\n" +
+			"#default,_result,,\r\n" +
+			",result,table,_value\r\n" +
+			",,0,cpu\r\n" +
+			",,0,mem\r\n" +
+			",,1,disk\r\n" +
+			"\r\n"))
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL)
+	client.Org = "my-org"
+	values, err := client.Values(context.Background(), `import "influxdata/influxdb/schema" schema.measurements(bucket: "b")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(values, ",") != "cpu,mem" {
+		t.Errorf("Client.Values() = %v, want [cpu mem]", values)
+	}
+}
+
+func Test_Rows(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Add("Content-Type", "text/csv")
+		rw.Write([]byte("#group,false,false,true,false\r\n" +
+			"#datatype,string,long,string,long\r\n" +
+			"#default,_result,,,\r\n" +
+			",result,table,_measurement,_value\r\n" +
+			",,0,cpu,12\r\n" +
+			",,1,mem,3\r\n" +
+			"\r\n" +
+			"#group,false,false,false\r\n" +
+			"#datatype,string,long,string\r\n" +
+			"#default,_result,,\r\n" +
+			",result,table,host\r\n" +
+			",,2,a\r\n" +
+			"\r\n"))
+	}))
+	defer ts.Close()
+
+	rows, err := NewClient(ts.URL).Rows(context.Background(), `from(bucket: "b")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]string{
+		{"_measurement": "cpu", "_value": "12"},
+		{"_measurement": "mem", "_value": "3"},
+		{"host": "a"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Client.Rows() = %v, want %v", rows, want)
+	}
+}
+
+func Test_RowsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("#datatype,string,string\r\n" +
+			"#group,true,true\r\n" +
+			"#default,,\r\n" +
+			",error,reference\r\n" +
+			",bucket not found,\r\n"))
+	}))
+	defer ts.Close()
+
+	if _, err := NewClient(ts.URL).Rows(context.Background(), `from(bucket: "b")`); err == nil || err.Error() != "bucket not found" {
+		t.Errorf("Client.Rows() error = %v, want bucket not found", err)
+	}
+}
+
+func Test_ReadTables(t *testing.T) {
+	csv := "#group,false,false,true,false\r\n" +
+		"#datatype,string,long,string,double\r\n" +
+		"#default,_result,,,\r\n" +
+		",result,table,_measurement,_value\r\n" +
+		",,0,cpu,1.5\r\n" +
+		"\r\n" +
+		"#group,false,false,true\r\n" +
+		"#datatype,string,long,string\r\n" +
+		"#default,_result,,\r\n" +
+		",result,table,host\r\n" +
+		",,1,a\r\n"
+
+	type record struct {
+		columns []flux.Column
+		values  []string
+	}
+	var got []record
+	err := flux.ReadTables(strings.NewReader(csv), func(columns []flux.Column, values []string) error {
+		got = append(got, record{columns, values})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []record{
+		{
+			columns: []flux.Column{{}, {Name: "result", DataType: "string"}, {Name: "table", DataType: "long"}, {Name: "_measurement", DataType: "string", Group: true}, {Name: "_value", DataType: "double"}},
+			values:  []string{"", "_result", "0", "cpu", "1.5"},
+		},
+		{
+			columns: []flux.Column{{}, {Name: "result", DataType: "string"}, {Name: "table", DataType: "long"}, {Name: "host", DataType: "string", Group: true}},
+			values:  []string{"", "_result", "1", "a"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadTables() = %+v, want %+v", got, want)
+	}
+
+	errCSV := "#datatype,string,string\r\n,error,reference\r\n,bucket not found,\r\n"
+	if err := flux.ReadTables(strings.NewReader(errCSV), func([]flux.Column, []string) error { return nil }); err == nil || err.Error() != "bucket not found" {
+		t.Errorf("ReadTables() error = %v, want bucket not found", err)
+	}
+}
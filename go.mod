@@ -26,6 +26,7 @@ require (
 	golang.org/x/oauth2 v0.34.0
 	google.golang.org/api v0.230.0
 	google.golang.org/protobuf v1.36.10
+	sigs.k8s.io/yaml v1.2.0
 )
 
 require github.com/influxdata/influxql v1.4.1
@@ -121,7 +122,6 @@ require (
 	google.golang.org/grpc v1.79.3 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
-	sigs.k8s.io/yaml v1.2.0 // indirect
 )
 
 replace github.com/coreos/go-systemd => github.com/coreos/go-systemd/v22 v22.0.0
@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/influxql"
 )
 
 // AllDB returns all databases from within Influx
@@ -16,6 +18,9 @@ func (c *Client) AllDB(ctx context.Context) ([]chronograf.Database, error) {
 
 // CreateDB creates a database within Influx
 func (c *Client) CreateDB(ctx context.Context, db *chronograf.Database) (*chronograf.Database, error) {
+	if c.isV3SrcType() {
+		return c.createDBV3(ctx, db)
+	}
 	_, err := c.Query(ctx, chronograf.Query{
 		Command: fmt.Sprintf(`CREATE DATABASE "%s"`, db.Name),
 	})
@@ -30,6 +35,9 @@ func (c *Client) CreateDB(ctx context.Context, db *chronograf.Database) (*chrono
 
 // DropDB drops a database within Influx
 func (c *Client) DropDB(ctx context.Context, db string) error {
+	if c.isV3SrcType() {
+		return c.dropDBV3(ctx, db)
+	}
 	_, err := c.Query(ctx, chronograf.Query{
 		Command: fmt.Sprintf(`DROP DATABASE "%s"`, db),
 		DB:      db,
@@ -42,6 +50,9 @@ func (c *Client) DropDB(ctx context.Context, db string) error {
 
 // AllRP returns all the retention policies for a specific database
 func (c *Client) AllRP(ctx context.Context, db string) ([]chronograf.RetentionPolicy, error) {
+	if c.isV3Managed() && c.MgmtURL != nil {
+		return c.showRetentionPoliciesViaMgmtApi(ctx, db)
+	}
 	return c.showRetentionPolicies(ctx, db)
 }
 
@@ -93,8 +104,8 @@ func (c *Client) CreateRP(ctx context.Context, db string, rp *chronograf.Retenti
 // UpdateRP updates a specific retention policy for a specific database
 func (c *Client) UpdateRP(ctx context.Context, db string, rp string, upd *chronograf.RetentionPolicy) (*chronograf.RetentionPolicy, error) {
 	if c.isV3SrcType() {
-		// Data retention in InfluxDB 3 is configured differently, on database level.
-		return nil, fmt.Errorf("retention policies not supported in InfluxDB 3")
+		// Data retention in InfluxDB 3 is configured on database level.
+		return c.updateRetentionV3(ctx, db, rp, upd)
 	}
 	var buffer bytes.Buffer
 	buffer.WriteString(fmt.Sprintf(`ALTER RETENTION POLICY "%s" ON "%s"`, rp, db))
@@ -110,7 +121,8 @@ func (c *Client) UpdateRP(ctx context.Context, db string, rp string, upd *chrono
 	if upd.Default == true {
 		buffer.WriteString(" DEFAULT")
 	}
-	queryRes, err := c.Query(ctx, chronograf.Query{
+	// The ALTER RETENTION POLICIES statements puts the error within the results itself
+	err := c.exec(ctx, chronograf.Query{
 		Command: buffer.String(),
 		DB:      db,
 		RP:      rp,
@@ -119,25 +131,6 @@ func (c *Client) UpdateRP(ctx context.Context, db string, rp string, upd *chrono
 		return nil, err
 	}
 
-	// The ALTER RETENTION POLICIES statements puts the error within the results itself
-	// So, we have to crack open the results to see what happens
-	octets, err := queryRes.MarshalJSON()
-	if err != nil {
-		return nil, err
-	}
-
-	results := make([]struct{ Error string }, 0)
-	if err := json.Unmarshal(octets, &results); err != nil {
-		return nil, err
-	}
-
-	// At last, we can check if there are any error strings
-	for _, r := range results {
-		if r.Error != "" {
-			return nil, fmt.Errorf("%s", r.Error)
-		}
-	}
-
 	res, err := c.getRP(ctx, db, upd.Name)
 	if err != nil {
 		return nil, err
@@ -242,3 +235,273 @@ func (c *Client) showMeasurements(ctx context.Context, db string, limit, offset
 
 	return results.Measurements(), nil
 }
+
+// AllCQ returns all continuous queries of a database
+func (c *Client) AllCQ(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	if c.isV3SrcType() {
+		return nil, fmt.Errorf("continuous queries not supported in InfluxDB 3")
+	}
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW CONTINUOUS QUERIES`,
+		DB:      db,
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := namedSeriesResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+	return results.ContinuousQueries(db), nil
+}
+
+// CreateCQ creates a continuous query of a database
+func (c *Client) CreateCQ(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	if c.isV3SrcType() {
+		return nil, fmt.Errorf("continuous queries not supported in InfluxDB 3")
+	}
+	stmt, err := ParseContinuousQuery(cq.Query)
+	if err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf(`CREATE CONTINUOUS QUERY %s ON %s`, influxql.QuoteIdent(cq.Name), influxql.QuoteIdent(db)))
+	if len(cq.ResampleEvery) > 0 || len(cq.ResampleFor) > 0 {
+		buffer.WriteString(" RESAMPLE")
+	}
+	for _, r := range []struct{ clause, duration string }{{"EVERY", cq.ResampleEvery}, {"FOR", cq.ResampleFor}} {
+		if r.duration == "" {
+			continue
+		}
+		d, err := influxql.ParseDuration(r.duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resample duration %q", r.duration)
+		}
+		buffer.WriteString(" " + r.clause + " " + influxql.FormatDuration(d))
+	}
+	buffer.WriteString(" BEGIN " + stmt.String() + " END")
+
+	if err := c.exec(ctx, chronograf.Query{Command: buffer.String(), DB: db}); err != nil {
+		return nil, err
+	}
+
+	cqs, err := c.AllCQ(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range cqs {
+		if q.Name == cq.Name {
+			return &q, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown continuous query")
+}
+
+// ParseContinuousQuery parses the query of a continuous query, which must be
+// a single SELECT statement writing INTO a measurement
+func ParseContinuousQuery(query string) (*influxql.SelectStatement, error) {
+	q, err := influxql.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+	if len(q.Statements) != 1 {
+		return nil, fmt.Errorf("invalid query: continuous queries must have a single statement")
+	}
+	stmt, ok := q.Statements[0].(*influxql.SelectStatement)
+	if !ok {
+		return nil, fmt.Errorf("invalid query: continuous queries must be SELECT statements")
+	}
+	if stmt.Target == nil {
+		return nil, fmt.Errorf("invalid query: continuous queries must have an INTO clause")
+	}
+	return stmt, nil
+}
+
+// DropCQ removes a continuous query of a database
+func (c *Client) DropCQ(ctx context.Context, db string, name string) error {
+	if c.isV3SrcType() {
+		return fmt.Errorf("continuous queries not supported in InfluxDB 3")
+	}
+	return c.exec(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`DROP CONTINUOUS QUERY %s ON %s`, influxql.QuoteIdent(name), influxql.QuoteIdent(db)),
+		DB:      db,
+	})
+}
+
+// AllSubscriptions returns all subscriptions of a database
+func (c *Client) AllSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	if c.isV3SrcType() {
+		return nil, fmt.Errorf("subscriptions not supported in InfluxDB 3")
+	}
+	res, err := c.Query(ctx, chronograf.Query{
+		Command: `SHOW SUBSCRIPTIONS`,
+		DB:      db,
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	results := namedSeriesResults{}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, err
+	}
+	return results.Subscriptions(db), nil
+}
+
+// CreateSubscription creates a subscription of a retention policy of a database
+func (c *Client) CreateSubscription(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	if c.isV3SrcType() {
+		return nil, fmt.Errorf("subscriptions not supported in InfluxDB 3")
+	}
+	destinations := make([]string, len(sub.Destinations))
+	for i, d := range sub.Destinations {
+		destinations[i] = influxql.QuoteString(d)
+	}
+	query := fmt.Sprintf(`CREATE SUBSCRIPTION %s ON %s DESTINATIONS %s %s`,
+		influxql.QuoteIdent(sub.Name), influxql.QuoteIdent(db, sub.RetentionPolicy), sub.Mode, strings.Join(destinations, ", "))
+	if err := c.exec(ctx, chronograf.Query{Command: query, DB: db}); err != nil {
+		return nil, err
+	}
+
+	subs, err := c.AllSubscriptions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subs {
+		if s.Name == sub.Name && s.RetentionPolicy == sub.RetentionPolicy {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown subscription")
+}
+
+// DropSubscription removes a subscription of a retention policy of a database
+func (c *Client) DropSubscription(ctx context.Context, db, rp, name string) error {
+	if c.isV3SrcType() {
+		return fmt.Errorf("subscriptions not supported in InfluxDB 3")
+	}
+	return c.exec(ctx, chronograf.Query{
+		Command: fmt.Sprintf(`DROP SUBSCRIPTION %s ON %s`, influxql.QuoteIdent(name), influxql.QuoteIdent(db, rp)),
+		DB:      db,
+	})
+}
+
+// exec runs a statement that does not return data. Statement errors are
+// returned within the results, so they are cracked open to find them.
+func (c *Client) exec(ctx context.Context, q chronograf.Query) error {
+	res, err := c.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+	octets, err := res.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	results := make([]struct{ Error string }, 0)
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			return fmt.Errorf("%s", r.Error)
+		}
+	}
+	return nil
+}
+
+// namedSeriesResults is used to deserialize InfluxQL SHOW commands that
+// return a series per database
+type namedSeriesResults []struct {
+	Series []struct {
+		Name    string          `json:"name"`
+		Columns []string        `json:"columns"`
+		Values  [][]interface{} `json:"values"`
+	} `json:"series"`
+}
+
+// ContinuousQueries converts SHOW CONTINUOUS QUERIES to the chronograf
+// ContinuousQueries of db
+func (r namedSeriesResults) ContinuousQueries(db string) []chronograf.ContinuousQuery {
+	res := []chronograf.ContinuousQuery{}
+	for _, result := range r {
+		for _, s := range result.Series {
+			if s.Name != db {
+				continue
+			}
+			for _, v := range s.Values {
+				if len(v) < 2 {
+					continue
+				}
+				name, _ := v[0].(string)
+				create, _ := v[1].(string)
+				res = append(res, parseContinuousQuery(name, create))
+			}
+		}
+	}
+	return res
+}
+
+// parseContinuousQuery extracts the SELECT statement and the resample
+// clause from the CREATE CONTINUOUS QUERY statement of a continuous query
+func parseContinuousQuery(name, create string) chronograf.ContinuousQuery {
+	cq := chronograf.ContinuousQuery{
+		Name:  name,
+		Query: create,
+	}
+	stmt, err := influxql.ParseStatement(create)
+	if err != nil {
+		return cq
+	}
+	if s, ok := stmt.(*influxql.CreateContinuousQueryStatement); ok {
+		cq.Query = s.Source.String()
+		if s.ResampleEvery != 0 {
+			cq.ResampleEvery = influxql.FormatDuration(s.ResampleEvery)
+		}
+		if s.ResampleFor != 0 {
+			cq.ResampleFor = influxql.FormatDuration(s.ResampleFor)
+		}
+	}
+	return cq
+}
+
+// Subscriptions converts SHOW SUBSCRIPTIONS to the chronograf Subscriptions
+// of db
+func (r namedSeriesResults) Subscriptions(db string) []chronograf.Subscription {
+	res := []chronograf.Subscription{}
+	for _, result := range r {
+		for _, s := range result.Series {
+			if s.Name != db {
+				continue
+			}
+			for _, v := range s.Values {
+				if len(v) < 4 {
+					continue
+				}
+				sub := chronograf.Subscription{
+					Destinations: []string{},
+				}
+				sub.RetentionPolicy, _ = v[0].(string)
+				sub.Name, _ = v[1].(string)
+				sub.Mode, _ = v[2].(string)
+				destinations, _ := v[3].([]interface{})
+				for _, d := range destinations {
+					if dest, ok := d.(string); ok {
+						sub.Destinations = append(sub.Destinations, dest)
+					}
+				}
+				res = append(res, sub)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,153 @@
+package influx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+// newAdminServer responds to each InfluxQL command with the results of
+// responses and records the commands it receives
+func newAdminServer(t *testing.T, responses map[string]string, commands *[]string) (*httptest.Server, *influx.Client) {
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		*commands = append(*commands, q)
+		res, ok := responses[q]
+		if !ok {
+			res = `[{"statement_id":0}]`
+		}
+		rw.Write([]byte(`{"results":` + res + `}`))
+	}))
+	client := &influx.Client{Logger: &mocks.TestLogger{}}
+	if err := client.Connect(context.Background(), &chronograf.Source{URL: ts.URL}); err != nil {
+		t.Fatal(err)
+	}
+	return ts, client
+}
+
+const showCQs = `[{"statement_id":0,"series":[
+	{"name":"_internal","columns":["name","query"]},
+	{"name":"telegraf","columns":["name","query"],"values":[
+		["cpu_1h","CREATE CONTINUOUS QUERY cpu_1h ON telegraf RESAMPLE EVERY 30m FOR 2h BEGIN SELECT mean(usage_idle) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h), * END"]
+	]}
+]}]`
+
+const showSubscriptions = `[{"statement_id":0,"series":[
+	{"name":"telegraf","columns":["retention_policy","name","mode","destinations"],"values":[
+		["autogen","kapacitor","ANY",["http://kapacitor:9092"]]
+	]}
+]}]`
+
+func TestClient_ContinuousQueries(t *testing.T) {
+	commands := []string{}
+	ts, client := newAdminServer(t, map[string]string{
+		"SHOW CONTINUOUS QUERIES": showCQs,
+		`CREATE CONTINUOUS QUERY broken ON telegraf BEGIN SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h) END`: `[{"statement_id":0,"error":"continuous query already exists"}]`,
+	}, &commands)
+	defer ts.Close()
+	ctx := context.Background()
+
+	want := chronograf.ContinuousQuery{
+		Name:          "cpu_1h",
+		Query:         `SELECT mean(usage_idle) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h), *`,
+		ResampleEvery: "30m",
+		ResampleFor:   "2h",
+	}
+	cqs, err := client.AllCQ(ctx, "telegraf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cqs, []chronograf.ContinuousQuery{want}) {
+		t.Errorf("AllCQ() = %+v, want %+v", cqs, want)
+	}
+
+	commands = commands[:0]
+	cq, err := client.CreateCQ(ctx, "telegraf", &chronograf.ContinuousQuery{
+		Name:          "cpu_1h",
+		Query:         `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)`,
+		ResampleEvery: "30m",
+		ResampleFor:   "2h",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*cq, want) {
+		t.Errorf("CreateCQ() = %+v, want %+v", *cq, want)
+	}
+	wantCreate := `CREATE CONTINUOUS QUERY cpu_1h ON telegraf RESAMPLE EVERY 30m FOR 2h BEGIN SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h) END`
+	if len(commands) == 0 || commands[0] != wantCreate {
+		t.Errorf("CreateCQ() ran %v, want %s", commands, wantCreate)
+	}
+
+	if _, err := client.CreateCQ(ctx, "telegraf", &chronograf.ContinuousQuery{
+		Name:  "broken",
+		Query: `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)`,
+	}); err == nil || err.Error() != "continuous query already exists" {
+		t.Errorf("CreateCQ() error = %v, want statement error", err)
+	}
+
+	commands = commands[:0]
+	if _, err := client.CreateCQ(ctx, "telegraf", &chronograf.ContinuousQuery{
+		Name:  "cpu_1h",
+		Query: `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h) END; DROP DATABASE telegraf; --`,
+	}); err == nil || len(commands) != 0 {
+		t.Errorf("CreateCQ() of several statements error = %v, ran %v", err, commands)
+	}
+
+	commands = commands[:0]
+	if err := client.DropCQ(ctx, "telegraf", "cpu_1h"); err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 1 || commands[0] != `DROP CONTINUOUS QUERY cpu_1h ON telegraf` {
+		t.Errorf("DropCQ() ran %v", commands)
+	}
+}
+
+func TestClient_Subscriptions(t *testing.T) {
+	commands := []string{}
+	ts, client := newAdminServer(t, map[string]string{
+		"SHOW SUBSCRIPTIONS": showSubscriptions,
+	}, &commands)
+	defer ts.Close()
+	ctx := context.Background()
+
+	want := chronograf.Subscription{
+		Name:            "kapacitor",
+		RetentionPolicy: "autogen",
+		Mode:            "ANY",
+		Destinations:    []string{"http://kapacitor:9092"},
+	}
+	subs, err := client.AllSubscriptions(ctx, "telegraf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(subs, []chronograf.Subscription{want}) {
+		t.Errorf("AllSubscriptions() = %+v, want %+v", subs, want)
+	}
+	if subs, _ := client.AllSubscriptions(ctx, "other"); len(subs) != 0 {
+		t.Errorf("AllSubscriptions() of other database = %+v", subs)
+	}
+
+	commands = commands[:0]
+	if _, err := client.CreateSubscription(ctx, "telegraf", &want); err != nil {
+		t.Fatal(err)
+	}
+	wantCreate := `CREATE SUBSCRIPTION kapacitor ON "telegraf".autogen DESTINATIONS ANY 'http://kapacitor:9092'`
+	if len(commands) == 0 || commands[0] != wantCreate {
+		t.Errorf("CreateSubscription() ran %v, want %s", commands, wantCreate)
+	}
+
+	commands = commands[:0]
+	if err := client.DropSubscription(ctx, "telegraf", "autogen", "kapacitor"); err != nil {
+		t.Fatal(err)
+	}
+	if len(commands) != 1 || commands[0] != `DROP SUBSCRIPTION kapacitor ON "telegraf".autogen` {
+		t.Errorf("DropSubscription() ran %v", commands)
+	}
+}
@@ -0,0 +1,462 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/util"
+	"github.com/influxdata/influxql"
+)
+
+// ErrV3ManagementAPI is returned when the management of an InfluxDB Clustered
+// or Cloud Dedicated source is requested without a management token
+var ErrV3ManagementAPI = errors.New("a management token is required to manage databases of this source")
+
+// ErrV3CoreOnly is returned when a feature of InfluxDB 3 Core and Enterprise is
+// requested from another type of source
+var ErrV3CoreOnly = errors.New("only supported by InfluxDB 3 Core and Enterprise sources")
+
+// ErrV3DatabaseName is returned when a database of the management API is
+// requested with a name that is not a valid path segment
+var ErrV3DatabaseName = errors.New("invalid database name: must not be empty, . or ..")
+
+// V3Error is an error response of the InfluxDB 3 HTTP or management API
+type V3Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *V3Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("received status code %d from server", e.StatusCode)
+	}
+	return fmt.Sprintf("received status code %d from server: err: %s", e.StatusCode, e.Message)
+}
+
+func newV3Error(status int, body []byte) error {
+	var msg struct {
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	v3Err := &V3Error{StatusCode: status}
+	if err := json.Unmarshal(body, &msg); err == nil && (msg.Error != "" || msg.Message != "") {
+		v3Err.Message = msg.Error
+		if v3Err.Message == "" {
+			v3Err.Message = msg.Message
+		}
+	} else {
+		v3Err.Message = strings.TrimSpace(string(body))
+	}
+	return v3Err
+}
+
+// TableField is a field column of a table
+type TableField struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // Type is one of utf8, int64, uint64, float64 or bool
+}
+
+// Table is a table of an InfluxDB 3 database with its tag and field columns
+type Table struct {
+	Name   string       `json:"name"`
+	Tags   []string     `json:"tags,omitempty"`
+	Fields []TableField `json:"fields,omitempty"`
+}
+
+// LastValueCache keeps the most recent values of a table in memory for each
+// combination of its key columns
+type LastValueCache struct {
+	Table        string   `json:"table"`
+	Name         string   `json:"name"`
+	KeyColumns   []string `json:"keyColumns,omitempty"`
+	ValueColumns []string `json:"valueColumns,omitempty"`
+	Count        int      `json:"count,omitempty"` // Count of values kept per key
+	TTL          int64    `json:"ttl,omitempty"`   // TTL of the values in seconds
+}
+
+// DistinctValueCache keeps the distinct values of columns of a table in memory
+type DistinctValueCache struct {
+	Table          string   `json:"table"`
+	Name           string   `json:"name"`
+	Columns        []string `json:"columns"`
+	MaxCardinality int      `json:"maxCardinality,omitempty"`
+	MaxAge         int64    `json:"maxAge,omitempty"` // MaxAge of the values in seconds
+}
+
+// isV3Core returns true for InfluxDB 3 Core and Enterprise sources, which
+// are managed through the HTTP API of the server
+func (c *Client) isV3Core() bool {
+	return c.SrcType == chronograf.InfluxDBv3Core || c.SrcType == chronograf.InfluxDBv3Enterprise
+}
+
+// isV3Managed returns true for InfluxDB Clustered and Cloud Dedicated
+// sources, which are managed through the management API
+func (c *Client) isV3Managed() bool {
+	return c.SrcType == chronograf.InfluxDBv3Clustered || c.SrcType == chronograf.InfluxDBv3CloudDedicated
+}
+
+// v3RetentionPeriod parses the duration of a database or retention policy.
+// Empty and infinite durations are zero.
+func v3RetentionPeriod(d string) (time.Duration, error) {
+	switch strings.ToUpper(d) {
+	case "", "INF", "0", "0S":
+		return 0, nil
+	}
+	dur, err := influxql.ParseDuration(d)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention period %q", d)
+	}
+	return dur, nil
+}
+
+// formatRetentionPeriod formats the retention period of a database
+func formatRetentionPeriod(d time.Duration) string {
+	if d == 0 {
+		return "INF"
+	}
+	return influxql.FormatDuration(d)
+}
+
+// createDBV3 creates a database with the duration of db as retention period
+func (c *Client) createDBV3(ctx context.Context, db *chronograf.Database) (*chronograf.Database, error) {
+	period, err := v3RetentionPeriod(db.Duration)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.isV3Core():
+		req := struct {
+			DB              string `json:"db"`
+			RetentionPeriod string `json:"retention_period,omitempty"`
+		}{DB: db.Name}
+		if period > 0 {
+			req.RetentionPeriod = influxql.FormatDuration(period)
+		}
+		err = c.v3Do(ctx, "POST", "/api/v3/configure/database", nil, &req, nil)
+	case c.isV3Managed():
+		req := mgmtDatabase{Name: db.Name, RetentionPeriod: int64(period)}
+		err = c.mgmtDo(ctx, "POST", "/databases", &req, nil)
+	default:
+		return nil, fmt.Errorf("creating databases is not supported by %s sources", c.SrcType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &chronograf.Database{Name: db.Name, Duration: formatRetentionPeriod(period)}, nil
+}
+
+// dropDBV3 deletes a database
+func (c *Client) dropDBV3(ctx context.Context, db string) error {
+	switch {
+	case c.isV3Core():
+		return c.v3Do(ctx, "DELETE", "/api/v3/configure/database", url.Values{"db": {db}}, nil, nil)
+	case c.isV3Managed():
+		path, err := mgmtDatabasePath(db)
+		if err != nil {
+			return err
+		}
+		return c.mgmtDo(ctx, "DELETE", path, nil, nil)
+	}
+	return fmt.Errorf("deleting databases is not supported by %s sources", c.SrcType)
+}
+
+// updateRetentionV3 changes the retention period of a database. InfluxDB 3
+// has no retention policies, the retention period of a database is presented
+// as its only retention policy.
+func (c *Client) updateRetentionV3(ctx context.Context, db, rp string, upd *chronograf.RetentionPolicy) (*chronograf.RetentionPolicy, error) {
+	period, err := v3RetentionPeriod(upd.Duration)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.isV3Core():
+		if period == 0 {
+			return nil, fmt.Errorf("the retention period of InfluxDB 3 Core and Enterprise databases cannot be removed")
+		}
+		req := struct {
+			DB              string `json:"db"`
+			RetentionPeriod string `json:"retention_period"`
+		}{DB: db, RetentionPeriod: influxql.FormatDuration(period)}
+		err = c.v3Do(ctx, "PUT", "/api/v3/configure/database", nil, &req, nil)
+	case c.isV3Managed():
+		req := struct {
+			RetentionPeriod int64 `json:"retentionPeriod"`
+		}{RetentionPeriod: int64(period)}
+		path, pathErr := mgmtDatabasePath(db)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		err = c.mgmtDo(ctx, "PATCH", path, &req, nil)
+	default:
+		return nil, fmt.Errorf("retention is not configurable for %s sources", c.SrcType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &chronograf.RetentionPolicy{
+		Name:     rp,
+		Duration: formatRetentionPeriod(period),
+		Default:  true,
+	}, nil
+}
+
+// mgmtDatabase is a database of the management API of InfluxDB Clustered and
+// Cloud Dedicated
+type mgmtDatabase struct {
+	Name               string `json:"name"`
+	MaxTables          int    `json:"maxTables,omitempty"`
+	MaxColumnsPerTable int    `json:"maxColumnsPerTable,omitempty"`
+	RetentionPeriod    int64  `json:"retentionPeriod"` // RetentionPeriod in nanoseconds, zero is infinite
+}
+
+// showRetentionPoliciesViaMgmtApi presents the retention period of a database
+// of the management API as its only retention policy
+func (c *Client) showRetentionPoliciesViaMgmtApi(ctx context.Context, db string) ([]chronograf.RetentionPolicy, error) {
+	var databases []mgmtDatabase
+	if err := c.mgmtDo(ctx, "GET", "/databases", nil, &databases); err != nil {
+		return nil, err
+	}
+	for _, d := range databases {
+		if d.Name == db {
+			return []chronograf.RetentionPolicy{{
+				Name:     "autogen",
+				Duration: formatRetentionPeriod(time.Duration(d.RetentionPeriod)),
+				Default:  true,
+			}}, nil
+		}
+	}
+	return nil, fmt.Errorf("database %q not found", db)
+}
+
+// CreateTable creates a table in a database of an InfluxDB 3 source. Tags and
+// fields are only set up by InfluxDB 3 Core and Enterprise.
+func (c *Client) CreateTable(ctx context.Context, db string, t *Table) (*Table, error) {
+	var err error
+	switch {
+	case c.isV3Core():
+		req := struct {
+			DB     string       `json:"db"`
+			Table  string       `json:"table"`
+			Tags   []string     `json:"tags"`
+			Fields []TableField `json:"fields"`
+		}{DB: db, Table: t.Name, Tags: t.Tags, Fields: t.Fields}
+		if req.Tags == nil {
+			req.Tags = []string{}
+		}
+		if req.Fields == nil {
+			req.Fields = []TableField{}
+		}
+		err = c.v3Do(ctx, "POST", "/api/v3/configure/table", nil, &req, nil)
+	case c.isV3Managed():
+		req := struct {
+			Name string `json:"name"`
+		}{Name: t.Name}
+		path, pathErr := mgmtDatabasePath(db, "tables")
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		err = c.mgmtDo(ctx, "POST", path, &req, nil)
+	default:
+		return nil, fmt.Errorf("creating tables is not supported by %s sources", c.SrcType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DropTable deletes a table of a database of an InfluxDB 3 Core or Enterprise
+// source
+func (c *Client) DropTable(ctx context.Context, db, table string) error {
+	if !c.isV3Core() {
+		return ErrV3CoreOnly
+	}
+	return c.v3Do(ctx, "DELETE", "/api/v3/configure/table", url.Values{"db": {db}, "table": {table}}, nil, nil)
+}
+
+// LastValueCaches lists the last value caches of a database
+func (c *Client) LastValueCaches(ctx context.Context, db string) ([]LastValueCache, error) {
+	if !c.isV3Core() {
+		return nil, ErrV3CoreOnly
+	}
+	var rows []struct {
+		Table            string   `json:"table"`
+		Name             string   `json:"name"`
+		KeyColumnNames   []string `json:"key_column_names"`
+		ValueColumnNames []string `json:"value_column_names"`
+		Count            int      `json:"count"`
+		TTL              int64    `json:"ttl"`
+	}
+	if err := c.v3SQL(ctx, db, "SELECT * FROM system.last_caches", &rows); err != nil {
+		return nil, err
+	}
+	caches := make([]LastValueCache, len(rows))
+	for i, r := range rows {
+		caches[i] = LastValueCache{
+			Table:        r.Table,
+			Name:         r.Name,
+			KeyColumns:   r.KeyColumnNames,
+			ValueColumns: r.ValueColumnNames,
+			Count:        r.Count,
+			TTL:          r.TTL,
+		}
+	}
+	return caches, nil
+}
+
+// CreateLastValueCache creates a last value cache of a table. Unset key
+// columns default to the tags of the table and unset value columns to all
+// other columns.
+func (c *Client) CreateLastValueCache(ctx context.Context, db string, lvc *LastValueCache) (*LastValueCache, error) {
+	if !c.isV3Core() {
+		return nil, ErrV3CoreOnly
+	}
+	req := struct {
+		DB           string   `json:"db"`
+		Table        string   `json:"table"`
+		Name         string   `json:"name,omitempty"`
+		KeyColumns   []string `json:"key_columns,omitempty"`
+		ValueColumns []string `json:"value_columns,omitempty"`
+		Count        int      `json:"count,omitempty"`
+		TTL          int64    `json:"ttl,omitempty"`
+	}{
+		DB:           db,
+		Table:        lvc.Table,
+		Name:         lvc.Name,
+		KeyColumns:   lvc.KeyColumns,
+		ValueColumns: lvc.ValueColumns,
+		Count:        lvc.Count,
+		TTL:          lvc.TTL,
+	}
+	if err := c.v3Do(ctx, "POST", "/api/v3/configure/last_cache", nil, &req, nil); err != nil {
+		return nil, err
+	}
+	return lvc, nil
+}
+
+// DropLastValueCache deletes a last value cache of a table
+func (c *Client) DropLastValueCache(ctx context.Context, db, table, name string) error {
+	if !c.isV3Core() {
+		return ErrV3CoreOnly
+	}
+	params := url.Values{"db": {db}, "table": {table}, "name": {name}}
+	return c.v3Do(ctx, "DELETE", "/api/v3/configure/last_cache", params, nil, nil)
+}
+
+// DistinctValueCaches lists the distinct value caches of a database
+func (c *Client) DistinctValueCaches(ctx context.Context, db string) ([]DistinctValueCache, error) {
+	if !c.isV3Core() {
+		return nil, ErrV3CoreOnly
+	}
+	var rows []struct {
+		Table          string   `json:"table"`
+		Name           string   `json:"name"`
+		ColumnNames    []string `json:"column_names"`
+		MaxCardinality int      `json:"max_cardinality"`
+		MaxAgeSeconds  int64    `json:"max_age_seconds"`
+	}
+	if err := c.v3SQL(ctx, db, "SELECT * FROM system.distinct_caches", &rows); err != nil {
+		return nil, err
+	}
+	caches := make([]DistinctValueCache, len(rows))
+	for i, r := range rows {
+		caches[i] = DistinctValueCache{
+			Table:          r.Table,
+			Name:           r.Name,
+			Columns:        r.ColumnNames,
+			MaxCardinality: r.MaxCardinality,
+			MaxAge:         r.MaxAgeSeconds,
+		}
+	}
+	return caches, nil
+}
+
+// CreateDistinctValueCache creates a distinct value cache of columns of a
+// table
+func (c *Client) CreateDistinctValueCache(ctx context.Context, db string, dvc *DistinctValueCache) (*DistinctValueCache, error) {
+	if !c.isV3Core() {
+		return nil, ErrV3CoreOnly
+	}
+	req := struct {
+		DB             string   `json:"db"`
+		Table          string   `json:"table"`
+		Name           string   `json:"name,omitempty"`
+		Columns        []string `json:"columns"`
+		MaxCardinality int      `json:"max_cardinality,omitempty"`
+		MaxAge         int64    `json:"max_age,omitempty"`
+	}{
+		DB:             db,
+		Table:          dvc.Table,
+		Name:           dvc.Name,
+		Columns:        dvc.Columns,
+		MaxCardinality: dvc.MaxCardinality,
+		MaxAge:         dvc.MaxAge,
+	}
+	if err := c.v3Do(ctx, "POST", "/api/v3/configure/distinct_cache", nil, &req, nil); err != nil {
+		return nil, err
+	}
+	return dvc, nil
+}
+
+// DropDistinctValueCache deletes a distinct value cache of a table
+func (c *Client) DropDistinctValueCache(ctx context.Context, db, table, name string) error {
+	if !c.isV3Core() {
+		return ErrV3CoreOnly
+	}
+	params := url.Values{"db": {db}, "table": {table}, "name": {name}}
+	return c.v3Do(ctx, "DELETE", "/api/v3/configure/distinct_cache", params, nil, nil)
+}
+
+// QuerySQL runs a SQL query on a database of an InfluxDB 3 Core or
+// Enterprise source and decodes its rows into res
+func (c *Client) QuerySQL(ctx context.Context, db, q string, res interface{}) error {
+	if !c.isV3Core() {
+		return ErrV3CoreOnly
+	}
+	return c.v3SQL(ctx, db, q, res)
+}
+
+// v3SQL runs a SQL query on a database and decodes its rows into res
+func (c *Client) v3SQL(ctx context.Context, db, q string, res interface{}) error {
+	params := url.Values{"db": {db}, "q": {q}, "format": {"json"}}
+	return c.v3Do(ctx, "GET", "/api/v3/query_sql", params, nil, res)
+}
+
+// v3Do sends a request to the HTTP API of an InfluxDB 3 Core or Enterprise
+// source with its authorization
+func (c *Client) v3Do(ctx context.Context, method, path string, params url.Values, body, res interface{}) error {
+	u := util.AppendPath(c.URL, path)
+	u.RawQuery = params.Encode()
+	return c.doJSON(ctx, method, u, c.Authorizer, body, res, newV3Error)
+}
+
+// mgmtDo sends a request to the management API of an InfluxDB Clustered or
+// Cloud Dedicated source with its management token. The path is escaped.
+func (c *Client) mgmtDo(ctx context.Context, method, path string, body, res interface{}) error {
+	if c.MgmtURL == nil {
+		return ErrV3ManagementAPI
+	}
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return err
+	}
+	u := util.AppendPath(c.MgmtURL, unescaped)
+	u.RawPath = strings.TrimSuffix(c.MgmtURL.EscapedPath(), "/") + path
+	return c.doJSON(ctx, method, u, c.MgmtAuthorizer, body, res, newV3Error)
+}
+
+// mgmtDatabasePath returns the escaped path of a database of the management
+// API followed by elems. Names that would change the path, such as .., are
+// rejected.
+func mgmtDatabasePath(db string, elems ...string) (string, error) {
+	if db == "" || db == "." || db == ".." {
+		return "", ErrV3DatabaseName
+	}
+	return "/databases/" + strings.Join(append([]string{url.PathEscape(db)}, elems...), "/"), nil
+}
@@ -0,0 +1,201 @@
+package influx_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+// recordedRequest is a request received by a stand-in of an InfluxDB 3 API
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Auth   string
+	Body   string
+}
+
+func newV3StandIn(t *testing.T, responses map[string]string, requests *[]recordedRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Query:  r.URL.RawQuery,
+			Auth:   r.Header.Get("Authorization"),
+			Body:   string(body),
+		})
+		res, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if strings.HasPrefix(res, `{"error":`) {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(res))
+	}))
+}
+
+func TestClient_V3CoreDatabases(t *testing.T) {
+	requests := []recordedRequest{}
+	ts := newV3StandIn(t, map[string]string{
+		"DELETE /api/v3/configure/table": `{"error":"table not found"}`,
+		"GET /api/v3/query_sql":          `[{"table":"cpu","name":"cpu_host_last_cache","key_column_names":["host"],"value_column_names":["usage"],"count":1,"ttl":14400}]`,
+	}, &requests)
+	defer ts.Close()
+
+	client := &influx.Client{Logger: &mocks.TestLogger{}}
+	src := &chronograf.Source{URL: ts.URL, Type: chronograf.InfluxDBv3Enterprise, DatabaseToken: "token"}
+	if err := client.Connect(context.Background(), src); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	db, err := client.CreateDB(ctx, &chronograf.Database{Name: "telegraf", Duration: "720h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.Duration != "30d" {
+		t.Errorf("CreateDB() duration = %s, want 30d", db.Duration)
+	}
+	if err := client.DropDB(ctx, "telegraf"); err != nil {
+		t.Fatal(err)
+	}
+	rp, err := client.UpdateRP(ctx, "telegraf", "autogen", &chronograf.RetentionPolicy{Name: "autogen", Duration: "7d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*rp, chronograf.RetentionPolicy{Name: "autogen", Duration: "1w", Default: true}) {
+		t.Errorf("UpdateRP() = %+v", *rp)
+	}
+	if _, err := client.UpdateRP(ctx, "telegraf", "autogen", &chronograf.RetentionPolicy{Name: "autogen", Duration: "INF"}); err == nil {
+		t.Error("UpdateRP() to an infinite duration succeeded")
+	}
+	if _, err := client.CreateTable(ctx, "telegraf", &influx.Table{
+		Name:   "cpu",
+		Tags:   []string{"host"},
+		Fields: []influx.TableField{{Name: "usage", Type: "float64"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	err = client.DropTable(ctx, "telegraf", "mem")
+	if v3Err, ok := err.(*influx.V3Error); !ok || v3Err.StatusCode != http.StatusBadRequest || v3Err.Message != "table not found" {
+		t.Errorf("DropTable() error = %#v", err)
+	}
+	caches, err := client.LastValueCaches(ctx, "telegraf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []influx.LastValueCache{{Table: "cpu", Name: "cpu_host_last_cache", KeyColumns: []string{"host"}, ValueColumns: []string{"usage"}, Count: 1, TTL: 14400}}
+	if !reflect.DeepEqual(caches, want) {
+		t.Errorf("LastValueCaches() = %+v, want %+v", caches, want)
+	}
+	if err := client.DropDistinctValueCache(ctx, "telegraf", "cpu", "hosts"); err != nil {
+		t.Fatal(err)
+	}
+
+	wantRequests := []recordedRequest{
+		{Method: "POST", Path: "/api/v3/configure/database", Body: `{"db":"telegraf","retention_period":"30d"}`},
+		{Method: "DELETE", Path: "/api/v3/configure/database", Query: "db=telegraf"},
+		{Method: "PUT", Path: "/api/v3/configure/database", Body: `{"db":"telegraf","retention_period":"1w"}`},
+		{Method: "POST", Path: "/api/v3/configure/table", Body: `{"db":"telegraf","table":"cpu","tags":["host"],"fields":[{"name":"usage","type":"float64"}]}`},
+		{Method: "DELETE", Path: "/api/v3/configure/table", Query: "db=telegraf&table=mem"},
+		{Method: "GET", Path: "/api/v3/query_sql", Query: "db=telegraf&format=json&q=SELECT+%2A+FROM+system.last_caches"},
+		{Method: "DELETE", Path: "/api/v3/configure/distinct_cache", Query: "db=telegraf&name=hosts&table=cpu"},
+	}
+	if len(requests) != len(wantRequests) {
+		t.Fatalf("requests = %+v, want %+v", requests, wantRequests)
+	}
+	for i, want := range wantRequests {
+		want.Auth = "Bearer token"
+		if requests[i] != want {
+			t.Errorf("request %d = %+v, want %+v", i, requests[i], want)
+		}
+	}
+}
+
+func TestClient_V3CloudDedicatedDatabases(t *testing.T) {
+	requests := []recordedRequest{}
+	ts := newV3StandIn(t, map[string]string{
+		"GET /api/v0/accounts/acc/clusters/cl/databases": `[{"name":"telegraf","maxTables":500,"maxColumnsPerTable":200,"retentionPeriod":604800000000000}]`,
+	}, &requests)
+	defer ts.Close()
+
+	client := &influx.Client{
+		Logger:   &mocks.TestLogger{},
+		V3Config: chronograf.V3Config{CloudDedicatedManagementURL: ts.URL},
+	}
+	src := &chronograf.Source{
+		URL:             ts.URL,
+		Type:            chronograf.InfluxDBv3CloudDedicated,
+		AccountID:       "acc",
+		ClusterID:       "cl",
+		ManagementToken: "mgmt",
+		DatabaseToken:   "token",
+	}
+	if err := client.Connect(context.Background(), src); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, err := client.CreateDB(ctx, &chronograf.Database{Name: "telegraf"}); err != nil {
+		t.Fatal(err)
+	}
+	rps, err := client.AllRP(ctx, "telegraf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rps, []chronograf.RetentionPolicy{{Name: "autogen", Duration: "1w", Default: true}}) {
+		t.Errorf("AllRP() = %+v", rps)
+	}
+	if _, err := client.UpdateRP(ctx, "telegraf", "autogen", &chronograf.RetentionPolicy{Duration: "INF"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DropDB(ctx, "telegraf"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.DropTable(ctx, "telegraf", "cpu"); err != influx.ErrV3CoreOnly {
+		t.Errorf("DropTable() error = %v, want %v", err, influx.ErrV3CoreOnly)
+	}
+	if _, err := client.CreateTable(ctx, "tele/graf", &influx.Table{Name: "cpu"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, db := range []string{"", ".", ".."} {
+		if err := client.DropDB(ctx, db); err != influx.ErrV3DatabaseName {
+			t.Errorf("DropDB(%q) error = %v, want %v", db, err, influx.ErrV3DatabaseName)
+		}
+		if _, err := client.UpdateRP(ctx, db, "autogen", &chronograf.RetentionPolicy{Duration: "INF"}); err != influx.ErrV3DatabaseName {
+			t.Errorf("UpdateRP(%q) error = %v, want %v", db, err, influx.ErrV3DatabaseName)
+		}
+		if _, err := client.CreateTable(ctx, db, &influx.Table{Name: "cpu"}); err != influx.ErrV3DatabaseName {
+			t.Errorf("CreateTable(%q) error = %v, want %v", db, err, influx.ErrV3DatabaseName)
+		}
+	}
+
+	base := "/api/v0/accounts/acc/clusters/cl/databases"
+	wantRequests := []recordedRequest{
+		{Method: "POST", Path: base, Body: `{"name":"telegraf","retentionPeriod":0}`},
+		{Method: "GET", Path: base},
+		{Method: "PATCH", Path: base + "/telegraf", Body: `{"retentionPeriod":0}`},
+		{Method: "DELETE", Path: base + "/telegraf"},
+		{Method: "POST", Path: base + "/tele%2Fgraf/tables", Body: `{"name":"cpu"}`},
+	}
+	if len(requests) != len(wantRequests) {
+		t.Fatalf("requests = %+v, want %+v", requests, wantRequests)
+	}
+	for i, want := range wantRequests {
+		want.Auth = "Bearer mgmt"
+		if requests[i] != want {
+			t.Errorf("request %d = %+v, want %+v", i, requests[i], want)
+		}
+	}
+}
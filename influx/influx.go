@@ -6,10 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -48,9 +48,13 @@ type Client struct {
 	SrcType            string
 	Logger             chronograf.Logger
 	DefaultDB          string
+	Org                string // Org is the organization of InfluxDB v2 sources
 	V3Config           chronograf.V3Config
+	Limits             QueryLimits // Limits are the default limits of queries; the limits set by the source take precedence
 
 	csvTagsStore *CSVTagsStore // (optional) Store to load CSV tag files from source.TagsCSVPath directory
+	srcID        int           // srcID is the ID of the source, whose in-flight queries are limited
+	limits       QueryLimits   // limits are the limits of the queries to the source
 }
 
 // Response is a partial JSON decoded InfluxQL response used
@@ -74,49 +78,23 @@ func (r *responseType) Error() string {
 }
 
 func (c *Client) query(u *url.URL, q chronograf.Query) (chronograf.Response, error) {
-	u = util.AppendPath(u, "/query")
-
-	req, err := http.NewRequest("POST", u.String(), nil)
+	req, logs, err := c.newQueryRequest(u, q, false)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
-	command := q.Command
-	logs := c.Logger.
-		WithField("component", "proxy").
-		WithField("host", req.Host).
-		WithField("command", command).
-		WithField("db", q.DB).
-		WithField("rp", q.RP)
-	logs.Debug("query")
-
-	params := req.URL.Query()
-	params.Set("q", command)
-	params.Set("db", q.DB)
-	params.Set("rp", q.RP)
-	params.Set("epoch", "ms")
-	if q.Epoch != "" {
-		params.Set("epoch", q.Epoch)
-	}
-	req.URL.RawQuery = params.Encode()
 
-	if c.Authorizer != nil {
-		if err := c.Authorizer.Set(req); err != nil {
-			logs.Error("Error setting authorization header ", err)
-			return nil, err
-		}
-	}
-
-	hc := &http.Client{}
-	hc.Transport = SharedTransport(c.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	resp, err := c.queryHTTPClient().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, upstreamError(err)
 	}
 	defer resp.Body.Close()
 
 	var response responseType
-	b, _ := io.ReadAll(resp.Body)
+	b, err := ReadResponse(resp.Body, c.limits.MaxResponseBytes)
+	if err != nil {
+		logs.Error("Error reading response from influxdb: ", err)
+		return nil, upstreamError(err)
+	}
 	logs.Debug("JSON response from InfluxDB: ", string(b))
 	dec := json.NewDecoder(bytes.NewReader(b))
 	decErr := dec.Decode(&response)
@@ -140,6 +118,50 @@ func (c *Client) query(u *url.URL, q chronograf.Query) (chronograf.Response, err
 	return &response, nil
 }
 
+// newQueryRequest creates the request of the query to the /query endpoint
+// of the source at u, asking for a chunked response if chunked is set.
+func (c *Client) newQueryRequest(u *url.URL, q chronograf.Query, chunked bool) (*http.Request, chronograf.Logger, error) {
+	u = util.AppendPath(u, "/query")
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	command := q.Command
+	logs := c.Logger.
+		WithField("component", "proxy").
+		WithField("host", req.Host).
+		WithField("command", command).
+		WithField("db", q.DB).
+		WithField("rp", q.RP)
+	logs.Debug("query")
+
+	params := req.URL.Query()
+	params.Set("q", command)
+	params.Set("db", q.DB)
+	params.Set("rp", q.RP)
+	params.Set("epoch", "ms")
+	if q.Epoch != "" {
+		params.Set("epoch", q.Epoch)
+	}
+	if chunked {
+		params.Set("chunked", "true")
+		if q.ChunkSize > 0 {
+			params.Set("chunk_size", strconv.Itoa(q.ChunkSize))
+		}
+	}
+	req.URL.RawQuery = params.Encode()
+
+	if c.Authorizer != nil {
+		if err := c.Authorizer.Set(req); err != nil {
+			logs.Error("Error setting authorization header ", err)
+			return nil, nil, err
+		}
+	}
+	return req, logs, nil
+}
+
 type result struct {
 	Response chronograf.Response
 	Err      error
@@ -176,8 +198,20 @@ func (c *Client) Query(ctx context.Context, q chronograf.Query) (chronograf.Resp
 		}
 	}
 
-	resps := make(chan (result))
+	if c.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.limits.Timeout)
+		defer cancel()
+	}
+	release, err := AcquireQuerySlot(ctx, c.srcID, c.limits.MaxConcurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	resps := make(chan (result), 1)
 	go func() {
+		// the slot is held until the upstream query ends
+		defer release()
 		var resp chronograf.Response
 		var err error
 		if c.SrcType == chronograf.InfluxDBv3Core || c.SrcType == chronograf.InfluxDBv3Enterprise {
@@ -289,6 +323,8 @@ func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
 	}
 
 	c.URL = u
+	c.srcID = src.ID
+	c.limits = c.Limits.Of(src)
 
 	if src.Type == chronograf.InfluxDBv3Clustered {
 		// InfluxDB Clustered also provides a management API.
@@ -330,6 +366,9 @@ func (c *Client) Connect(ctx context.Context, src *chronograf.Source) error {
 			}
 		}
 	}
+	if src.Type == chronograf.InfluxDBv2 {
+		c.Org = src.Username // org is stored in Username
+	}
 	c.SrcType = src.Type
 	return nil
 }
@@ -467,22 +506,33 @@ func (c *Client) ping(u *url.URL) (string, string, error) {
 }
 
 // Write POSTs line protocol to a database and retention policy
+// Write writes the points to the source. The points of the same database and
+// retention policy are written in a single request.
 func (c *Client) Write(ctx context.Context, points []chronograf.Point) error {
-	for _, point := range points {
-		if err := c.writePoint(ctx, &point); err != nil {
+	type target struct{ db, rp string }
+	batches := map[target][]string{}
+	targets := []target{}
+	for i := range points {
+		lp, err := toLineProtocol(&points[i])
+		if err != nil {
+			return err
+		}
+		t := target{points[i].Database, points[i].RetentionPolicy}
+		if _, ok := batches[t]; !ok {
+			targets = append(targets, t)
+		}
+		batches[t] = append(batches[t], lp)
+	}
+	for _, t := range targets {
+		if err := c.writeBatch(ctx, t.db, t.rp, strings.Join(batches[t], "\n")); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *Client) writePoint(ctx context.Context, point *chronograf.Point) error {
-	lp, err := toLineProtocol(point)
-	if err != nil {
-		return err
-	}
-
-	err = c.write(ctx, c.URL, point.Database, point.RetentionPolicy, lp)
+func (c *Client) writeBatch(ctx context.Context, db, rp, lp string) error {
+	err := c.write(ctx, c.URL, db, rp, lp)
 	if err == nil {
 		return nil
 	}
@@ -496,13 +546,13 @@ func (c *Client) writePoint(ctx context.Context, point *chronograf.Point) error
 	// If the database was not found, try to recreate it:
 	if strings.Contains(err.Error(), "database not found") {
 		_, err = c.CreateDB(ctx, &chronograf.Database{
-			Name: point.Database,
+			Name: db,
 		})
 		if err != nil {
 			return err
 		}
 		// retry the write
-		return c.write(ctx, c.URL, point.Database, point.RetentionPolicy, lp)
+		return c.write(ctx, c.URL, db, rp, lp)
 	}
 
 	return err
@@ -723,6 +723,38 @@ func Test_Write(t *testing.T) {
 	}
 }
 
+func Test_WriteBatches(t *testing.T) {
+	t.Parallel()
+	writes := []string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		writes = append(writes, r.URL.Query().Get("db")+": "+string(content))
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	client, err := NewClient(ts.URL, log.New(log.DebugLevel))
+	if err != nil {
+		t.Fatal("Unexpected error initializing client: err:", err)
+	}
+	client.Connect(context.Background(), &chronograf.Source{URL: ts.URL})
+
+	err = client.Write(context.Background(), []chronograf.Point{
+		{Database: "a", Measurement: "cpu", Time: 1, Fields: map[string]interface{}{"v": 0.5}},
+		{Database: "b", Measurement: "cpu", Time: 2, Fields: map[string]interface{}{"v": 1.25}},
+		{Database: "a", Measurement: "mem", Time: 3, Fields: map[string]interface{}{"v": int64(2)}},
+	})
+	if err != nil {
+		t.Fatalf("No error expected, but received: %v", err)
+	}
+	want := []string{
+		"a: cpu v=0.5 1\nmem v=2i 3",
+		"b: cpu v=1.25 2",
+	}
+	if strings.Join(writes, "|") != strings.Join(want, "|") {
+		t.Errorf("writes = %q, want %q", writes, want)
+	}
+}
+
 func Test_Query(t *testing.T) {
 	t.Parallel()
 	calledPath := ""
@@ -0,0 +1,560 @@
+package influx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/util"
+)
+
+// v2PageSize is the number of objects requested per page of InfluxDB v2 lists
+const v2PageSize = 100
+
+// ErrNotV2 is returned by the InfluxDB v2 administration methods of clients
+// that are not connected to an InfluxDB v2 source
+var ErrNotV2 = errors.New("source is not an InfluxDB v2 source")
+
+// V2Error is an error response of the InfluxDB v2 API
+type V2Error struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *V2Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("received status code %d from server", e.StatusCode)
+	}
+	return fmt.Sprintf("received status code %d from server: err: %s", e.StatusCode, e.Message)
+}
+
+// RetentionRule is the retention of the data of a bucket. Zero EverySeconds
+// retains the data forever.
+type RetentionRule struct {
+	Type                      string `json:"type"`
+	EverySeconds              int64  `json:"everySeconds"`
+	ShardGroupDurationSeconds int64  `json:"shardGroupDurationSeconds,omitempty"`
+}
+
+// Bucket is a named location where time series data is stored in InfluxDB v2
+type Bucket struct {
+	ID             string          `json:"id,omitempty"`
+	OrgID          string          `json:"orgID,omitempty"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description,omitempty"`
+	Type           string          `json:"type,omitempty"` // Type is either user or system
+	RetentionRules []RetentionRule `json:"retentionRules"`
+	CreatedAt      *time.Time      `json:"createdAt,omitempty"`
+	UpdatedAt      *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// BucketUpdate are the changes to a bucket. Empty fields and a nil
+// Description are left unchanged.
+type BucketUpdate struct {
+	Name           string          `json:"name,omitempty"`
+	Description    *string         `json:"description,omitempty"`
+	RetentionRules []RetentionRule `json:"retentionRules,omitempty"`
+}
+
+// DBRPMapping maps an InfluxDB 1.x database and retention policy to a bucket
+type DBRPMapping struct {
+	ID              string `json:"id,omitempty"`
+	OrgID           string `json:"orgID,omitempty"`
+	BucketID        string `json:"bucketID"`
+	Database        string `json:"database"`
+	RetentionPolicy string `json:"retention_policy"`
+	Default         bool   `json:"default"`
+	Virtual         bool   `json:"virtual,omitempty"` // Virtual mappings are derived from bucket names and cannot be changed
+}
+
+// PermissionResource is the resource of a permission. A resource without an
+// ID or name covers all resources of its type.
+type PermissionResource struct {
+	Type  string `json:"type"`
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	OrgID string `json:"orgID,omitempty"`
+	Org   string `json:"org,omitempty"`
+}
+
+// Permission allows to read or write a resource
+type Permission struct {
+	Action   string             `json:"action"` // Action is either read or write
+	Resource PermissionResource `json:"resource"`
+}
+
+// Authorization is an API token of InfluxDB v2 with the permissions it grants
+type Authorization struct {
+	ID          string       `json:"id,omitempty"`
+	Token       string       `json:"token,omitempty"`
+	Status      string       `json:"status,omitempty"` // Status is either active or inactive
+	Description string       `json:"description,omitempty"`
+	OrgID       string       `json:"orgID,omitempty"`
+	Org         string       `json:"org,omitempty"`
+	UserID      string       `json:"userID,omitempty"`
+	User        string       `json:"user,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	CreatedAt   *time.Time   `json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time   `json:"updatedAt,omitempty"`
+}
+
+// AuthorizationUpdate are the changes to an API token. An empty Status and
+// a nil Description are left unchanged.
+type AuthorizationUpdate struct {
+	Status      string  `json:"status,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// Task is a Flux script that InfluxDB v2 runs on a schedule
+type Task struct {
+	ID              string     `json:"id,omitempty"`
+	OrgID           string     `json:"orgID,omitempty"`
+	Org             string     `json:"org,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	Description     string     `json:"description,omitempty"`
+	Status          string     `json:"status,omitempty"` // Status is either active or inactive
+	Flux            string     `json:"flux,omitempty"`
+	Every           string     `json:"every,omitempty"`
+	Cron            string     `json:"cron,omitempty"`
+	Offset          string     `json:"offset,omitempty"`
+	LatestCompleted *time.Time `json:"latestCompleted,omitempty"`
+	LastRunStatus   string     `json:"lastRunStatus,omitempty"`
+	LastRunError    string     `json:"lastRunError,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+// TaskUpdate are the changes to a task. Empty fields and a nil Description
+// are left unchanged.
+type TaskUpdate struct {
+	Name        string  `json:"name,omitempty"`
+	Status      string  `json:"status,omitempty"`
+	Flux        string  `json:"flux,omitempty"`
+	Every       string  `json:"every,omitempty"`
+	Cron        string  `json:"cron,omitempty"`
+	Offset      string  `json:"offset,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// TaskRun is a run of a task
+type TaskRun struct {
+	ID           string     `json:"id"`
+	TaskID       string     `json:"taskID"`
+	Status       string     `json:"status"`
+	ScheduledFor *time.Time `json:"scheduledFor,omitempty"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	RequestedAt  *time.Time `json:"requestedAt,omitempty"`
+}
+
+// TaskRunLog is a log event of a run of a task
+type TaskRunLog struct {
+	RunID   string    `json:"runID"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// Buckets lists the buckets of the organization of the source
+func (c *Client) Buckets(ctx context.Context) ([]Bucket, error) {
+	buckets := []Bucket{}
+	for offset := 0; ; offset += v2PageSize {
+		params := c.v2OrgParams()
+		params.Set("limit", strconv.Itoa(v2PageSize))
+		params.Set("offset", strconv.Itoa(offset))
+		var page struct {
+			Buckets []Bucket `json:"buckets"`
+		}
+		if err := c.v2Do(ctx, "GET", "/api/v2/buckets", params, nil, &page); err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, page.Buckets...)
+		if len(page.Buckets) < v2PageSize {
+			return buckets, nil
+		}
+	}
+}
+
+// CreateBucket creates a bucket in the organization of the source
+func (c *Client) CreateBucket(ctx context.Context, b *Bucket) (*Bucket, error) {
+	orgID, err := c.v2OrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := *b
+	req.OrgID = orgID
+	if req.RetentionRules == nil {
+		req.RetentionRules = []RetentionRule{}
+	}
+	res := &Bucket{}
+	if err := c.v2Do(ctx, "POST", "/api/v2/buckets", nil, &req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateBucket changes the name, description and retention rules of a bucket
+func (c *Client) UpdateBucket(ctx context.Context, id string, upd *BucketUpdate) (*Bucket, error) {
+	res := &Bucket{}
+	if err := c.v2Do(ctx, "PATCH", "/api/v2/buckets/"+id, nil, upd, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteBucket deletes a bucket and all of its data
+func (c *Client) DeleteBucket(ctx context.Context, id string) error {
+	return c.v2Do(ctx, "DELETE", "/api/v2/buckets/"+id, nil, nil, nil)
+}
+
+// DBRPs lists the DBRP mappings of the organization of the source, optionally
+// only those of a bucket
+func (c *Client) DBRPs(ctx context.Context, bucketID string) ([]DBRPMapping, error) {
+	params := c.v2OrgParams()
+	if bucketID != "" {
+		params.Set("bucketID", bucketID)
+	}
+	var res struct {
+		Content []DBRPMapping `json:"content"`
+	}
+	if err := c.v2Do(ctx, "GET", "/api/v2/dbrps", params, nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Content == nil {
+		res.Content = []DBRPMapping{}
+	}
+	return res.Content, nil
+}
+
+// CreateDBRP maps a database and retention policy to a bucket
+func (c *Client) CreateDBRP(ctx context.Context, m *DBRPMapping) (*DBRPMapping, error) {
+	orgID, err := c.v2OrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := *m
+	req.OrgID = orgID
+	res := &dbrpResponse{}
+	if err := c.v2Do(ctx, "POST", "/api/v2/dbrps", nil, &req, res); err != nil {
+		return nil, err
+	}
+	return &res.DBRPMapping, nil
+}
+
+// UpdateDBRP changes the retention policy and default flag of a DBRP mapping
+func (c *Client) UpdateDBRP(ctx context.Context, id string, m *DBRPMapping) (*DBRPMapping, error) {
+	req := struct {
+		RetentionPolicy string `json:"retention_policy,omitempty"`
+		Default         bool   `json:"default"`
+	}{
+		RetentionPolicy: m.RetentionPolicy,
+		Default:         m.Default,
+	}
+	res := &dbrpResponse{}
+	if err := c.v2Do(ctx, "PATCH", "/api/v2/dbrps/"+id, c.v2OrgParams(), &req, res); err != nil {
+		return nil, err
+	}
+	return &res.DBRPMapping, nil
+}
+
+// DeleteDBRP deletes a DBRP mapping
+func (c *Client) DeleteDBRP(ctx context.Context, id string) error {
+	return c.v2Do(ctx, "DELETE", "/api/v2/dbrps/"+id, c.v2OrgParams(), nil, nil)
+}
+
+// dbrpResponse decodes a DBRP mapping whether or not the server wraps it in
+// a content object, which differs between InfluxDB v2 releases
+type dbrpResponse struct {
+	DBRPMapping
+}
+
+func (r *dbrpResponse) UnmarshalJSON(b []byte) error {
+	var wrapped struct {
+		Content *DBRPMapping `json:"content"`
+	}
+	if err := json.Unmarshal(b, &wrapped); err != nil {
+		return err
+	}
+	if wrapped.Content != nil {
+		r.DBRPMapping = *wrapped.Content
+		return nil
+	}
+	return json.Unmarshal(b, &r.DBRPMapping)
+}
+
+// Authorizations lists the API tokens of the organization of the source
+func (c *Client) Authorizations(ctx context.Context) ([]Authorization, error) {
+	var res struct {
+		Authorizations []Authorization `json:"authorizations"`
+	}
+	if err := c.v2Do(ctx, "GET", "/api/v2/authorizations", c.v2OrgParams(), nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Authorizations == nil {
+		res.Authorizations = []Authorization{}
+	}
+	return res.Authorizations, nil
+}
+
+// CreateAuthorization creates an API token in the organization of the source.
+// Permissions without an organization are scoped to it.
+func (c *Client) CreateAuthorization(ctx context.Context, a *Authorization) (*Authorization, error) {
+	orgID, err := c.v2OrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		OrgID       string       `json:"orgID"`
+		Status      string       `json:"status,omitempty"`
+		Description string       `json:"description,omitempty"`
+		Permissions []Permission `json:"permissions"`
+	}{
+		OrgID:       orgID,
+		Status:      a.Status,
+		Description: a.Description,
+		Permissions: make([]Permission, len(a.Permissions)),
+	}
+	for i, p := range a.Permissions {
+		if p.Resource.OrgID == "" && p.Resource.Org == "" {
+			p.Resource.OrgID = orgID
+		}
+		req.Permissions[i] = p
+	}
+	res := &Authorization{}
+	if err := c.v2Do(ctx, "POST", "/api/v2/authorizations", nil, &req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateAuthorization changes the status and description of an API token
+func (c *Client) UpdateAuthorization(ctx context.Context, id string, upd *AuthorizationUpdate) (*Authorization, error) {
+	res := &Authorization{}
+	if err := c.v2Do(ctx, "PATCH", "/api/v2/authorizations/"+id, nil, upd, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteAuthorization deletes an API token
+func (c *Client) DeleteAuthorization(ctx context.Context, id string) error {
+	return c.v2Do(ctx, "DELETE", "/api/v2/authorizations/"+id, nil, nil, nil)
+}
+
+// Tasks lists the tasks of the organization of the source
+func (c *Client) Tasks(ctx context.Context) ([]Task, error) {
+	tasks := []Task{}
+	after := ""
+	for {
+		params := c.v2OrgParams()
+		params.Set("limit", strconv.Itoa(v2PageSize))
+		if after != "" {
+			params.Set("after", after)
+		}
+		var page struct {
+			Tasks []Task `json:"tasks"`
+		}
+		if err := c.v2Do(ctx, "GET", "/api/v2/tasks", params, nil, &page); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, page.Tasks...)
+		if len(page.Tasks) < v2PageSize {
+			return tasks, nil
+		}
+		after = page.Tasks[len(page.Tasks)-1].ID
+	}
+}
+
+// Task returns a task by ID
+func (c *Client) Task(ctx context.Context, id string) (*Task, error) {
+	res := &Task{}
+	if err := c.v2Do(ctx, "GET", "/api/v2/tasks/"+id, nil, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateTask creates a task in the organization of the source. The name and
+// schedule of the task are taken from the option task of its Flux script.
+func (c *Client) CreateTask(ctx context.Context, t *Task) (*Task, error) {
+	orgID, err := c.v2OrgID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req := struct {
+		OrgID       string `json:"orgID"`
+		Status      string `json:"status,omitempty"`
+		Flux        string `json:"flux"`
+		Description string `json:"description,omitempty"`
+	}{
+		OrgID:       orgID,
+		Status:      t.Status,
+		Flux:        t.Flux,
+		Description: t.Description,
+	}
+	res := &Task{}
+	if err := c.v2Do(ctx, "POST", "/api/v2/tasks", nil, &req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// UpdateTask changes the script, schedule, status and description of a task
+func (c *Client) UpdateTask(ctx context.Context, id string, upd *TaskUpdate) (*Task, error) {
+	res := &Task{}
+	if err := c.v2Do(ctx, "PATCH", "/api/v2/tasks/"+id, nil, upd, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteTask deletes a task
+func (c *Client) DeleteTask(ctx context.Context, id string) error {
+	return c.v2Do(ctx, "DELETE", "/api/v2/tasks/"+id, nil, nil, nil)
+}
+
+// TaskRuns lists the most recent runs of a task, at most limit of them if
+// limit is positive
+func (c *Client) TaskRuns(ctx context.Context, taskID string, limit int) ([]TaskRun, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var res struct {
+		Runs []TaskRun `json:"runs"`
+	}
+	if err := c.v2Do(ctx, "GET", "/api/v2/tasks/"+taskID+"/runs", params, nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Runs == nil {
+		res.Runs = []TaskRun{}
+	}
+	return res.Runs, nil
+}
+
+// TaskRunLogs returns the log events of a run of a task
+func (c *Client) TaskRunLogs(ctx context.Context, taskID, runID string) ([]TaskRunLog, error) {
+	var res struct {
+		Events []TaskRunLog `json:"events"`
+	}
+	path := "/api/v2/tasks/" + taskID + "/runs/" + runID + "/logs"
+	if err := c.v2Do(ctx, "GET", path, nil, nil, &res); err != nil {
+		return nil, err
+	}
+	if res.Events == nil {
+		res.Events = []TaskRunLog{}
+	}
+	return res.Events, nil
+}
+
+// v2OrgParams returns the query parameters selecting the organization of the
+// source, which InfluxDB v2 sources store as the username
+func (c *Client) v2OrgParams() url.Values {
+	params := url.Values{}
+	if c.Org != "" {
+		params.Set("org", c.Org)
+	}
+	return params
+}
+
+// v2OrgID looks up the ID of the organization of the source, which is
+// required to create objects in it
+func (c *Client) v2OrgID(ctx context.Context) (string, error) {
+	var res struct {
+		Orgs []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"orgs"`
+	}
+	if err := c.v2Do(ctx, "GET", "/api/v2/orgs", c.v2OrgParams(), nil, &res); err != nil {
+		return "", err
+	}
+	for _, o := range res.Orgs {
+		if c.Org == "" || o.Name == c.Org {
+			return o.ID, nil
+		}
+	}
+	return "", fmt.Errorf("organization %q not found", c.Org)
+}
+
+// v2Do sends a request to the InfluxDB v2 API of the source with its
+// authorization and decodes the JSON response into res, if set
+func (c *Client) v2Do(ctx context.Context, method, path string, params url.Values, body, res interface{}) error {
+	if c.SrcType != chronograf.InfluxDBv2 {
+		return ErrNotV2
+	}
+	u := util.AppendPath(c.URL, path)
+	u.RawQuery = params.Encode()
+	return c.doJSON(ctx, method, u, c.Authorizer, body, res, func(status int, b []byte) error {
+		v2Err := &V2Error{StatusCode: status}
+		_ = json.Unmarshal(b, v2Err)
+		return v2Err
+	})
+}
+
+// doJSON sends body as JSON, if set, to an API of the source and decodes the
+// JSON response into res, if set. Responses with an error status are turned
+// into an error by apiErr.
+func (c *Client) doJSON(ctx context.Context, method string, u *url.URL, auth Authorizer, body, res interface{}, apiErr func(status int, body []byte) error) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	logs := c.Logger.
+		WithField("component", "proxy").
+		WithField("host", req.Host).
+		WithField("method", method)
+	logs.Debug(u.Path)
+
+	if auth != nil {
+		if err := auth.Set(req); err != nil {
+			logs.Error("Error setting authorization header ", err)
+			return err
+		}
+	}
+
+	hc := &http.Client{}
+	hc.Transport = SharedTransport(c.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return chronograf.ErrUpstreamTimeout
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		b, _ := io.ReadAll(resp.Body)
+		return apiErr(resp.StatusCode, b)
+	}
+	if res == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		logs.WithField("influx_status", resp.StatusCode).
+			Error("Error parsing results from influxdb: err:", err)
+		return err
+	}
+	return nil
+}
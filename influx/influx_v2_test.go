@@ -0,0 +1,216 @@
+package influx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func newV2Client(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *influx.Client) {
+	ts := httptest.NewServer(handler)
+	client := &influx.Client{Logger: &mocks.TestLogger{}}
+	src := &chronograf.Source{URL: ts.URL, Type: chronograf.InfluxDBv2, Username: "my-org", Password: "my-token"}
+	if err := client.Connect(context.Background(), src); err != nil {
+		t.Fatal(err)
+	}
+	return ts, client
+}
+
+func TestClient_Buckets(t *testing.T) {
+	ts, client := newV2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Token my-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.URL.Query().Get("org"); got != "my-org" {
+			t.Errorf("org = %q", got)
+		}
+		// two pages, the first one full
+		buckets := []string{}
+		if r.URL.Query().Get("offset") == "0" {
+			for i := 0; i < 100; i++ {
+				buckets = append(buckets, fmt.Sprintf(`{"id":"%d","name":"b%d","retentionRules":[]}`, i, i))
+			}
+		} else {
+			buckets = append(buckets, `{"id":"100","name":"telegraf","retentionRules":[{"type":"expire","everySeconds":3600}]}`)
+		}
+		fmt.Fprintf(w, `{"buckets":[%s]}`, strings.Join(buckets, ","))
+	})
+	defer ts.Close()
+
+	buckets, err := client.Buckets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 101 {
+		t.Fatalf("len(Buckets()) = %d, want 101", len(buckets))
+	}
+	want := influx.Bucket{ID: "100", Name: "telegraf", RetentionRules: []influx.RetentionRule{{Type: "expire", EverySeconds: 3600}}}
+	if !reflect.DeepEqual(buckets[100], want) {
+		t.Errorf("Buckets()[100] = %+v, want %+v", buckets[100], want)
+	}
+}
+
+func TestClient_CreateBucket(t *testing.T) {
+	ts, client := newV2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/orgs":
+			w.Write([]byte(`{"orgs":[{"id":"0a","name":"my-org"}]}`))
+		case "/api/v2/buckets":
+			var b influx.Bucket
+			json.NewDecoder(r.Body).Decode(&b)
+			if b.OrgID != "0a" {
+				t.Errorf("orgID = %q, want 0a", b.OrgID)
+			}
+			if b.Name == "exists" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"code":"conflict","message":"bucket with name exists already exists"}`))
+				return
+			}
+			b.ID = "1b"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(b)
+		}
+	})
+	defer ts.Close()
+
+	b, err := client.CreateBucket(context.Background(), &influx.Bucket{Name: "telegraf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &influx.Bucket{ID: "1b", OrgID: "0a", Name: "telegraf", RetentionRules: []influx.RetentionRule{}}
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("CreateBucket() = %+v, want %+v", b, want)
+	}
+
+	_, err = client.CreateBucket(context.Background(), &influx.Bucket{Name: "exists"})
+	v2Err, ok := err.(*influx.V2Error)
+	if !ok || v2Err.StatusCode != http.StatusUnprocessableEntity || v2Err.Code != "conflict" {
+		t.Errorf("CreateBucket() error = %#v, want a conflict", err)
+	}
+}
+
+func TestClient_DBRPs(t *testing.T) {
+	mapping := `{"id":"3c","orgID":"0a","bucketID":"1b","database":"telegraf","retention_policy":"autogen","default":true}`
+	ts, client := newV2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v2/orgs":
+			w.Write([]byte(`{"orgs":[{"id":"0a","name":"my-org"}]}`))
+		case r.Method == "GET":
+			if got := r.URL.Query().Get("bucketID"); got != "1b" {
+				t.Errorf("bucketID = %q", got)
+			}
+			w.Write([]byte(`{"content":[` + mapping + `]}`))
+		case r.Method == "POST":
+			// releases before 2.1 respond with the mapping itself
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(mapping))
+		case r.Method == "PATCH":
+			w.Write([]byte(`{"content":` + mapping + `}`))
+		}
+	})
+	defer ts.Close()
+	ctx := context.Background()
+
+	want := influx.DBRPMapping{ID: "3c", OrgID: "0a", BucketID: "1b", Database: "telegraf", RetentionPolicy: "autogen", Default: true}
+	mappings, err := client.DBRPs(ctx, "1b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(mappings, []influx.DBRPMapping{want}) {
+		t.Errorf("DBRPs() = %+v, want %+v", mappings, want)
+	}
+	created, err := client.CreateDBRP(ctx, &influx.DBRPMapping{BucketID: "1b", Database: "telegraf", RetentionPolicy: "autogen"})
+	if err != nil || !reflect.DeepEqual(*created, want) {
+		t.Errorf("CreateDBRP() = %+v, %v, want %+v", created, err, want)
+	}
+	updated, err := client.UpdateDBRP(ctx, "3c", &influx.DBRPMapping{Default: true})
+	if err != nil || !reflect.DeepEqual(*updated, want) {
+		t.Errorf("UpdateDBRP() = %+v, %v, want %+v", updated, err, want)
+	}
+}
+
+func TestClient_CreateAuthorization(t *testing.T) {
+	ts, client := newV2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/orgs":
+			w.Write([]byte(`{"orgs":[{"id":"0a","name":"my-org"}]}`))
+		case "/api/v2/authorizations":
+			body, _ := ioutil.ReadAll(r.Body)
+			want := `{"orgID":"0a","description":"telegraf","permissions":[{"action":"write","resource":{"type":"buckets","id":"1b","orgID":"0a"}}]}`
+			if string(body) != want {
+				t.Errorf("body = %s, want %s", body, want)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"4d","token":"secret","status":"active","description":"telegraf","orgID":"0a","permissions":[]}`))
+		}
+	})
+	defer ts.Close()
+
+	a, err := client.CreateAuthorization(context.Background(), &influx.Authorization{
+		Description: "telegraf",
+		Permissions: []influx.Permission{{Action: "write", Resource: influx.PermissionResource{Type: "buckets", ID: "1b"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != "4d" || a.Token != "secret" {
+		t.Errorf("CreateAuthorization() = %+v", a)
+	}
+}
+
+func TestClient_TaskRuns(t *testing.T) {
+	ts, client := newV2Client(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/tasks/5e/runs":
+			if got := r.URL.Query().Get("limit"); got != "10" {
+				t.Errorf("limit = %q", got)
+			}
+			w.Write([]byte(`{"runs":[{"id":"6f","taskID":"5e","status":"failed"}]}`))
+		case "/api/v2/tasks/5e/runs/6f/logs":
+			w.Write([]byte(`{"events":[{"runID":"6f","time":"2026-10-19T00:00:00Z","message":"error exhausting result iterator"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not found","message":"task not found"}`))
+		}
+	})
+	defer ts.Close()
+	ctx := context.Background()
+
+	runs, err := client.TaskRuns(ctx, "5e", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(runs, []influx.TaskRun{{ID: "6f", TaskID: "5e", Status: "failed"}}) {
+		t.Errorf("TaskRuns() = %+v", runs)
+	}
+	logs, err := client.TaskRunLogs(ctx, "5e", "6f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Message != "error exhausting result iterator" {
+		t.Errorf("TaskRunLogs() = %+v", logs)
+	}
+	if _, err := client.TaskRuns(ctx, "7a", 0); err == nil || err.(*influx.V2Error).StatusCode != http.StatusNotFound {
+		t.Errorf("TaskRuns() of unknown task error = %v", err)
+	}
+}
+
+func TestClient_V2AdminOfV1Source(t *testing.T) {
+	client := &influx.Client{Logger: &mocks.TestLogger{}}
+	if err := client.Connect(context.Background(), &chronograf.Source{URL: "http://localhost:8086", Type: chronograf.InfluxDBv1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Buckets(context.Background()); err != influx.ErrNotV2 {
+		t.Errorf("Buckets() error = %v, want %v", err, influx.ErrNotV2)
+	}
+}
@@ -3,7 +3,6 @@ package influx
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"math/rand"
 	"net/http"
 	"net/url"
@@ -116,15 +115,17 @@ func (c *Client) queryV3(u *url.URL, q chronograf.Query) (chronograf.Response, e
 	}
 
 	// Do the request
-	hc := &http.Client{}
-	hc.Transport = SharedTransport(c.InsecureSkipVerify)
-	resp, err := hc.Do(req)
+	resp, err := c.queryHTTPClient().Do(req)
 	if err != nil {
-		return nil, err
+		return nil, upstreamError(err)
 	}
 	defer resp.Body.Close()
 
-	b, _ := io.ReadAll(resp.Body)
+	b, err := ReadResponse(resp.Body, c.limits.MaxResponseBytes)
+	if err != nil {
+		logs.Error("Error reading response from InfluxDB: ", err)
+		return nil, upstreamError(err)
+	}
 	bodyString := string(b)
 	logs.Debug("JSON response from InfluxDB: ", bodyString)
 
@@ -0,0 +1,109 @@
+package influx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+// QueryLimits limit the queries to a source. Zero values mean no limit.
+type QueryLimits struct {
+	Timeout          time.Duration // Timeout is the maximum duration of a query, including the wait for a free query slot
+	MaxConcurrent    int           // MaxConcurrent is the maximum number of in-flight queries to the source
+	MaxResponseBytes int64         // MaxResponseBytes is the maximum size of the response to a query
+}
+
+// Of returns the limits of the queries to src. The limits set by the source
+// take precedence over l.
+func (l QueryLimits) Of(src *chronograf.Source) QueryLimits {
+	if src.QueryTimeout > 0 {
+		l.Timeout = time.Duration(src.QueryTimeout) * time.Second
+	}
+	if src.MaxConcurrentQueries > 0 {
+		l.MaxConcurrent = src.MaxConcurrentQueries
+	}
+	if src.MaxResponseBytes > 0 {
+		l.MaxResponseBytes = src.MaxResponseBytes
+	}
+	return l
+}
+
+// querySlots are the slots of the in-flight queries of each source. They are
+// shared by all clients, so that the limit holds across requests.
+var querySlots = struct {
+	sync.Mutex
+	sources map[int]chan struct{}
+}{sources: map[int]chan struct{}{}}
+
+// AcquireQuerySlot waits for one of the max query slots of the source with
+// the ID to be free. The returned function frees the slot again. It returns
+// chronograf.ErrUpstreamTimeout if ctx is done first.
+func AcquireQuerySlot(ctx context.Context, srcID int, max int) (func(), error) {
+	if max <= 0 {
+		return func() {}, nil
+	}
+	querySlots.Lock()
+	slots, ok := querySlots.sources[srcID]
+	if !ok || cap(slots) != max {
+		// queries holding a slot of a previous limit free it on their own
+		slots = make(chan struct{}, max)
+		querySlots.sources[srcID] = slots
+	}
+	querySlots.Unlock()
+
+	release := func() { <-slots }
+	// a free slot is taken even if ctx is done
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, chronograf.ErrUpstreamTimeout
+	}
+}
+
+// ReadResponse reads the response body r, failing with
+// chronograf.ErrResponseTooLarge as soon as it exceeds max bytes. A max of
+// zero reads all of r.
+func ReadResponse(r io.Reader, max int64) ([]byte, error) {
+	if max <= 0 {
+		return io.ReadAll(r)
+	}
+	b, err := io.ReadAll(io.LimitReader(r, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > max {
+		return nil, chronograf.ErrResponseTooLarge
+	}
+	return b, nil
+}
+
+// queryHTTPClient returns the HTTP client of the queries to the source,
+// which times out after the query timeout
+func (c *Client) queryHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: SharedTransport(c.InsecureSkipVerify),
+		Timeout:   c.limits.Timeout,
+	}
+}
+
+// upstreamError returns chronograf.ErrUpstreamTimeout if err is a timeout of
+// the request to the source
+func upstreamError(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return chronograf.ErrUpstreamTimeout
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package influx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+)
+
+func TestQueryLimits_Of(t *testing.T) {
+	defaults := influx.QueryLimits{Timeout: time.Minute, MaxConcurrent: 10, MaxResponseBytes: 1 << 20}
+	got := defaults.Of(&chronograf.Source{QueryTimeout: 5, MaxResponseBytes: 100})
+	want := influx.QueryLimits{Timeout: 5 * time.Second, MaxConcurrent: 10, MaxResponseBytes: 100}
+	if got != want {
+		t.Errorf("Of() = %+v, want %+v", got, want)
+	}
+	if got := defaults.Of(&chronograf.Source{}); got != defaults {
+		t.Errorf("Of() = %+v, want the defaults %+v", got, defaults)
+	}
+}
+
+func TestReadResponse(t *testing.T) {
+	if b, err := influx.ReadResponse(strings.NewReader("12345"), 5); err != nil || string(b) != "12345" {
+		t.Errorf("ReadResponse() = %q, %v, want the response", b, err)
+	}
+	if _, err := influx.ReadResponse(strings.NewReader("123456"), 5); err != chronograf.ErrResponseTooLarge {
+		t.Errorf("ReadResponse() error = %v, want %v", err, chronograf.ErrResponseTooLarge)
+	}
+	if b, err := influx.ReadResponse(strings.NewReader("123456"), 0); err != nil || string(b) != "123456" {
+		t.Errorf("ReadResponse() = %q, %v, want the unlimited response", b, err)
+	}
+}
+
+func TestAcquireQuerySlot(t *testing.T) {
+	ctx := context.Background()
+	release, err := influx.AcquireQuerySlot(ctx, 1001, 1)
+	if err != nil {
+		t.Fatalf("AcquireQuerySlot() error = %v", err)
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := influx.AcquireQuerySlot(timeout, 1001, 1); err != chronograf.ErrUpstreamTimeout {
+		t.Errorf("AcquireQuerySlot() of a busy source error = %v, want %v", err, chronograf.ErrUpstreamTimeout)
+	}
+	if release, err := influx.AcquireQuerySlot(timeout, 1002, 1); err != nil {
+		t.Errorf("AcquireQuerySlot() of another source error = %v", err)
+	} else {
+		release()
+	}
+
+	release()
+	if release, err := influx.AcquireQuerySlot(timeout, 1001, 1); err != nil {
+		t.Errorf("AcquireQuerySlot() of a released slot error = %v", err)
+	} else {
+		release()
+	}
+}
+
+func Test_Influx_QueryLimits(t *testing.T) {
+	block := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "SELECT slow":
+			<-block
+		case "SELECT large":
+			w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[0,1],[1,2],[2,3]]}]}]}`))
+			return
+		}
+		w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+	}))
+	defer ts.Close()
+	defer close(block)
+
+	newClient := func(src chronograf.Source) *influx.Client {
+		c := &influx.Client{
+			Logger: log.New(log.DebugLevel),
+			Limits: influx.QueryLimits{MaxResponseBytes: 64},
+		}
+		src.URL = ts.URL
+		if err := c.Connect(context.Background(), &src); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	ctx := context.Background()
+
+	if _, err := newClient(chronograf.Source{ID: 2001}).Query(ctx, chronograf.Query{Command: "SELECT large"}); err != chronograf.ErrResponseTooLarge {
+		t.Errorf("Query() of a large response error = %v, want %v", err, chronograf.ErrResponseTooLarge)
+	}
+	if _, err := newClient(chronograf.Source{ID: 2001, MaxResponseBytes: 1024}).Query(ctx, chronograf.Query{Command: "SELECT large"}); err != nil {
+		t.Errorf("Query() within the limit of the source error = %v", err)
+	}
+
+	slow := newClient(chronograf.Source{ID: 2002, QueryTimeout: 1, MaxConcurrentQueries: 1})
+	start := time.Now()
+	if _, err := slow.Query(ctx, chronograf.Query{Command: "SELECT slow"}); err != chronograf.ErrUpstreamTimeout {
+		t.Errorf("Query() of a slow query error = %v, want %v", err, chronograf.ErrUpstreamTimeout)
+	}
+	if d := time.Since(start); d > 3*time.Second {
+		t.Errorf("Query() of a slow query took %v", d)
+	}
+	// the timed out query has released its slot
+	if _, err := slow.Query(ctx, chronograf.Query{Command: "SELECT fast"}); err != nil {
+		t.Errorf("Query() after a timed out query error = %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package influx
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/influxdata/chronograf"
 )
@@ -58,8 +62,10 @@ func toLineProtocol(point *chronograf.Point) (string, error) {
 			format = fmt.Sprintf("%s=%di", escapeKeys.Replace(field), v)
 		case uint64, uint32, uint16, uint8, uint:
 			format = fmt.Sprintf("%s=%du", escapeKeys.Replace(field), v)
-		case float64, float32:
-			format = fmt.Sprintf("%s=%f", escapeKeys.Replace(field), v)
+		case float64:
+			format = fmt.Sprintf("%s=%s", escapeKeys.Replace(field), strconv.FormatFloat(v, 'f', -1, 64))
+		case float32:
+			format = fmt.Sprintf("%s=%s", escapeKeys.Replace(field), strconv.FormatFloat(float64(v), 'f', -1, 32))
 		case string:
 			format = fmt.Sprintf(`%s="%s"`, escapeKeys.Replace(field), escapeFieldStrings.Replace(v))
 		case bool:
@@ -82,3 +88,120 @@ func toLineProtocol(point *chronograf.Point) (string, error) {
 	}
 	return lp, nil
 }
+
+// FormatLineProtocol formats the point as a line of line protocol with a
+// timestamp in nanoseconds, the inverse of ParseLineProtocol
+func FormatLineProtocol(point *chronograf.Point) (string, error) {
+	return toLineProtocol(point)
+}
+
+// ParseLineProtocol parses a line of line protocol into a point, whose time
+// is in nanoseconds. The timestamp of the line is in units of precision.
+// Points without a timestamp have a zero time.
+func ParseLineProtocol(line string, precision time.Duration) (chronograf.Point, error) {
+	point := chronograf.Point{
+		Tags:   map[string]string{},
+		Fields: map[string]interface{}{},
+	}
+	sections := splitLineProtocol(line, ' ', true)
+	if len(sections) < 2 || len(sections) > 3 {
+		return point, fmt.Errorf("expected a measurement, fields and an optional timestamp separated by spaces")
+	}
+
+	series := splitLineProtocol(sections[0], ',', false)
+	point.Measurement = unescapeLineProtocol(series[0])
+	if point.Measurement == "" {
+		return point, errors.New("missing measurement")
+	}
+	for _, tag := range series[1:] {
+		kv := splitLineProtocol(tag, '=', false)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return point, fmt.Errorf("invalid tag %q", tag)
+		}
+		point.Tags[unescapeLineProtocol(kv[0])] = unescapeLineProtocol(kv[1])
+	}
+
+	for _, field := range splitLineProtocol(sections[1], ',', true) {
+		kv := splitLineProtocol(field, '=', true)
+		if len(kv) != 2 || kv[0] == "" {
+			return point, fmt.Errorf("invalid field %q", field)
+		}
+		value, err := parseFieldValue(kv[1])
+		if err != nil {
+			return point, fmt.Errorf("invalid value of field %q: %v", unescapeLineProtocol(kv[0]), err)
+		}
+		point.Fields[unescapeLineProtocol(kv[0])] = value
+	}
+
+	if len(sections) == 3 {
+		ts, err := strconv.ParseInt(sections[2], 10, 64)
+		if err != nil {
+			return point, fmt.Errorf("invalid timestamp %q", sections[2])
+		}
+		if point.Time, err = scaleTimestamp(ts, precision); err != nil {
+			return point, err
+		}
+	}
+	return point, nil
+}
+
+// scaleTimestamp converts a timestamp in units of precision to nanoseconds
+func scaleTimestamp(ts int64, precision time.Duration) (int64, error) {
+	if precision <= 0 {
+		precision = time.Nanosecond
+	}
+	unit := int64(precision)
+	if ts > math.MaxInt64/unit || ts < math.MinInt64/unit {
+		return 0, fmt.Errorf("timestamp %d out of range", ts)
+	}
+	return ts * unit, nil
+}
+
+// splitLineProtocol splits s at the unescaped occurrences of sep. Quoted
+// strings are not split if quoted is set.
+func splitLineProtocol(s string, sep byte, quoted bool) []string {
+	parts := []string{}
+	start, inQuote := 0, false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case quoted && s[i] == '"':
+			inQuote = !inQuote
+		case s[i] == sep && !inQuote:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescapeLineProtocol removes the escaping of measurements, tag keys, tag
+// values and field keys
+var unescapeLineProtocol = strings.NewReplacer(
+	`\,`, `,`,
+	`\ `, ` `,
+	`\=`, `=`,
+	`\"`, `"`,
+).Replace
+
+// parseFieldValue parses the value of a field
+func parseFieldValue(v string) (interface{}, error) {
+	switch {
+	case len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"':
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(v[1 : len(v)-1]), nil
+	case v == "t" || v == "T" || v == "true" || v == "True" || v == "TRUE":
+		return true, nil
+	case v == "f" || v == "F" || v == "false" || v == "False" || v == "FALSE":
+		return false, nil
+	case strings.HasSuffix(v, "i"):
+		return strconv.ParseInt(v[:len(v)-1], 10, 64)
+	case strings.HasSuffix(v, "u"):
+		return strconv.ParseUint(v[:len(v)-1], 10, 64)
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("%q is not a number, boolean or string", v)
+	}
+	return f, nil
+}
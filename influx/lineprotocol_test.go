@@ -1,6 +1,7 @@
 package influx
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -49,7 +50,7 @@ func Test_toLineProtocol(t *testing.T) {
 					"invalidField": time.Time{},
 				},
 			},
-			want: `telegraf float=88.000000,int=19i,string="mph",time_machine=true,uint=85u`,
+			want: `telegraf float=88,int=19i,string="mph",time_machine=true,uint=85u`,
 		},
 		4: {
 			name: "test all influx data types",
@@ -69,7 +70,7 @@ func Test_toLineProtocol(t *testing.T) {
 				},
 				Time: 497115501000000000,
 			},
-			want: `telegraf,doc=brown,marty=mcfly float=88.000000,int=19i,string="mph",time_machine=true,uint=85u 497115501000000000`,
+			want: `telegraf,doc=brown,marty=mcfly float=88,int=19i,string="mph",time_machine=true,uint=85u 497115501000000000`,
 		},
 		5: {
 			name: "measurements with comma or spaces are escaped",
@@ -127,3 +128,107 @@ func Test_toLineProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLineProtocol(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		precision time.Duration
+		want      chronograf.Point
+		wantErr   bool
+	}{
+		{
+			name: "fields of all types",
+			line: `cpu,host=a,region=us-west float=1.5,int=-2i,uint=3u,bool=t,string="a \"quoted\" \\ string, with spaces" 1500000000000000000`,
+			want: chronograf.Point{
+				Measurement: "cpu",
+				Tags:        map[string]string{"host": "a", "region": "us-west"},
+				Fields: map[string]interface{}{
+					"float":  1.5,
+					"int":    int64(-2),
+					"uint":   uint64(3),
+					"bool":   true,
+					"string": `a "quoted" \ string, with spaces`,
+				},
+				Time: 1500000000000000000,
+			},
+		},
+		{
+			name: "escaped names",
+			line: `O\ Romeo\,\ Romeo,part\=act=JULIET\ II line=33i`,
+			want: chronograf.Point{
+				Measurement: "O Romeo, Romeo",
+				Tags:        map[string]string{"part=act": "JULIET II"},
+				Fields:      map[string]interface{}{"line": int64(33)},
+			},
+		},
+		{
+			name:      "precision",
+			line:      "cpu value=1 1500000000",
+			precision: time.Second,
+			want: chronograf.Point{
+				Measurement: "cpu",
+				Tags:        map[string]string{},
+				Fields:      map[string]interface{}{"value": 1.0},
+				Time:        1500000000000000000,
+			},
+		},
+		{
+			name:    "missing fields",
+			line:    "cpu,host=a",
+			wantErr: true,
+		},
+		{
+			name:    "invalid field value",
+			line:    "cpu value=high",
+			wantErr: true,
+		},
+		{
+			name:    "invalid timestamp",
+			line:    "cpu value=1 yesterday",
+			wantErr: true,
+		},
+		{
+			name:      "timestamp out of range",
+			line:      "cpu value=1 9223372036854775807",
+			precision: time.Second,
+			wantErr:   true,
+		},
+		{
+			name:    "empty tag value",
+			line:    "cpu,host= value=1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLineProtocol(tt.line, tt.precision)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLineProtocol() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLineProtocol() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLineProtocol_RoundTrip(t *testing.T) {
+	point := chronograf.Point{
+		Measurement: "disk io",
+		Tags:        map[string]string{"path": "/var,log", "label": "a=b"},
+		Fields:      map[string]interface{}{"read": 0.1, "ops": int64(12), "note": `say "hi"`},
+		Time:        1516920177345000000,
+	}
+	lp, err := toLineProtocol(&point)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseLineProtocol(lp, time.Nanosecond)
+	if err != nil {
+		t.Fatalf("ParseLineProtocol(%q) error = %v", lp, err)
+	}
+	if !reflect.DeepEqual(got, point) {
+		t.Errorf("ParseLineProtocol(%q) = %#v, want %#v", lp, got, point)
+	}
+}
@@ -0,0 +1,152 @@
+package influx
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// metaQueryResult is a single statement result of an InfluxQL response
+type metaQueryResult struct {
+	Series []struct {
+		Name    string          `json:"name"`
+		Columns []string        `json:"columns"`
+		Values  [][]interface{} `json:"values"`
+	} `json:"series"`
+	Error string `json:"error"`
+}
+
+var metaQueryPrefixes = []string{
+	"SHOW DATABASES",
+	"SHOW MEASUREMENTS",
+	"SHOW SERIES",
+	"SHOW TAG VALUES",
+	"SHOW FIELD KEYS",
+	"SHOW TAG KEYS",
+}
+
+// MetaQueryPrefix returns the kind of meta query, e.g. SHOW TAG VALUES, or an
+// empty string if values of template variables cannot be read from the
+// results of the query.
+func MetaQueryPrefix(query string) string {
+	words := strings.Split(strings.ToUpper(strings.TrimSpace(query)), " ")
+	join := func(n int) string {
+		if len(words) < n {
+			n = len(words)
+		}
+		return strings.Join(words[:n], " ")
+	}
+	for _, prefix := range metaQueryPrefixes {
+		if prefix == join(2) || prefix == join(3) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// MetaQueryValues extracts the values for a template variable from the
+// results of its meta query the same way the web client does.
+func MetaQueryValues(query string, results []byte) ([]string, error) {
+	prefix := MetaQueryPrefix(query)
+	if prefix == "" {
+		return nil, fmt.Errorf("could not find parser for meta query %q", query)
+	}
+
+	var res []metaQueryResult
+	if err := json.Unmarshal(results, &res); err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return []string{}, nil
+	}
+
+	column := func(r metaQueryResult, name string, index int) []string {
+		values := []string{}
+		if len(r.Series) == 0 {
+			return values
+		}
+		s := r.Series[0]
+		if name != "" {
+			index = indexOf(s.Columns, name)
+		}
+		for _, v := range s.Values {
+			if index >= 0 && index < len(v) {
+				values = append(values, fmt.Sprint(v[index]))
+			}
+		}
+		return values
+	}
+
+	switch prefix {
+	case "SHOW FIELD KEYS", "SHOW MEASUREMENTS":
+		name := "fieldKey"
+		if prefix == "SHOW MEASUREMENTS" {
+			name = "name"
+		}
+		values := []string{}
+		errs := []string{}
+		for _, r := range res {
+			if r.Error != "" {
+				errs = append(errs, r.Error)
+				continue
+			}
+			values = append(values, column(r, name, 0)...)
+		}
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("%s", strings.Join(errs, ", "))
+		}
+		return values, nil
+	case "SHOW TAG VALUES":
+		if res[0].Error != "" {
+			return []string{}, nil
+		}
+		keys := []string{}
+		tags := map[string][]string{}
+		for _, s := range res[0].Series {
+			k, v := indexOf(s.Columns, "key"), indexOf(s.Columns, "value")
+			for _, row := range s.Values {
+				if k < 0 || v < 0 || k >= len(row) || v >= len(row) {
+					continue
+				}
+				key := fmt.Sprint(row[k])
+				if _, ok := tags[key]; !ok {
+					keys = append(keys, key)
+				}
+				tags[key] = append(tags[key], fmt.Sprint(row[v]))
+			}
+		}
+		values := []string{}
+		for _, key := range keys {
+			values = append(values, uniqueSorted(tags[key])...)
+		}
+		return values, nil
+	default:
+		if res[0].Error != "" {
+			return nil, fmt.Errorf("%s", res[0].Error)
+		}
+		return column(res[0], "", 0), nil
+	}
+}
+
+func indexOf(columns []string, name string) int {
+	for i, c := range columns {
+		if c == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
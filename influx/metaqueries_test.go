@@ -0,0 +1,70 @@
+package influx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetaQueryValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		results string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "databases",
+			query:   "SHOW DATABASES",
+			results: `[{"series":[{"name":"databases","columns":["name"],"values":[["_internal"],["telegraf"]]}]}]`,
+			want:    []string{"_internal", "telegraf"},
+		},
+		{
+			name:    "measurements",
+			query:   `SHOW MEASUREMENTS ON "telegraf"`,
+			results: `[{"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["mem"]]}]}]`,
+			want:    []string{"cpu", "mem"},
+		},
+		{
+			name:    "field keys",
+			query:   `show field keys on "telegraf" from "cpu"`,
+			results: `[{"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["usage_idle","float"],["usage_user","float"]]}]}]`,
+			want:    []string{"usage_idle", "usage_user"},
+		},
+		{
+			name:    "tag values are unique and sorted",
+			query:   `SHOW TAG VALUES ON "telegraf" FROM "cpu" WITH KEY = "host"`,
+			results: `[{"series":[{"name":"cpu","columns":["key","value"],"values":[["host","b"],["host","a"]]},{"name":"disk","columns":["key","value"],"values":[["host","a"]]}]}]`,
+			want:    []string{"a", "b"},
+		},
+		{
+			name:    "tag values of failed query are empty",
+			query:   `SHOW TAG VALUES WITH KEY = "host"`,
+			results: `[{"error":"database not found"}]`,
+			want:    []string{},
+		},
+		{
+			name:    "failed query",
+			query:   "SHOW DATABASES",
+			results: `[{"error":"unauthorized"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "not a meta query",
+			query:   `SELECT "host" FROM "cpu"`,
+			results: `[]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MetaQueryValues(tt.query, []byte(tt.results))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MetaQueryValues() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MetaQueryValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package influx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/influxdata/chronograf"
+)
+
+var _ QueryStreamer = &Client{}
+
+// QueryStreamer streams the responses of chunked queries
+type QueryStreamer interface {
+	QueryStream(ctx context.Context, q chronograf.Query) (io.ReadCloser, error)
+}
+
+// ErrStreamingUnsupported is returned by QueryStream for sources whose
+// queries cannot be streamed
+var ErrStreamingUnsupported = errors.New("streaming queries are not supported by the source")
+
+// QueryStream issues a chunked query and returns the response body as it is
+// read from the source: a stream of JSON objects, each with the results of a
+// chunk. The query is cancelled when ctx is done or the body is closed, and
+// it holds a query slot of the source until then. Reading the body fails
+// with chronograf.ErrResponseTooLarge once it exceeds the maximum response
+// size, and with chronograf.ErrUpstreamTimeout once the query times out.
+func (c *Client) QueryStream(ctx context.Context, q chronograf.Query) (io.ReadCloser, error) {
+	if chronograf.IsV3SrcType(c.SrcType) {
+		// InfluxDB 3 queries are rewritten from the complete response
+		return nil, ErrStreamingUnsupported
+	}
+
+	cancel := func() {}
+	if c.limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.limits.Timeout)
+	}
+	release, err := AcquireQuerySlot(ctx, c.srcID, c.limits.MaxConcurrent)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	stream := &queryStream{ctx: ctx, max: c.limits.MaxResponseBytes}
+	stream.close = func() {
+		cancel()
+		release()
+	}
+
+	req, logs, err := c.newQueryRequest(c.URL, q, true)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+	hc := &http.Client{Transport: SharedTransport(c.InsecureSkipVerify)}
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		stream.Close()
+		return nil, stream.err(upstreamError(err))
+	}
+	stream.body = resp.Body
+	if resp.StatusCode != http.StatusOK {
+		defer stream.Close()
+		var response responseType
+		b, _ := ReadResponse(resp.Body, 64*1024)
+		logs.Debug("JSON response from InfluxDB: ", string(b))
+		_ = json.Unmarshal(b, &response)
+		return nil, fmt.Errorf("received status code %d from server: err: %s", resp.StatusCode, response.Error())
+	}
+	return stream, nil
+}
+
+// queryStream is the body of a streamed query
+type queryStream struct {
+	ctx   context.Context
+	body  io.ReadCloser
+	max   int64 // max is the maximum size of the body; zero means no limit
+	n     int64 // n is the number of bytes read
+	close func()
+	once  sync.Once
+}
+
+func (s *queryStream) Read(p []byte) (int, error) {
+	if s.max > 0 {
+		if s.n > s.max {
+			return 0, chronograf.ErrResponseTooLarge
+		}
+		// read one byte more than the limit to know if it is exceeded
+		if rest := s.max - s.n + 1; int64(len(p)) > rest {
+			p = p[:rest]
+		}
+	}
+	n, err := s.body.Read(p)
+	s.n += int64(n)
+	if s.max > 0 && s.n > s.max {
+		return n - int(s.n-s.max), chronograf.ErrResponseTooLarge
+	}
+	if err != nil && err != io.EOF {
+		err = s.err(err)
+	}
+	return n, err
+}
+
+// err returns chronograf.ErrUpstreamTimeout if the query timed out
+func (s *queryStream) err(err error) error {
+	if s.ctx.Err() == context.DeadlineExceeded {
+		return chronograf.ErrUpstreamTimeout
+	}
+	return err
+}
+
+// Close cancels the query and frees its query slot
+func (s *queryStream) Close() error {
+	var err error
+	if s.body != nil {
+		err = s.body.Close()
+	}
+	s.once.Do(s.close)
+	return err
+}
@@ -0,0 +1,78 @@
+package influx_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+)
+
+func Test_Influx_QueryStream(t *testing.T) {
+	chunks := []string{
+		`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[0,1],[1,2]]}],"partial":true}]}`,
+		`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[2,3]]}]}]}`,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		if params.Get("chunked") != "true" || params.Get("chunk_size") != "2" {
+			t.Errorf("unexpected query parameters %v", params)
+		}
+		for _, chunk := range chunks {
+			w.Write([]byte(chunk + "\n"))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer ts.Close()
+
+	connect := func(src chronograf.Source) *influx.Client {
+		c := &influx.Client{Logger: log.New(log.DebugLevel)}
+		src.URL = ts.URL
+		if err := c.Connect(context.Background(), &src); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	q := chronograf.Query{Command: "SELECT value FROM cpu", DB: "telegraf", Chunked: true, ChunkSize: 2}
+
+	body, err := connect(chronograf.Source{ID: 3001}).QueryStream(context.Background(), q)
+	if err != nil {
+		t.Fatalf("QueryStream() error = %v", err)
+	}
+	dec := json.NewDecoder(body)
+	for i := 0; ; i++ {
+		var chunk json.RawMessage
+		if err := dec.Decode(&chunk); err == io.EOF {
+			if i != len(chunks) {
+				t.Errorf("QueryStream() streamed %d chunks, want %d", i, len(chunks))
+			}
+			break
+		} else if err != nil {
+			t.Fatalf("QueryStream() chunk %d error = %v", i, err)
+		}
+		if i < len(chunks) && string(chunk) != chunks[i] {
+			t.Errorf("QueryStream() chunk %d = %s, want %s", i, chunk, chunks[i])
+		}
+	}
+	body.Close()
+
+	body, err = connect(chronograf.Source{ID: 3001, MaxResponseBytes: 150}).QueryStream(context.Background(), q)
+	if err != nil {
+		t.Fatalf("QueryStream() error = %v", err)
+	}
+	b, err := ioutil.ReadAll(body)
+	if err != chronograf.ErrResponseTooLarge || len(b) != 150 {
+		t.Errorf("QueryStream() of a large response read %d bytes, error = %v, want 150 bytes, %v", len(b), err, chronograf.ErrResponseTooLarge)
+	}
+	body.Close()
+
+	if _, err := connect(chronograf.Source{ID: 3001, Type: chronograf.InfluxDBv3Core}).QueryStream(context.Background(), q); err != influx.ErrStreamingUnsupported {
+		t.Errorf("QueryStream() of InfluxDB 3 error = %v, want %v", err, influx.ErrStreamingUnsupported)
+	}
+}
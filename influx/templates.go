@@ -0,0 +1,335 @@
+package influx
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+// Template variables that are always provided by dashboards
+const (
+	// TemplateVarInterval is replaced by the GROUP BY interval that yields
+	// DesiredPointsPerGraph points for the duration of the query
+	TemplateVarInterval = ":interval:"
+	// TemplateVarDashboardTime is the lower bound of the dashboard time range
+	TemplateVarDashboardTime = ":dashboardTime:"
+	// TemplateVarUpperDashboardTime is the upper bound of the dashboard time range
+	TemplateVarUpperDashboardTime = ":upperDashboardTime:"
+)
+
+// DesiredPointsPerGraph is the number of points a graph aims to display
+// when its query uses TemplateVarInterval
+const DesiredPointsPerGraph = 360
+
+// TimeRangeTemplates creates the dashboard time range templates for lower
+// and upper bounds. Bounds containing a ':' are timestamps that are quoted
+// when rendered, any other bound (e.g. now() - 1h) is inserted as-is. An
+// empty upper bound means now().
+func TimeRangeTemplates(lower, upper string) []chronograf.TemplateVar {
+	if upper == "" {
+		upper = "now()"
+	}
+	timeValue := func(v string) chronograf.TemplateValue {
+		typ := "constant"
+		if strings.Contains(v, ":") {
+			typ = "timeStamp"
+		}
+		return chronograf.TemplateValue{Value: v, Type: typ, Selected: true}
+	}
+	return []chronograf.TemplateVar{
+		{
+			Var:    TemplateVarDashboardTime,
+			Values: []chronograf.TemplateValue{timeValue(lower)},
+		},
+		{
+			Var:    TemplateVarUpperDashboardTime,
+			Values: []chronograf.TemplateValue{timeValue(upper)},
+		},
+	}
+}
+
+// Interval returns the GROUP BY interval in milliseconds used for a query
+// spanning duration d.
+func Interval(d time.Duration) int64 {
+	ms := float64(d) / float64(time.Millisecond)
+	return int64(math.Floor(ms/DesiredPointsPerGraph + 0.5))
+}
+
+// ReplaceInterval replaces TemplateVarInterval within the query by the
+// interval for a query spanning duration d.
+func ReplaceInterval(query string, d time.Duration) string {
+	if !strings.Contains(query, TemplateVarInterval) {
+		return query
+	}
+	return strings.Replace(query, TemplateVarInterval, fmt.Sprintf("%dms", Interval(d)), -1)
+}
+
+// TemplateReplace replaces all templates within the query by their selected
+// value. Templates are rendered in dependency order so that values referring
+// to other templates are themselves replaced. Quoting follows the web
+// client: keys, measurements and databases are double quoted, tag values and
+// timestamps are single quoted and all other values are inserted as-is.
+// Within regular expressions values are never quoted.
+func TemplateReplace(query string, templates []chronograf.TemplateVar) (string, error) {
+	sorted, err := sortTemplates(templates)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range sorted {
+		if query, err = renderTemplate(query, t); err != nil {
+			return "", err
+		}
+	}
+	return query, nil
+}
+
+// SelectedTemplateValue returns the selected value of the template.
+func SelectedTemplateValue(t chronograf.TemplateVar) (chronograf.TemplateValue, bool) {
+	for _, v := range t.Values {
+		if v.Selected {
+			return v, true
+		}
+	}
+	return chronograf.TemplateValue{}, false
+}
+
+func renderTemplate(query string, t chronograf.TemplateVar) (string, error) {
+	if len(t.Values) == 0 || !strings.Contains(query, t.Var) {
+		return query, nil
+	}
+	tv, ok := SelectedTemplateValue(t)
+	if !ok {
+		return query, nil
+	}
+
+	switch tv.Type {
+	case "tagKey", "fieldKey", "measurement", "database":
+		q, err := replaceInRegex(query, t.Var, tv.Value)
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(q, t.Var, `"`+tv.Value+`"`, -1), nil
+	case "tagValue", "timeStamp":
+		q, err := replaceInRegex(query, t.Var, tv.Value)
+		if err != nil {
+			return "", err
+		}
+		return strings.Replace(q, t.Var, `'`+tv.Value+`'`, -1), nil
+	case "csv", "constant", "influxql", "flux", "map":
+		return strings.Replace(query, t.Var, tv.Value, -1), nil
+	}
+	return query, nil
+}
+
+// replaceInRegex replaces the first occurrence of search within every
+// regular expression literal compared with =~ or !~.
+func replaceInRegex(query, search, replacement string) (string, error) {
+	for i := 0; i < len(query)-1; {
+		if op := query[i : i+2]; op != "=~" && op != "!~" {
+			i++
+			continue
+		}
+		start := strings.Index(query[i:], "/")
+		if start == -1 {
+			return "", fmt.Errorf("expected token '/' in '%s'", query[i:])
+		}
+		start += i
+		end := strings.Index(query[start+1:], "/")
+		if end == -1 {
+			return "", fmt.Errorf("expected token '/' in '%s'", query[start+1:])
+		}
+		end += start + 1
+
+		content := strings.Replace(query[start+1:end], search, replacement, 1)
+		query = query[:start+1] + content + query[end:]
+		i = start + 1 + len(content)
+	}
+	return query, nil
+}
+
+var templateNamePattern = regexp.MustCompile(`:[^:\s][^:\n]*:`)
+
+// sortTemplates orders templates so that a template is rendered before all
+// templates its values refer to.
+func sortTemplates(templates []chronograf.TemplateVar) ([]chronograf.TemplateVar, error) {
+	names := make([]string, len(templates))
+	deps := make([][]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Var
+		deps[i] = TemplateDependencies(t.Values)
+	}
+	order, err := dependencyOrder(names, deps)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]chronograf.TemplateVar, len(order))
+	for i, j := range order {
+		sorted[len(order)-1-i] = templates[j]
+	}
+	return sorted, nil
+}
+
+// ResolveOrder orders dashboard templates so that every template follows
+// the templates its query, or its values if it has no query, refers to.
+// Templates can be resolved in this order.
+func ResolveOrder(templates []chronograf.Template) ([]chronograf.Template, error) {
+	names := make([]string, len(templates))
+	deps := make([][]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Var
+		switch {
+		case t.Query != nil && t.Query.Command != "":
+			deps[i] = TemplateNames(t.Query.Command)
+		case t.Query != nil && t.Query.Flux != "":
+			deps[i] = TemplateNames(t.Query.Flux)
+		default:
+			deps[i] = TemplateDependencies(t.Values)
+		}
+	}
+	order, err := dependencyOrder(names, deps)
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]chronograf.Template, len(order))
+	for i, j := range order {
+		sorted[i] = templates[j]
+	}
+	return sorted, nil
+}
+
+// dependencyOrder returns the indexes of the named templates in depth-first
+// post order of their dependencies, i.e. dependencies come first.
+func dependencyOrder(names []string, deps [][]string) ([]int, error) {
+	byName := make(map[string]int, len(names))
+	for i, name := range names {
+		byName[name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(names))
+	order := make([]int, 0, len(names))
+	var visit func(i int) error
+	visit = func(i int) error {
+		state[i] = visiting
+		for _, dep := range deps[i] {
+			c, ok := byName[dep]
+			if !ok {
+				continue
+			}
+			switch state[c] {
+			case visiting:
+				return fmt.Errorf("cyclic dependency in template %q", names[c])
+			case unvisited:
+				if err := visit(c); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+		return nil
+	}
+	for i := range names {
+		if state[i] == unvisited {
+			if err := visit(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
+
+// TemplateDependencies returns the names of all templates referenced within
+// the values.
+func TemplateDependencies(values []chronograf.TemplateValue) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, v := range values {
+		for _, name := range TemplateNames(v.Value) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// TemplateNames returns the names of all templates referenced within s.
+func TemplateNames(s string) []string {
+	return templateNamePattern.FindAllString(s, -1)
+}
+
+// fluxIntervalPattern matches flux scripts that use the computed interval
+var fluxIntervalPattern = regexp.MustCompile(`autoInterval|v\.windowPeriod`)
+
+// RenderFluxTemplates prefixes the flux script with the variables the web
+// client provides to dashboard queries: dashboardTime, upperDashboardTime
+// and, if used, autoInterval for backward compatibility and the v record
+// holding timeRangeStart, timeRangeStop, windowPeriod and the selected value
+// of every template. lower and upper must be flux expressions; d is the
+// duration of the time range used to compute the interval.
+func RenderFluxTemplates(script, lower, upper string, templates []chronograf.TemplateVar, d time.Duration) string {
+	imports, body := SplitFluxImports(script)
+
+	extras := []string{}
+	for _, t := range templates {
+		if t.Var == TemplateVarDashboardTime || t.Var == TemplateVarUpperDashboardTime || t.Var == TemplateVarInterval {
+			continue
+		}
+		if len(t.Var) < 2 || !strings.HasPrefix(t.Var, ":") || !strings.HasSuffix(t.Var, ":") {
+			continue
+		}
+		tv, _ := SelectedTemplateValue(t)
+		extras = append(extras, fmt.Sprintf(` "%s" : %s `, t.Var[1:len(t.Var)-1], FluxString(tv.Value)))
+	}
+	extraVars := ""
+	if len(extras) > 0 {
+		extraVars = strings.Join(extras, ",") + " ,"
+	}
+
+	var vars string
+	if fluxIntervalPattern.MatchString(script) {
+		vars = fmt.Sprintf("dashboardTime = %s\nupperDashboardTime = %s\nautoInterval = %dms\nv = {%s timeRangeStart: dashboardTime , timeRangeStop: upperDashboardTime , windowPeriod: autoInterval }", lower, upper, Interval(d), extraVars)
+	} else {
+		vars = fmt.Sprintf("dashboardTime = %s\nupperDashboardTime = %s\nv = {%s timeRangeStart: dashboardTime , timeRangeStop: upperDashboardTime }", lower, upper, extraVars)
+	}
+	return fmt.Sprintf("%s\n\n%s\n\n%s", imports, vars, body)
+}
+
+// SplitFluxImports separates the package clause and import statements at the
+// top of a flux script from its body.
+func SplitFluxImports(script string) (imports, body string) {
+	lines := strings.Split(script, "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "import ") || strings.HasPrefix(line, "package ") {
+			continue
+		}
+		break
+	}
+	return strings.TrimSpace(strings.Join(lines[:i], "\n")), strings.Join(lines[i:], "\n")
+}
+
+var fluxEscaper = strings.NewReplacer(
+	"\r", `\r`,
+	"\n", `\n`,
+	"\t", `\t`,
+	`"`, `\"`,
+	`\`, `\\`,
+	"${", `\${`,
+)
+
+// FluxString returns s as a flux string literal.
+func FluxString(s string) string {
+	return `"` + fluxEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,169 @@
+package influx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/chronograf"
+)
+
+func TestTemplateReplace(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		templates []chronograf.TemplateVar
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:  "select with keys, values and time range",
+			query: `SELECT :field: FROM :db:."autogen".:measurement: WHERE "host" = :host: AND time > :dashboardTime: AND time < :upperDashboardTime:`,
+			templates: append([]chronograf.TemplateVar{
+				{
+					Var:    ":field:",
+					Values: []chronograf.TemplateValue{{Value: "usage_idle", Type: "fieldKey", Selected: true}},
+				},
+				{
+					Var:    ":db:",
+					Values: []chronograf.TemplateValue{{Value: "telegraf", Type: "database", Selected: true}},
+				},
+				{
+					Var:    ":measurement:",
+					Values: []chronograf.TemplateValue{{Value: "cpu", Type: "measurement", Selected: true}},
+				},
+				{
+					Var: ":host:",
+					Values: []chronograf.TemplateValue{
+						{Value: "a", Type: "tagValue"},
+						{Value: "b", Type: "tagValue", Selected: true},
+					},
+				},
+			}, TimeRangeTemplates("now() - 1h", "")...),
+			want: `SELECT "usage_idle" FROM "telegraf"."autogen"."cpu" WHERE "host" = 'b' AND time > now() - 1h AND time < now()`,
+		},
+		{
+			name:  "timestamps are quoted",
+			query: `SELECT "a" FROM "b" WHERE time > :dashboardTime: AND time < :upperDashboardTime:`,
+			templates: TimeRangeTemplates(
+				"2024-01-01T00:00:00.000Z",
+				"2024-01-02T00:00:00.000Z",
+			),
+			want: `SELECT "a" FROM "b" WHERE time > '2024-01-01T00:00:00.000Z' AND time < '2024-01-02T00:00:00.000Z'`,
+		},
+		{
+			name:  "values are not quoted within regular expressions",
+			query: `SELECT "a" FROM "b" WHERE "host" =~ /^:host:$/ AND "cpu" = :host:`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":host:",
+					Values: []chronograf.TemplateValue{{Value: "server01", Type: "tagValue", Selected: true}},
+				},
+			},
+			want: `SELECT "a" FROM "b" WHERE "host" =~ /^server01$/ AND "cpu" = 'server01'`,
+		},
+		{
+			name:  "csv and constant values are inserted as-is",
+			query: `SELECT :fields: FROM "cpu" LIMIT :limit:`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":fields:",
+					Values: []chronograf.TemplateValue{{Value: `"a", "b"`, Type: "csv", Selected: true}},
+				},
+				{
+					Var:    ":limit:",
+					Values: []chronograf.TemplateValue{{Value: "10", Type: "constant", Selected: true}},
+				},
+			},
+			want: `SELECT "a", "b" FROM "cpu" LIMIT 10`,
+		},
+		{
+			name:  "templates without selected value are kept",
+			query: `SELECT "a" FROM :measurement:`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":measurement:",
+					Values: []chronograf.TemplateValue{{Value: "cpu", Type: "measurement"}},
+				},
+			},
+			want: `SELECT "a" FROM :measurement:`,
+		},
+		{
+			name:  "values referring to other templates are rendered first",
+			query: `SELECT "a" FROM "b" WHERE time > :start:`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":offset:",
+					Values: []chronograf.TemplateValue{{Value: "2h", Type: "constant", Selected: true}},
+				},
+				{
+					Var:    ":start:",
+					Values: []chronograf.TemplateValue{{Value: "now() - :offset:", Type: "constant", Selected: true}},
+				},
+			},
+			want: `SELECT "a" FROM "b" WHERE time > now() - 2h`,
+		},
+		{
+			name:  "cyclic dependencies are an error",
+			query: `SELECT :a: FROM "b"`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":a:",
+					Values: []chronograf.TemplateValue{{Value: ":b:", Type: "constant", Selected: true}},
+				},
+				{
+					Var:    ":b:",
+					Values: []chronograf.TemplateValue{{Value: ":a:", Type: "constant", Selected: true}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:  "unterminated regular expression is an error",
+			query: `SELECT "a" FROM "b" WHERE "host" =~ /:host:`,
+			templates: []chronograf.TemplateVar{
+				{
+					Var:    ":host:",
+					Values: []chronograf.TemplateValue{{Value: "a", Type: "tagValue", Selected: true}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateReplace(tt.query, tt.templates)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TemplateReplace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TemplateReplace() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceInterval(t *testing.T) {
+	got := ReplaceInterval(`SELECT mean("a") FROM "b" GROUP BY time(:interval:)`, time.Hour)
+	want := `SELECT mean("a") FROM "b" GROUP BY time(10000ms)`
+	if got != want {
+		t.Errorf("ReplaceInterval() = %s, want %s", got, want)
+	}
+}
+
+func TestRenderFluxTemplates(t *testing.T) {
+	templates := []chronograf.TemplateVar{
+		{
+			Var:    ":host:",
+			Values: []chronograf.TemplateValue{{Value: `a"b`, Type: "tagValue", Selected: true}},
+		},
+	}
+	script := "import \"strings\"\n\nfrom(bucket: \"b\")\n  |> range(start: v.timeRangeStart)\n  |> aggregateWindow(every: v.windowPeriod, fn: mean)"
+	got := RenderFluxTemplates(script, "-1h", "now()", templates, time.Hour)
+	want := "import \"strings\"\n\n" +
+		"dashboardTime = -1h\nupperDashboardTime = now()\nautoInterval = 10000ms\n" +
+		`v = { "host" : "a\"b"  , timeRangeStart: dashboardTime , timeRangeStop: upperDashboardTime , windowPeriod: autoInterval }` +
+		"\n\nfrom(bucket: \"b\")\n  |> range(start: v.timeRangeStart)\n  |> aggregateWindow(every: v.windowPeriod, fn: mean)"
+	if got != want {
+		t.Errorf("RenderFluxTemplates() = %q, want %q", got, want)
+	}
+}
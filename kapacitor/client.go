@@ -42,6 +42,9 @@ type KapaClient interface {
 	ListTasks(opt *client.ListTasksOptions) ([]client.Task, error)
 	UpdateTask(link client.Link, opt client.UpdateTaskOptions) (client.Task, error)
 	DeleteTask(link client.Link) error
+	ListTopics(opt *client.ListTopicsOptions) (client.Topics, error)
+	ListTopicEvents(link client.Link, opt *client.ListTopicEventsOptions) (client.TopicEvents, error)
+	ListTopicHandlers(link client.Link) (client.TopicHandlers, error)
 }
 
 // NewClient creates a client that interfaces with Kapacitor tasks
@@ -22,6 +22,11 @@ type MockKapa struct {
 	DeleteError error
 	LastStatus  client.TaskStatus
 
+	ResTopics        client.Topics
+	ResTopicEvents   client.TopicEvents
+	ResTopicHandlers client.TopicHandlers
+	TopicError       error
+
 	*client.CreateTaskOptions
 	client.Link
 	*client.TaskOptions
@@ -61,6 +66,20 @@ func (m *MockKapa) DeleteTask(link client.Link) error {
 	return m.DeleteError
 }
 
+func (m *MockKapa) ListTopics(opt *client.ListTopicsOptions) (client.Topics, error) {
+	return m.ResTopics, nil
+}
+
+func (m *MockKapa) ListTopicEvents(link client.Link, opt *client.ListTopicEventsOptions) (client.TopicEvents, error) {
+	m.Link = link
+	return m.ResTopicEvents, m.TopicError
+}
+
+func (m *MockKapa) ListTopicHandlers(link client.Link) (client.TopicHandlers, error) {
+	m.Link = link
+	return m.ResTopicHandlers, m.TopicError
+}
+
 type MockID struct {
 	ID string
 }
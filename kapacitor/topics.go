@@ -0,0 +1,141 @@
+package kapacitor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	client "github.com/influxdata/kapacitor/client/v1"
+)
+
+// ErrTopicNotFound is returned if kapacitor has no alert topic with the ID
+const ErrTopicNotFound = Error("alert topic not found")
+
+// Topic is an alert topic of kapacitor with the level of its most severe
+// event
+type Topic struct {
+	ID        string `json:"id"`
+	Level     string `json:"level"`
+	Collected int64  `json:"collected"` // Collected is the number of events the topic has received
+}
+
+// TopicEvent is the current state of an alert of a topic
+type TopicEvent struct {
+	ID       string        `json:"id"`
+	Level    string        `json:"level"`
+	Message  string        `json:"message"`
+	Details  string        `json:"details,omitempty"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"` // Duration is how long the alert has been at a level other than OK
+}
+
+// TopicHandler is a handler of the events of a topic
+type TopicHandler struct {
+	ID      string                 `json:"id"`
+	Kind    string                 `json:"kind"`
+	Match   string                 `json:"match,omitempty"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// Topics lists the alert topics whose IDs match the glob pattern and whose
+// level is at least minLevel. Empty arguments list all topics.
+func (c *Client) Topics(ctx context.Context, pattern, minLevel string) ([]Topic, error) {
+	kapa, err := c.kapaClient(c.URL, c.Username, c.Password, c.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := kapa.ListTopics(&client.ListTopicsOptions{
+		Pattern:  pattern,
+		MinLevel: minLevel,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([]Topic, len(res.Topics))
+	for i, t := range res.Topics {
+		topics[i] = Topic{
+			ID:        t.ID,
+			Level:     t.Level,
+			Collected: t.Collected,
+		}
+	}
+	return topics, nil
+}
+
+// TopicEvents lists the current states of the alerts of a topic whose level
+// is at least minLevel
+func (c *Client) TopicEvents(ctx context.Context, topic, minLevel string) ([]TopicEvent, error) {
+	kapa, err := c.kapaClient(c.URL, c.Username, c.Password, c.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := kapa.ListTopicEvents(client.Link{Href: c.TopicHref(topic, "events")}, &client.ListTopicEventsOptions{
+		MinLevel: minLevel,
+	})
+	if err != nil {
+		return nil, topicError(kapa, topic, err)
+	}
+
+	events := make([]TopicEvent, len(res.Events))
+	for i, e := range res.Events {
+		events[i] = TopicEvent{
+			ID:       e.ID,
+			Level:    e.State.Level,
+			Message:  e.State.Message,
+			Details:  e.State.Details,
+			Time:     e.State.Time,
+			Duration: time.Duration(e.State.Duration),
+		}
+	}
+	return events, nil
+}
+
+// TopicHandlers lists the handlers of the events of a topic
+func (c *Client) TopicHandlers(ctx context.Context, topic string) ([]TopicHandler, error) {
+	kapa, err := c.kapaClient(c.URL, c.Username, c.Password, c.InsecureSkipVerify)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := kapa.ListTopicHandlers(client.Link{Href: c.TopicHref(topic, "handlers")})
+	if err != nil {
+		return nil, topicError(kapa, topic, err)
+	}
+
+	handlers := make([]TopicHandler, len(res.Handlers))
+	for i, h := range res.Handlers {
+		handlers[i] = TopicHandler{
+			ID:      h.ID,
+			Kind:    h.Kind,
+			Match:   h.Match,
+			Options: h.Options,
+		}
+	}
+	return handlers, nil
+}
+
+// TopicHref returns the link to a resource of an alert topic, such as its
+// events or handlers
+func (c *Client) TopicHref(topic, resource string) string {
+	return fmt.Sprintf("/kapacitor/v1/alerts/topics/%s/%s", url.PathEscape(topic), resource)
+}
+
+// topicError returns ErrTopicNotFound for the failed request of a resource
+// of a topic if kapacitor does not have the topic, or err otherwise. The
+// kapacitor client does not expose the status of failed requests.
+func topicError(kapa KapaClient, topic string, err error) error {
+	res, listErr := kapa.ListTopics(nil)
+	if listErr != nil {
+		return err
+	}
+	for _, t := range res.Topics {
+		if t.ID == topic {
+			return err
+		}
+	}
+	return ErrTopicNotFound
+}
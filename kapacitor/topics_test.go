@@ -0,0 +1,97 @@
+package kapacitor
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	client "github.com/influxdata/kapacitor/client/v1"
+)
+
+func TestClient_Topics(t *testing.T) {
+	kapa := &MockKapa{
+		ResTopics: client.Topics{
+			Topics: []client.Topic{
+				{ID: "main:cpu:alert2", Level: "CRITICAL", Collected: 5},
+			},
+		},
+		ResTopicEvents: client.TopicEvents{
+			Topic: "main:cpu:alert2",
+			Events: []client.TopicEvent{
+				{
+					ID: "cpu:host=a",
+					State: client.EventState{
+						Level:    "CRITICAL",
+						Message:  "cpu is high",
+						Time:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+						Duration: client.Duration(90 * time.Minute),
+					},
+				},
+			},
+		},
+		ResTopicHandlers: client.TopicHandlers{
+			Topic: "main:cpu:alert2",
+			Handlers: []client.TopicHandler{
+				{ID: "slack", Kind: "slack", Options: map[string]interface{}{"channel": "#alerts"}},
+			},
+		},
+	}
+	c := &Client{
+		kapaClient: func(url, username, password string, insecureSkipVerify bool) (KapaClient, error) {
+			return kapa, nil
+		},
+	}
+	ctx := context.Background()
+
+	topics, err := c.Topics(ctx, "main:*", "WARNING")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Topic{{ID: "main:cpu:alert2", Level: "CRITICAL", Collected: 5}}; !reflect.DeepEqual(topics, want) {
+		t.Errorf("Topics() = %v, want %v", topics, want)
+	}
+
+	events, err := c.TopicEvents(ctx, "main:cpu:alert2", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEvents := []TopicEvent{{
+		ID:       "cpu:host=a",
+		Level:    "CRITICAL",
+		Message:  "cpu is high",
+		Time:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Duration: 90 * time.Minute,
+	}}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("TopicEvents() = %v, want %v", events, wantEvents)
+	}
+	if want := "/kapacitor/v1/alerts/topics/main:cpu:alert2/events"; kapa.Link.Href != want {
+		t.Errorf("TopicEvents() link = %s, want %s", kapa.Link.Href, want)
+	}
+
+	handlers, err := c.TopicHandlers(ctx, "main:cpu:alert2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHandlers := []TopicHandler{{ID: "slack", Kind: "slack", Options: map[string]interface{}{"channel": "#alerts"}}}
+	if !reflect.DeepEqual(handlers, wantHandlers) {
+		t.Errorf("TopicHandlers() = %v, want %v", handlers, wantHandlers)
+	}
+
+	kapa.TopicError = fmt.Errorf("connection refused")
+	if _, err := c.TopicEvents(ctx, "main:cpu:alert2", ""); err != kapa.TopicError {
+		t.Errorf("TopicEvents() error = %v, want %v", err, kapa.TopicError)
+	}
+	if _, err := c.TopicHandlers(ctx, "unknown"); err != ErrTopicNotFound {
+		t.Errorf("TopicHandlers() of an unknown topic error = %v, want %v", err, ErrTopicNotFound)
+	}
+}
+
+func TestClient_TopicHref(t *testing.T) {
+	c := &Client{}
+	if got, want := c.TopicHref("main:cpu/alert 2", "events"), "/kapacitor/v1/alerts/topics/main:cpu%2Falert%202/events"; got != want {
+		t.Errorf("TopicHref() = %s, want %s", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/chronograf"
 	"google.golang.org/protobuf/proto"
@@ -33,27 +34,30 @@ func UnmarshalBuild(data []byte, b *chronograf.BuildInfo) error {
 // MarshalSource encodes a source to binary protobuf format.
 func MarshalSource(s chronograf.Source) ([]byte, error) {
 	return proto.Marshal(&Source{
-		ID:                 int64(s.ID),
-		Name:               s.Name,
-		Type:               s.Type,
-		Username:           s.Username,
-		Password:           s.Password,
-		SharedSecret:       s.SharedSecret,
-		URL:                s.URL,
-		MetaURL:            s.MetaURL,
-		InsecureSkipVerify: s.InsecureSkipVerify,
-		Default:            s.Default,
-		Telegraf:           s.Telegraf,
-		Organization:       s.Organization,
-		Role:               s.Role,
-		DefaultRP:          s.DefaultRP,
-		Version:            s.Version,
-		ClusterID:          s.ClusterID,
-		AccountID:          s.AccountID,
-		ManagementToken:    s.ManagementToken,
-		DatabaseToken:      s.DatabaseToken,
-		TagsCSVPath:        s.TagsCSVPath,
-		DefaultDatabase:    s.DefaultDB,
+		ID:                   int64(s.ID),
+		Name:                 s.Name,
+		Type:                 s.Type,
+		Username:             s.Username,
+		Password:             s.Password,
+		SharedSecret:         s.SharedSecret,
+		URL:                  s.URL,
+		MetaURL:              s.MetaURL,
+		InsecureSkipVerify:   s.InsecureSkipVerify,
+		Default:              s.Default,
+		Telegraf:             s.Telegraf,
+		Organization:         s.Organization,
+		Role:                 s.Role,
+		DefaultRP:            s.DefaultRP,
+		Version:              s.Version,
+		ClusterID:            s.ClusterID,
+		AccountID:            s.AccountID,
+		ManagementToken:      s.ManagementToken,
+		DatabaseToken:        s.DatabaseToken,
+		TagsCSVPath:          s.TagsCSVPath,
+		DefaultDatabase:      s.DefaultDB,
+		QueryTimeout:         int64(s.QueryTimeout),
+		MaxConcurrentQueries: int64(s.MaxConcurrentQueries),
+		MaxResponseBytes:     s.MaxResponseBytes,
 	})
 }
 
@@ -85,6 +89,9 @@ func UnmarshalSource(data []byte, s *chronograf.Source) error {
 	s.DatabaseToken = pb.DatabaseToken
 	s.TagsCSVPath = pb.TagsCSVPath
 	s.DefaultDB = pb.DefaultDatabase
+	s.QueryTimeout = int(pb.QueryTimeout)
+	s.MaxConcurrentQueries = int(pb.MaxConcurrentQueries)
+	s.MaxResponseBytes = pb.MaxResponseBytes
 	return nil
 }
 
@@ -161,11 +168,26 @@ func MarshalLayout(l chronograf.Layout) ([]byte, error) {
 			}
 		}
 
+		colors := make([]*Color, len(c.CellColors))
+		for j, color := range c.CellColors {
+			colors[j] = &Color{
+				ID:    color.ID,
+				Type:  color.Type,
+				Hex:   color.Hex,
+				Name:  color.Name,
+				Value: color.Value,
+			}
+		}
+
 		axes := make(map[string]*Axis, len(c.Axes))
 		for a, r := range c.Axes {
 			axes[a] = &Axis{
 				Bounds: r.Bounds,
 				Label:  r.Label,
+				Prefix: r.Prefix,
+				Suffix: r.Suffix,
+				Base:   r.Base,
+				Scale:  r.Scale,
 			}
 		}
 
@@ -179,6 +201,7 @@ func MarshalLayout(l chronograf.Layout) ([]byte, error) {
 			Queries: queries,
 			Type:    c.Type,
 			Axes:    axes,
+			Colors:  colors,
 		}
 	}
 	return proto.Marshal(&Layout{
@@ -220,24 +243,40 @@ func UnmarshalLayout(data []byte, l *chronograf.Layout) error {
 				}
 			}
 		}
+		var colors []chronograf.CellColor
+		for _, color := range c.Colors {
+			colors = append(colors, chronograf.CellColor{
+				ID:    color.ID,
+				Type:  color.Type,
+				Hex:   color.Hex,
+				Name:  color.Name,
+				Value: color.Value,
+			})
+		}
+
 		axes := make(map[string]chronograf.Axis, len(c.Axes))
 		for a, r := range c.Axes {
 			axes[a] = chronograf.Axis{
 				Bounds: r.Bounds,
 				Label:  r.Label,
+				Prefix: r.Prefix,
+				Suffix: r.Suffix,
+				Base:   r.Base,
+				Scale:  r.Scale,
 			}
 		}
 
 		cells[i] = chronograf.Cell{
-			X:       c.X,
-			Y:       c.Y,
-			W:       c.W,
-			H:       c.H,
-			I:       c.I,
-			Name:    c.Name,
-			Queries: queries,
-			Type:    c.Type,
-			Axes:    axes,
+			X:          c.X,
+			Y:          c.Y,
+			W:          c.W,
+			H:          c.H,
+			I:          c.I,
+			Name:       c.Name,
+			Queries:    queries,
+			Type:       c.Type,
+			Axes:       axes,
+			CellColors: colors,
 		}
 	}
 	l.Cells = cells
@@ -392,9 +431,126 @@ func MarshalDashboard(d chronograf.Dashboard) ([]byte, error) {
 		Templates:    templates,
 		Name:         d.Name,
 		Organization: d.Organization,
+		ACL:          marshalDashboardACL(d.ACL),
+		Shares:       marshalDashboardShares(d.Shares),
+		Managed:      marshalDashboardSync(d.Managed),
 	})
 }
 
+func marshalDashboardSync(m *chronograf.DashboardSync) *DashboardSync {
+	if m == nil {
+		return nil
+	}
+	return &DashboardSync{
+		Slug:           m.Slug,
+		File:           m.File,
+		DefinitionHash: m.DefinitionHash,
+		ContentHash:    m.ContentHash,
+		SyncedAt:       m.SyncedAt.UnixNano(),
+	}
+}
+
+func unmarshalDashboardSync(pb *DashboardSync) *chronograf.DashboardSync {
+	if pb == nil {
+		return nil
+	}
+	return &chronograf.DashboardSync{
+		Slug:           pb.Slug,
+		File:           pb.File,
+		DefinitionHash: pb.DefinitionHash,
+		ContentHash:    pb.ContentHash,
+		SyncedAt:       time.Unix(0, pb.SyncedAt).UTC(),
+	}
+}
+
+func marshalDashboardShares(shares []chronograf.DashboardShare) []*DashboardShare {
+	if len(shares) == 0 {
+		return nil
+	}
+	pb := make([]*DashboardShare, len(shares))
+	for i, s := range shares {
+		pb[i] = &DashboardShare{
+			ID:        s.ID,
+			SourceID:  s.SourceID,
+			Lower:     s.Lower,
+			Upper:     s.Upper,
+			CreatedBy: s.CreatedBy,
+			CreatedAt: s.CreatedAt.UnixNano(),
+			ExpiresAt: s.ExpiresAt.UnixNano(),
+		}
+	}
+	return pb
+}
+
+func unmarshalDashboardShares(pb []*DashboardShare) []chronograf.DashboardShare {
+	if len(pb) == 0 {
+		return nil
+	}
+	shares := make([]chronograf.DashboardShare, len(pb))
+	for i, s := range pb {
+		shares[i] = chronograf.DashboardShare{
+			ID:        s.ID,
+			SourceID:  s.SourceID,
+			Lower:     s.Lower,
+			Upper:     s.Upper,
+			CreatedBy: s.CreatedBy,
+			CreatedAt: time.Unix(0, s.CreatedAt).UTC(),
+			ExpiresAt: time.Unix(0, s.ExpiresAt).UTC(),
+		}
+	}
+	return shares
+}
+
+func marshalDashboardACL(acl *chronograf.DashboardACL) *DashboardACL {
+	if acl == nil {
+		return nil
+	}
+	users := make([]*DashboardUserAccess, len(acl.Users))
+	for i, u := range acl.Users {
+		users[i] = &DashboardUserAccess{
+			ID:     u.ID,
+			Access: u.Access,
+		}
+	}
+	roles := make([]*DashboardRoleAccess, len(acl.Roles))
+	for i, r := range acl.Roles {
+		roles[i] = &DashboardRoleAccess{
+			Name:   r.Name,
+			Access: r.Access,
+		}
+	}
+	return &DashboardACL{
+		Owner: acl.Owner,
+		Users: users,
+		Roles: roles,
+	}
+}
+
+func unmarshalDashboardACL(pb *DashboardACL) *chronograf.DashboardACL {
+	if pb == nil {
+		return nil
+	}
+	users := make([]chronograf.DashboardUserAccess, len(pb.Users))
+	for i, u := range pb.Users {
+		users[i] = chronograf.DashboardUserAccess{
+			ID:     u.ID,
+			Access: u.Access,
+		}
+	}
+	roles := make([]chronograf.DashboardRoleAccess, len(pb.Roles))
+	for i, r := range pb.Roles {
+		roles[i] = chronograf.DashboardRoleAccess{
+			Name:   r.Name,
+			Access: r.Access,
+		}
+	}
+	return &chronograf.DashboardACL{
+		Owner: pb.Owner,
+		Users: users,
+		Roles: roles,
+	}
+}
+
 // UnmarshalDashboard decodes a layout from binary protobuf data.
 func UnmarshalDashboard(data []byte, d *chronograf.Dashboard) error {
 	var pb Dashboard
@@ -587,6 +743,9 @@ func UnmarshalDashboard(data []byte, d *chronograf.Dashboard) error {
 	d.Templates = templates
 	d.Name = pb.Name
 	d.Organization = pb.Organization
+	d.ACL = unmarshalDashboardACL(pb.ACL)
+	d.Shares = unmarshalDashboardShares(pb.Shares)
+	d.Managed = unmarshalDashboardSync(pb.Managed)
 	return nil
 }
 
@@ -923,3 +1082,34 @@ func UnmarshalMapping(data []byte, m *chronograf.Mapping) error {
 func UnmarshalMappingPB(data []byte, m *Mapping) error {
 	return proto.Unmarshal(data, m)
 }
+
+// MarshalInstalledPackage encodes an installed package to binary protobuf format.
+func MarshalInstalledPackage(p *chronograf.InstalledPackage) ([]byte, error) {
+	j, err := json.Marshal(p.Package)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(&InstalledPackage{
+		Organization: p.Organization,
+		Name:         p.Manifest.Name,
+		Version:      p.Manifest.Version,
+		InstalledAt:  p.InstalledAt.UnixNano(),
+		JSON:         string(j),
+	})
+}
+
+// UnmarshalInstalledPackage decodes an installed package from binary protobuf data.
+func UnmarshalInstalledPackage(data []byte, p *chronograf.InstalledPackage) error {
+	var pb InstalledPackage
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(pb.JSON), &p.Package); err != nil {
+		return err
+	}
+	p.Organization = pb.Organization
+	p.Manifest.Name = pb.Name
+	p.Manifest.Version = pb.Version
+	p.InstalledAt = time.Unix(0, pb.InstalledAt).UTC()
+	return nil
+}
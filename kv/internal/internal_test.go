@@ -3,6 +3,7 @@ package internal_test
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/chronograf"
@@ -58,6 +59,19 @@ func TestMarshalSource(t *testing.T) {
 				Telegraf:        "telegraf",
 			},
 		},
+		{
+			name: "Source with query limits",
+			src: chronograf.Source{
+				ID:                   12,
+				Name:                 "Fountain of Truth",
+				Type:                 "influx",
+				URL:                  "http://twin-pines.mall.io:8086",
+				Telegraf:             "telegraf",
+				QueryTimeout:         30,
+				MaxConcurrentQueries: 4,
+				MaxResponseBytes:     10 << 20,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -123,6 +137,15 @@ func TestMarshalLayout(t *testing.T) {
 				I:    "anotherid",
 				Type: "line",
 				Name: "cell1",
+				CellColors: []chronograf.CellColor{
+					{
+						ID:    "color",
+						Type:  "threshold",
+						Hex:   "#FFD255",
+						Name:  "thunder",
+						Value: "90",
+					},
+				},
 				Axes: map[string]chronograf.Axis{
 					"y": {
 						Bounds: []string{"0", "100"},
@@ -228,6 +251,95 @@ func Test_MarshalDashboard(t *testing.T) {
 	}
 }
 
+func Test_MarshalDashboard_WithACL(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Cells:        []chronograf.DashboardCell{},
+		Templates:    []chronograf.Template{},
+		Name:         "Finance",
+		Organization: "1337",
+		ACL: &chronograf.DashboardACL{
+			Owner: 42,
+			Users: []chronograf.DashboardUserAccess{
+				{
+					ID:     7,
+					Access: chronograf.DashboardAccessEdit,
+				},
+			},
+			Roles: []chronograf.DashboardRoleAccess{
+				{
+					Name:   "viewer",
+					Access: chronograf.DashboardAccessView,
+				},
+			},
+		},
+	}
+
+	var actual chronograf.Dashboard
+	if buf, err := internal.MarshalDashboard(dashboard); err != nil {
+		t.Fatal("Error marshaling dashboard: err", err)
+	} else if err := internal.UnmarshalDashboard(buf, &actual); err != nil {
+		t.Fatal("Error unmarshaling dashboard: err:", err)
+	} else if !cmp.Equal(dashboard, actual) {
+		t.Fatalf("Dashboard protobuf copy error: diff follows:\n%s", cmp.Diff(dashboard, actual))
+	}
+}
+
+func Test_MarshalDashboard_WithShares(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Cells:        []chronograf.DashboardCell{},
+		Templates:    []chronograf.Template{},
+		Name:         "Status",
+		Organization: "1337",
+		Shares: []chronograf.DashboardShare{
+			{
+				ID:        "0f3b1c5a",
+				SourceID:  "2",
+				Lower:     "now() - 1h",
+				CreatedBy: 42,
+				CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpiresAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var actual chronograf.Dashboard
+	if buf, err := internal.MarshalDashboard(dashboard); err != nil {
+		t.Fatal("Error marshaling dashboard: err", err)
+	} else if err := internal.UnmarshalDashboard(buf, &actual); err != nil {
+		t.Fatal("Error unmarshaling dashboard: err:", err)
+	} else if !cmp.Equal(dashboard, actual) {
+		t.Fatalf("Dashboard protobuf copy error: diff follows:\n%s", cmp.Diff(dashboard, actual))
+	}
+}
+
+func Test_MarshalDashboard_Managed(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Cells:        []chronograf.DashboardCell{},
+		Templates:    []chronograf.Template{},
+		Name:         "Status",
+		Organization: "1337",
+		Managed: &chronograf.DashboardSync{
+			Slug:           "status",
+			File:           "1337/status.yaml",
+			DefinitionHash: "d3f",
+			ContentHash:    "c0n",
+			SyncedAt:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var actual chronograf.Dashboard
+	if buf, err := internal.MarshalDashboard(dashboard); err != nil {
+		t.Fatal("Error marshaling dashboard: err", err)
+	} else if err := internal.UnmarshalDashboard(buf, &actual); err != nil {
+		t.Fatal("Error unmarshaling dashboard: err:", err)
+	} else if !cmp.Equal(dashboard, actual) {
+		t.Fatalf("Dashboard protobuf copy error: diff follows:\n%s", cmp.Diff(dashboard, actual))
+	}
+}
+
 func Test_MarshalDashboard_WithLegacyBounds(t *testing.T) {
 	dashboard := chronograf.Dashboard{
 		ID: 1,
@@ -15,9 +15,11 @@ var (
 	cellBucket               = []byte("cellsv2")
 	configBucket             = []byte("ConfigV1")
 	dashboardsBucket         = []byte("Dashoard") // keep spelling for backwards compat
+	layoutsBucket            = []byte("LayoutsV1")
 	mappingsBucket           = []byte("MappingsV1")
 	organizationConfigBucket = []byte("OrganizationConfigV1")
 	organizationsBucket      = []byte("OrganizationsV1")
+	packagesBucket           = []byte("PackagesV1")
 	serversBucket            = []byte("Servers")
 	sourcesBucket            = []byte("Sources")
 	usersBucket              = []byte("UsersV2")
@@ -112,9 +114,11 @@ func (s *Service) initialize(ctx context.Context, tx Tx) error {
 		cellBucket,
 		configBucket,
 		dashboardsBucket,
+		layoutsBucket,
 		mappingsBucket,
 		organizationConfigBucket,
 		organizationsBucket,
+		packagesBucket,
 		serversBucket,
 		sourcesBucket,
 		usersBucket,
@@ -153,6 +157,11 @@ func (s *Service) DashboardsStore() chronograf.DashboardsStore {
 	return &dashboardsStore{client: s, IDs: &id.UUID{}}
 }
 
+// LayoutsStore returns a chronograf.LayoutsStore.
+func (s *Service) LayoutsStore() chronograf.LayoutsStore {
+	return &layoutsStore{client: s, IDs: &id.UUID{}}
+}
+
 // MappingsStore returns a chronograf.MappingsStore.
 func (s *Service) MappingsStore() chronograf.MappingsStore {
 	return &mappingsStore{client: s}
@@ -163,6 +172,11 @@ func (s *Service) OrganizationConfigStore() chronograf.OrganizationConfigStore {
 	return &organizationConfigStore{client: s}
 }
 
+// PackagesStore returns a chronograf.PackagesStore.
+func (s *Service) PackagesStore() chronograf.PackagesStore {
+	return &packagesStore{client: s}
+}
+
 // OrganizationsStore returns a chronograf.OrganizationsStore.
 func (s *Service) OrganizationsStore() chronograf.OrganizationsStore {
 	return &organizationsStore{client: s}
@@ -0,0 +1,120 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/kv/internal"
+)
+
+// Ensure layoutsStore implements chronograf.LayoutsStore.
+var _ chronograf.LayoutsStore = &layoutsStore{}
+
+// layoutsStore stores the layouts of the host pages that are created through
+// the API rather than shipped as files
+type layoutsStore struct {
+	client *Service
+	IDs    chronograf.ID
+}
+
+// All returns all layouts of the store
+func (s *layoutsStore) All(ctx context.Context) ([]chronograf.Layout, error) {
+	layouts := []chronograf.Layout{}
+	if err := s.client.kv.View(ctx, func(tx Tx) error {
+		return tx.Bucket(layoutsBucket).ForEach(func(k, v []byte) error {
+			var layout chronograf.Layout
+			if err := internal.UnmarshalLayout(v, &layout); err != nil {
+				return err
+			}
+			layouts = append(layouts, layout)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return layouts, nil
+}
+
+// Add creates a new layout with a new ID. Cells without an ID are given one.
+func (s *layoutsStore) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	if err := s.client.kv.Update(ctx, func(tx Tx) error {
+		id, err := s.IDs.Generate()
+		if err != nil {
+			return err
+		}
+		layout.ID = id
+		if err := s.cellIDs(&layout); err != nil {
+			return err
+		}
+		v, err := internal.MarshalLayout(layout)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(layoutsBucket).Put([]byte(layout.ID), v)
+	}); err != nil {
+		return chronograf.Layout{}, err
+	}
+
+	return layout, nil
+}
+
+// Get returns the layout of the ID
+func (s *layoutsStore) Get(ctx context.Context, ID string) (chronograf.Layout, error) {
+	var layout chronograf.Layout
+	if err := s.client.kv.View(ctx, func(tx Tx) error {
+		v, err := tx.Bucket(layoutsBucket).Get([]byte(ID))
+		if v == nil || err != nil {
+			return chronograf.ErrLayoutNotFound
+		}
+		return internal.UnmarshalLayout(v, &layout)
+	}); err != nil {
+		return chronograf.Layout{}, err
+	}
+
+	return layout, nil
+}
+
+// Delete removes the layout from the store
+func (s *layoutsStore) Delete(ctx context.Context, layout chronograf.Layout) error {
+	return s.client.kv.Update(ctx, func(tx Tx) error {
+		b := tx.Bucket(layoutsBucket)
+		if v, err := b.Get([]byte(layout.ID)); v == nil || err != nil {
+			return chronograf.ErrLayoutNotFound
+		}
+		return b.Delete([]byte(layout.ID))
+	})
+}
+
+// Update replaces an existing layout. Cells without an ID are given one.
+func (s *layoutsStore) Update(ctx context.Context, layout chronograf.Layout) error {
+	return s.client.kv.Update(ctx, func(tx Tx) error {
+		b := tx.Bucket(layoutsBucket)
+		if v, err := b.Get([]byte(layout.ID)); v == nil || err != nil {
+			return chronograf.ErrLayoutNotFound
+		}
+		if err := s.cellIDs(&layout); err != nil {
+			return err
+		}
+		v, err := internal.MarshalLayout(layout)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(layout.ID), v)
+	})
+}
+
+// cellIDs generates the IDs of the cells of the layout without one
+func (s *layoutsStore) cellIDs(layout *chronograf.Layout) error {
+	for i, cell := range layout.Cells {
+		if cell.I != "" {
+			continue
+		}
+		id, err := s.IDs.Generate()
+		if err != nil {
+			return err
+		}
+		layout.Cells[i].I = id
+	}
+	return nil
+}
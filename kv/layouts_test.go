@@ -0,0 +1,88 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/influxdata/chronograf"
+)
+
+func TestLayoutsStore(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.LayoutsStore()
+
+	layout := chronograf.Layout{
+		Application: "custom_app",
+		Measurement: "cpu",
+		Autoflow:    true,
+		Cells: []chronograf.Cell{
+			{
+				W:    4,
+				H:    4,
+				Name: "CPU",
+				Queries: []chronograf.Query{
+					{Command: `SELECT mean("usage_user") FROM "cpu"`, DB: "telegraf", RP: "autogen", GroupBys: []string{}, Wheres: []string{}},
+				},
+				Axes: map[string]chronograf.Axis{
+					"y": {Bounds: []string{"0", "100"}, Label: "%", Base: "10", Scale: "linear"},
+				},
+				Type:       "line",
+				CellColors: []chronograf.CellColor{{ID: "1", Type: "min", Hex: "#00C9FF", Name: "laser", Value: "0"}},
+			},
+			{I: "existing", Name: "Memory", Type: "single-stat"},
+		},
+	}
+
+	got, err := s.Add(ctx, layout)
+	if err != nil {
+		t.Fatalf("LayoutsStore.Add() error = %v", err)
+	}
+	if got.ID == "" {
+		t.Fatal("LayoutsStore.Add() did not generate an ID")
+	}
+	if got.Cells[0].I == "" || got.Cells[1].I != "existing" {
+		t.Errorf("LayoutsStore.Add() cell IDs = %q, %q", got.Cells[0].I, got.Cells[1].I)
+	}
+
+	stored, err := s.Get(ctx, got.ID)
+	if err != nil {
+		t.Fatalf("LayoutsStore.Get() error = %v", err)
+	}
+	if diff := cmp.Diff(got, stored, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("LayoutsStore.Get():\n-want/+got\ndiff %s", diff)
+	}
+
+	stored.Measurement = "mem"
+	stored.Cells = append(stored.Cells, chronograf.Cell{Name: "Swap"})
+	if err := s.Update(ctx, stored); err != nil {
+		t.Fatalf("LayoutsStore.Update() error = %v", err)
+	}
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatalf("LayoutsStore.All() error = %v", err)
+	}
+	if len(all) != 1 || all[0].Measurement != "mem" || len(all[0].Cells) != 3 || all[0].Cells[2].I == "" {
+		t.Errorf("LayoutsStore.All() = %+v, want the updated layout", all)
+	}
+
+	if err := s.Delete(ctx, stored); err != nil {
+		t.Fatalf("LayoutsStore.Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, stored.ID); err != chronograf.ErrLayoutNotFound {
+		t.Errorf("LayoutsStore.Get() error = %v, want %v", err, chronograf.ErrLayoutNotFound)
+	}
+	if err := s.Update(ctx, stored); err != chronograf.ErrLayoutNotFound {
+		t.Errorf("LayoutsStore.Update() error = %v, want %v", err, chronograf.ErrLayoutNotFound)
+	}
+	if err := s.Delete(ctx, stored); err != chronograf.ErrLayoutNotFound {
+		t.Errorf("LayoutsStore.Delete() error = %v, want %v", err, chronograf.ErrLayoutNotFound)
+	}
+}
@@ -0,0 +1,84 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/kv/internal"
+)
+
+// Ensure packagesStore implements chronograf.PackagesStore.
+var _ chronograf.PackagesStore = &packagesStore{}
+
+// packagesStore stores the packages installed in organizations. Packages are
+// keyed by the ID of their organization and their name.
+type packagesStore struct {
+	client *Service
+}
+
+func packageKey(orgID, name string) []byte {
+	return []byte(orgID + "/" + name)
+}
+
+// All returns the packages installed in the organization
+func (s *packagesStore) All(ctx context.Context, orgID string) ([]chronograf.InstalledPackage, error) {
+	prefix := packageKey(orgID, "")
+	pkgs := []chronograf.InstalledPackage{}
+	if err := s.client.kv.View(ctx, func(tx Tx) error {
+		return tx.Bucket(packagesBucket).ForEach(func(k, v []byte) error {
+			if !bytes.HasPrefix(k, prefix) {
+				return nil
+			}
+			var pkg chronograf.InstalledPackage
+			if err := internal.UnmarshalInstalledPackage(v, &pkg); err != nil {
+				return err
+			}
+			pkgs = append(pkgs, pkg)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+
+	return pkgs, nil
+}
+
+// Get returns the package with name installed in the organization
+func (s *packagesStore) Get(ctx context.Context, orgID, name string) (chronograf.InstalledPackage, error) {
+	var pkg chronograf.InstalledPackage
+	if err := s.client.kv.View(ctx, func(tx Tx) error {
+		v, err := tx.Bucket(packagesBucket).Get(packageKey(orgID, name))
+		if v == nil || err != nil {
+			return chronograf.ErrPackageNotFound
+		}
+		return internal.UnmarshalInstalledPackage(v, &pkg)
+	}); err != nil {
+		return chronograf.InstalledPackage{}, err
+	}
+
+	return pkg, nil
+}
+
+// Put installs the package in its organization, replacing an installed version
+func (s *packagesStore) Put(ctx context.Context, pkg chronograf.InstalledPackage) error {
+	v, err := internal.MarshalInstalledPackage(&pkg)
+	if err != nil {
+		return err
+	}
+	return s.client.kv.Update(ctx, func(tx Tx) error {
+		return tx.Bucket(packagesBucket).Put(packageKey(pkg.Organization, pkg.Manifest.Name), v)
+	})
+}
+
+// Delete uninstalls the package from its organization
+func (s *packagesStore) Delete(ctx context.Context, pkg chronograf.InstalledPackage) error {
+	key := packageKey(pkg.Organization, pkg.Manifest.Name)
+	return s.client.kv.Update(ctx, func(tx Tx) error {
+		b := tx.Bucket(packagesBucket)
+		if v, err := b.Get(key); v == nil || err != nil {
+			return chronograf.ErrPackageNotFound
+		}
+		return b.Delete(key)
+	})
+}
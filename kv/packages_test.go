@@ -0,0 +1,78 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/chronograf"
+)
+
+func TestPackagesStore(t *testing.T) {
+	client, err := NewTestClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	s := client.PackagesStore()
+
+	system := chronograf.InstalledPackage{
+		Package: chronograf.Package{
+			Manifest: chronograf.PackageManifest{Name: "system", Version: "1.0.0", Description: "System metrics"},
+			Protoboards: []chronograf.Protoboard{
+				{ID: "system", Meta: chronograf.ProtoboardMeta{Name: "System", Version: "1.0.0", Measurements: []string{"cpu"}}},
+			},
+			Layouts: []chronograf.Layout{
+				{ID: "cpu", Application: "system", Measurement: "cpu"},
+			},
+		},
+		Organization: "1",
+		InstalledAt:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	other := system
+	other.Organization = "2"
+	other.Manifest.Version = "0.9.0"
+
+	for _, pkg := range []chronograf.InstalledPackage{system, other} {
+		if err := s.Put(ctx, pkg); err != nil {
+			t.Fatalf("PackagesStore.Put() error = %v", err)
+		}
+	}
+
+	got, err := s.Get(ctx, "1", "system")
+	if err != nil {
+		t.Fatalf("PackagesStore.Get() error = %v", err)
+	}
+	if diff := cmp.Diff(system, got); diff != "" {
+		t.Errorf("PackagesStore.Get() -want/+got\n%s", diff)
+	}
+
+	upgraded := system
+	upgraded.Manifest.Version = "1.1.0"
+	if err := s.Put(ctx, upgraded); err != nil {
+		t.Fatalf("PackagesStore.Put() error = %v", err)
+	}
+	all, err := s.All(ctx, "1")
+	if err != nil {
+		t.Fatalf("PackagesStore.All() error = %v", err)
+	}
+	if len(all) != 1 || all[0].Manifest.Version != "1.1.0" {
+		t.Errorf("PackagesStore.All() = %+v, want the upgraded package of organization 1", all)
+	}
+
+	if err := s.Delete(ctx, upgraded); err != nil {
+		t.Fatalf("PackagesStore.Delete() error = %v", err)
+	}
+	if _, err := s.Get(ctx, "1", "system"); err != chronograf.ErrPackageNotFound {
+		t.Errorf("PackagesStore.Get() error = %v, want %v", err, chronograf.ErrPackageNotFound)
+	}
+	if err := s.Delete(ctx, upgraded); err != chronograf.ErrPackageNotFound {
+		t.Errorf("PackagesStore.Delete() error = %v, want %v", err, chronograf.ErrPackageNotFound)
+	}
+	if all, _ := s.All(ctx, "2"); len(all) != 1 || all[0].Manifest.Version != "0.9.0" {
+		t.Errorf("PackagesStore.All() = %+v, want the package of organization 2", all)
+	}
+}
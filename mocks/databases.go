@@ -21,6 +21,14 @@ type Databases struct {
 	DropRPF   func(context.Context, string, string) error
 
 	GetMeasurementsF func(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error)
+
+	AllCQF    func(context.Context, string) ([]chronograf.ContinuousQuery, error)
+	CreateCQF func(context.Context, string, *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error)
+	DropCQF   func(context.Context, string, string) error
+
+	AllSubscriptionsF   func(context.Context, string) ([]chronograf.Subscription, error)
+	CreateSubscriptionF func(context.Context, string, *chronograf.Subscription) (*chronograf.Subscription, error)
+	DropSubscriptionF   func(ctx context.Context, db, rp, name string) error
 }
 
 // AllDB lists all databases in the current data source
@@ -67,3 +75,33 @@ func (d *Databases) DropRP(ctx context.Context, rpX string, rpY string) error {
 func (d *Databases) GetMeasurements(ctx context.Context, db string, limit, offset int) ([]chronograf.Measurement, error) {
 	return d.GetMeasurementsF(ctx, db, limit, offset)
 }
+
+// AllCQ lists all continuous queries of a database in the current data source
+func (d *Databases) AllCQ(ctx context.Context, db string) ([]chronograf.ContinuousQuery, error) {
+	return d.AllCQF(ctx, db)
+}
+
+// CreateCQ creates a continuous query of a database in the current data source
+func (d *Databases) CreateCQ(ctx context.Context, db string, cq *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+	return d.CreateCQF(ctx, db, cq)
+}
+
+// DropCQ drops a continuous query of a database in the current data source
+func (d *Databases) DropCQ(ctx context.Context, db string, name string) error {
+	return d.DropCQF(ctx, db, name)
+}
+
+// AllSubscriptions lists all subscriptions of a database in the current data source
+func (d *Databases) AllSubscriptions(ctx context.Context, db string) ([]chronograf.Subscription, error) {
+	return d.AllSubscriptionsF(ctx, db)
+}
+
+// CreateSubscription creates a subscription of a database in the current data source
+func (d *Databases) CreateSubscription(ctx context.Context, db string, sub *chronograf.Subscription) (*chronograf.Subscription, error) {
+	return d.CreateSubscriptionF(ctx, db, sub)
+}
+
+// DropSubscription drops a subscription of a retention policy of a database in the current data source
+func (d *Databases) DropSubscription(ctx context.Context, db, rp, name string) error {
+	return d.DropSubscriptionF(ctx, db, rp, name)
+}
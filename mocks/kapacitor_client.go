@@ -14,6 +14,10 @@ type KapaClient struct {
 	ListTasksF  func(opts *client.ListTasksOptions) ([]client.Task, error)
 	TaskF       func(link client.Link, opts *client.TaskOptions) (client.Task, error)
 	UpdateTaskF func(link client.Link, opts client.UpdateTaskOptions) (client.Task, error)
+
+	ListTopicsF        func(opts *client.ListTopicsOptions) (client.Topics, error)
+	ListTopicEventsF   func(link client.Link, opts *client.ListTopicEventsOptions) (client.TopicEvents, error)
+	ListTopicHandlersF func(link client.Link) (client.TopicHandlers, error)
 }
 
 func (p *KapaClient) CreateTask(opts client.CreateTaskOptions) (client.Task, error) {
@@ -35,3 +39,15 @@ func (p *KapaClient) Task(link client.Link, opts *client.TaskOptions) (client.Ta
 func (p *KapaClient) UpdateTask(link client.Link, opts client.UpdateTaskOptions) (client.Task, error) {
 	return p.UpdateTaskF(link, opts)
 }
+
+func (p *KapaClient) ListTopics(opts *client.ListTopicsOptions) (client.Topics, error) {
+	return p.ListTopicsF(opts)
+}
+
+func (p *KapaClient) ListTopicEvents(link client.Link, opts *client.ListTopicEventsOptions) (client.TopicEvents, error) {
+	return p.ListTopicEventsF(link, opts)
+}
+
+func (p *KapaClient) ListTopicHandlers(link client.Link) (client.TopicHandlers, error) {
+	return p.ListTopicHandlersF(link)
+}
@@ -9,14 +9,29 @@ import (
 var _ chronograf.LayoutsStore = &LayoutsStore{}
 
 type LayoutsStore struct {
-	AllF func(ctx context.Context) ([]chronograf.Layout, error)
-	GetF func(ctx context.Context, id string) (chronograf.Layout, error)
+	AllF    func(ctx context.Context) ([]chronograf.Layout, error)
+	AddF    func(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error)
+	DeleteF func(ctx context.Context, layout chronograf.Layout) error
+	GetF    func(ctx context.Context, id string) (chronograf.Layout, error)
+	UpdateF func(ctx context.Context, layout chronograf.Layout) error
 }
 
 func (s *LayoutsStore) All(ctx context.Context) ([]chronograf.Layout, error) {
 	return s.AllF(ctx)
 }
 
+func (s *LayoutsStore) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	return s.AddF(ctx, layout)
+}
+
+func (s *LayoutsStore) Delete(ctx context.Context, layout chronograf.Layout) error {
+	return s.DeleteF(ctx, layout)
+}
+
 func (s *LayoutsStore) Get(ctx context.Context, id string) (chronograf.Layout, error) {
 	return s.GetF(ctx, id)
 }
+
+func (s *LayoutsStore) Update(ctx context.Context, layout chronograf.Layout) error {
+	return s.UpdateF(ctx, layout)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/influxdata/chronograf"
+)
+
+var _ chronograf.PackagesStore = &PackagesStore{}
+
+type PackagesStore struct {
+	AllF    func(ctx context.Context, orgID string) ([]chronograf.InstalledPackage, error)
+	GetF    func(ctx context.Context, orgID, name string) (chronograf.InstalledPackage, error)
+	PutF    func(ctx context.Context, pkg chronograf.InstalledPackage) error
+	DeleteF func(ctx context.Context, pkg chronograf.InstalledPackage) error
+}
+
+func (s *PackagesStore) All(ctx context.Context, orgID string) ([]chronograf.InstalledPackage, error) {
+	return s.AllF(ctx, orgID)
+}
+
+func (s *PackagesStore) Get(ctx context.Context, orgID, name string) (chronograf.InstalledPackage, error) {
+	return s.GetF(ctx, orgID, name)
+}
+
+func (s *PackagesStore) Put(ctx context.Context, pkg chronograf.InstalledPackage) error {
+	return s.PutF(ctx, pkg)
+}
+
+func (s *PackagesStore) Delete(ctx context.Context, pkg chronograf.InstalledPackage) error {
+	return s.DeleteF(ctx, pkg)
+}
@@ -18,6 +18,7 @@ type Store struct {
 	OrganizationsStore      chronograf.OrganizationsStore
 	ConfigStore             chronograf.ConfigStore
 	OrganizationConfigStore chronograf.OrganizationConfigStore
+	PackagesStore           chronograf.PackagesStore
 }
 
 func (s *Store) Sources(ctx context.Context) chronograf.SourcesStore {
@@ -59,3 +60,7 @@ func (s *Store) Config(ctx context.Context) chronograf.ConfigStore {
 func (s *Store) OrganizationConfig(ctx context.Context) chronograf.OrganizationConfigStore {
 	return s.OrganizationConfigStore
 }
+
+func (s *Store) Packages(ctx context.Context) chronograf.PackagesStore {
+	return s.PackagesStore
+}
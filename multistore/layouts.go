@@ -54,3 +54,47 @@ func (s *Layouts) Get(ctx context.Context, ID string) (chronograf.Layout, error)
 	}
 	return chronograf.Layout{}, err
 }
+
+// Add creates a new layout in the first store that accepts layouts
+func (s *Layouts) Add(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+	var err error = chronograf.ErrLayoutReadOnly
+	for _, store := range s.Stores {
+		var l chronograf.Layout
+		l, err = store.Add(ctx, layout)
+		if err == nil {
+			return l, nil
+		}
+	}
+	return chronograf.Layout{}, err
+}
+
+// Delete removes the layout from the first store that has it
+func (s *Layouts) Delete(ctx context.Context, layout chronograf.Layout) error {
+	return s.write(func(store chronograf.LayoutsStore) error {
+		return store.Delete(ctx, layout)
+	})
+}
+
+// Update replaces the layout in the first store that has it
+func (s *Layouts) Update(ctx context.Context, layout chronograf.Layout) error {
+	return s.write(func(store chronograf.LayoutsStore) error {
+		return store.Update(ctx, layout)
+	})
+}
+
+// write tries the change against each store sequentially until success.
+// Errors of stores that do not have the layout are only returned if no
+// store has it.
+func (s *Layouts) write(change func(chronograf.LayoutsStore) error) error {
+	var err error = chronograf.ErrLayoutNotFound
+	for _, store := range s.Stores {
+		e := change(store)
+		if e == nil {
+			return nil
+		}
+		if err == chronograf.ErrLayoutNotFound {
+			err = e
+		}
+	}
+	return err
+}
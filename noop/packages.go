@@ -0,0 +1,29 @@
+package noop
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/chronograf"
+)
+
+// ensure PackagesStore implements chronograf.PackagesStore
+var _ chronograf.PackagesStore = &PackagesStore{}
+
+type PackagesStore struct{}
+
+func (s *PackagesStore) All(context.Context, string) ([]chronograf.InstalledPackage, error) {
+	return []chronograf.InstalledPackage{}, nil
+}
+
+func (s *PackagesStore) Get(context.Context, string, string) (chronograf.InstalledPackage, error) {
+	return chronograf.InstalledPackage{}, chronograf.ErrPackageNotFound
+}
+
+func (s *PackagesStore) Put(context.Context, chronograf.InstalledPackage) error {
+	return fmt.Errorf("failed to install package")
+}
+
+func (s *PackagesStore) Delete(context.Context, chronograf.InstalledPackage) error {
+	return chronograf.ErrPackageNotFound
+}
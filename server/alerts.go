@@ -0,0 +1,361 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	kapa "github.com/influxdata/chronograf/kapacitor"
+	"github.com/influxdata/influxql"
+)
+
+const (
+	alertHistoryDefaultLimit = 100
+	alertHistoryMaxLimit     = 1000
+	alertHistoryDefaultRange = 24 * time.Hour
+)
+
+// alertLevels are the levels of kapacitor alerts, the least severe first
+var alertLevels = []string{"OK", "INFO", "WARNING", "CRITICAL"}
+
+// alertLevel returns the upper case level and its severity
+func alertLevel(level string) (string, int, error) {
+	level = strings.ToUpper(level)
+	for i, l := range alertLevels {
+		if l == level {
+			return l, i, nil
+		}
+	}
+	return "", 0, fmt.Errorf("invalid level %q: must be one of OK, INFO, WARNING or CRITICAL", level)
+}
+
+type topicLinks struct {
+	Events   string `json:"events"`   // Events link to the current states of the alerts of the topic
+	Handlers string `json:"handlers"` // Handlers link to the handlers of the topic
+}
+
+type topicResponse struct {
+	kapa.Topic
+	Links topicLinks `json:"links"`
+}
+
+type topicsResponse struct {
+	Topics []topicResponse `json:"topics"`
+}
+
+type topicEventsResponse struct {
+	Topic  string            `json:"topic"`
+	Events []kapa.TopicEvent `json:"events"` // Events are ordered from the most severe
+}
+
+type topicHandlersResponse struct {
+	Topic    string              `json:"topic"`
+	Handlers []kapa.TopicHandler `json:"handlers"`
+}
+
+func newTopicResponse(srv chronograf.Server, topic kapa.Topic) topicResponse {
+	base := fmt.Sprintf("/chronograf/v1/sources/%d/kapacitors/%d/alerts/topics/%s", srv.SrcID, srv.ID, url.PathEscape(topic.ID))
+	return topicResponse{
+		Topic: topic,
+		Links: topicLinks{
+			Events:   base + "/events",
+			Handlers: base + "/handlers",
+		},
+	}
+}
+
+// KapacitorTopics lists the alert topics of a kapacitor with the level of
+// their most severe alert
+func (s *Service) KapacitorTopics(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	minLevel := params.Get("minLevel")
+	if minLevel != "" {
+		var err error
+		if minLevel, _, err = alertLevel(minLevel); err != nil {
+			invalidData(w, err, s.Logger)
+			return
+		}
+	}
+	srv, c, ok := s.kapacitorClient(w, r)
+	if !ok {
+		return
+	}
+
+	topics, err := c.Topics(r.Context(), params.Get("pattern"), minLevel)
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+	res := topicsResponse{Topics: []topicResponse{}}
+	for _, topic := range topics {
+		res.Topics = append(res.Topics, newTopicResponse(srv, topic))
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// KapacitorTopicEvents lists the current states of the alerts of a topic,
+// with their levels and how long they have not been OK
+func (s *Service) KapacitorTopicEvents(w http.ResponseWriter, r *http.Request) {
+	minLevel := r.URL.Query().Get("minLevel")
+	if minLevel != "" {
+		var err error
+		if minLevel, _, err = alertLevel(minLevel); err != nil {
+			invalidData(w, err, s.Logger)
+			return
+		}
+	}
+	_, c, ok := s.kapacitorClient(w, r)
+	if !ok {
+		return
+	}
+
+	topic := httprouter.GetParamFromContext(r.Context(), "topic")
+	events, err := c.TopicEvents(r.Context(), topic, minLevel)
+	if err == kapa.ErrTopicNotFound {
+		Error(w, http.StatusNotFound, fmt.Sprintf("alert topic %s not found", topic), s.Logger)
+		return
+	}
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		_, si, _ := alertLevel(events[i].Level)
+		_, sj, _ := alertLevel(events[j].Level)
+		if si != sj {
+			return si > sj
+		}
+		return events[i].ID < events[j].ID
+	})
+	encodeJSON(w, http.StatusOK, topicEventsResponse{Topic: topic, Events: events}, s.Logger)
+}
+
+// KapacitorTopicHandlers lists the handlers of the events of a topic
+func (s *Service) KapacitorTopicHandlers(w http.ResponseWriter, r *http.Request) {
+	_, c, ok := s.kapacitorClient(w, r)
+	if !ok {
+		return
+	}
+
+	topic := httprouter.GetParamFromContext(r.Context(), "topic")
+	handlers, err := c.TopicHandlers(r.Context(), topic)
+	if err == kapa.ErrTopicNotFound {
+		Error(w, http.StatusNotFound, fmt.Sprintf("alert topic %s not found", topic), s.Logger)
+		return
+	}
+	if err != nil {
+		Error(w, http.StatusInternalServerError, err.Error(), s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, topicHandlersResponse{Topic: topic, Handlers: handlers}, s.Logger)
+}
+
+// kapacitorClient returns the client of the kapacitor of the source of the
+// request, writing a not found error if there is none
+func (s *Service) kapacitorClient(w http.ResponseWriter, r *http.Request) (chronograf.Server, *kapa.Client, bool) {
+	id, err := paramID("kid", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return chronograf.Server{}, nil, false
+	}
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return chronograf.Server{}, nil, false
+	}
+
+	ctx := r.Context()
+	srv, err := s.Store.Servers(ctx).Get(ctx, id)
+	if err != nil || srv.SrcID != srcID {
+		notFound(w, id, s.Logger)
+		return srv, nil, false
+	}
+	return srv, kapa.NewClient(srv.URL, srv.Username, srv.Password, srv.InsecureSkipVerify), true
+}
+
+// alertHistoryQuery filters the alerts written by the rules of chronograf
+type alertHistoryQuery struct {
+	Rules  []string // Rules are the names of the rules of the alerts
+	Levels []string
+	Lower  time.Time // Lower is the inclusive start of the range
+	Upper  time.Time // Upper is the exclusive end of the range
+	Limit  int
+}
+
+// newAlertHistoryQuery parses the alert history query of the parameters.
+// The range is the day before now by default.
+func newAlertHistoryQuery(params url.Values, now time.Time) (alertHistoryQuery, error) {
+	q := alertHistoryQuery{
+		Rules:  listParam(params, "rule"),
+		Levels: []string{},
+		Upper:  now,
+		Limit:  alertHistoryDefaultLimit,
+	}
+	for _, level := range listParam(params, "level") {
+		l, _, err := alertLevel(level)
+		if err != nil {
+			return q, err
+		}
+		q.Levels = append(q.Levels, l)
+	}
+	if upper := params.Get("upper"); upper != "" {
+		t, err := time.Parse(time.RFC3339Nano, upper)
+		if err != nil {
+			return q, fmt.Errorf("invalid upper %q: must be an RFC3339 time", upper)
+		}
+		q.Upper = t
+	}
+	q.Lower = q.Upper.Add(-alertHistoryDefaultRange)
+	if lower := params.Get("lower"); lower != "" {
+		t, err := time.Parse(time.RFC3339Nano, lower)
+		if err != nil {
+			return q, fmt.Errorf("invalid lower %q: must be an RFC3339 time", lower)
+		}
+		q.Lower = t
+	}
+	if !q.Lower.Before(q.Upper) {
+		return q, fmt.Errorf("lower must precede upper")
+	}
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > alertHistoryMaxLimit {
+			return q, fmt.Errorf("invalid limit %q: must be between 1 and %d", limit, alertHistoryMaxLimit)
+		}
+		q.Limit = n
+	}
+	return q, nil
+}
+
+// influxQL selects the alerts of the history, the newest first
+func (q alertHistoryQuery) influxQL() string {
+	conds := []string{fmt.Sprintf("time >= %d AND time < %d", q.Lower.UnixNano(), q.Upper.UnixNano())}
+	for _, f := range []struct {
+		tag    string
+		values []string
+	}{
+		{"alertName", q.Rules},
+		{kapa.LevelTag, q.Levels},
+	} {
+		if len(f.values) == 0 {
+			continue
+		}
+		ors := make([]string, len(f.values))
+		for i, v := range f.values {
+			ors[i] = fmt.Sprintf("%s = %s", influxql.QuoteIdent(f.tag), influxql.QuoteString(v))
+		}
+		conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+	}
+	return fmt.Sprintf("SELECT * FROM %s WHERE %s ORDER BY time DESC LIMIT %d",
+		influxql.QuoteIdent(kapa.Database, kapa.RP, kapa.Measurement), strings.Join(conds, " AND "), q.Limit)
+}
+
+// alertHistoryEntry is an alert written by a rule of chronograf
+type alertHistoryEntry struct {
+	Time        time.Time         `json:"time"`
+	Rule        string            `json:"rule"` // Rule is the name of the rule of the alert
+	ID          string            `json:"id"`   // ID identifies the series of the alert
+	Level       string            `json:"level"`
+	TriggerType string            `json:"triggerType,omitempty"`
+	Value       interface{}       `json:"value"`
+	Message     string            `json:"message,omitempty"`
+	Duration    int64             `json:"duration"`       // Duration in nanoseconds is how long the alert has not been OK
+	Tags        map[string]string `json:"tags,omitempty"` // Tags are the tags of the grouped series of the alert
+}
+
+type alertHistoryResponse struct {
+	Alerts []alertHistoryEntry `json:"alerts"` // Alerts are ordered from the newest
+}
+
+// AlertHistory lists the alerts that the rules of chronograf have written to
+// the alerts measurement of the chronograf database of the source, filtered
+// by rule, level and time
+func (s *Service) AlertHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	q, err := newAlertHistoryQuery(r.URL.Query(), time.Now())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	ts, err := s.TimeSeries(src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	alerts, err := queryAlertHistory(ctx, ts, q)
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+	encodeJSON(w, http.StatusOK, alertHistoryResponse{Alerts: alerts}, s.Logger)
+}
+
+// queryAlertHistory reads the alerts of the history. Columns other than the
+// tags and fields written by every rule are the tags of the grouped series.
+func queryAlertHistory(ctx context.Context, ts chronograf.TimeSeries, q alertHistoryQuery) ([]alertHistoryEntry, error) {
+	series, err := queryInfluxQL(ctx, ts, kapa.Database, kapa.RP, q.influxQL())
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []alertHistoryEntry{}
+	for _, s := range series {
+		for _, row := range s.Values {
+			a := alertHistoryEntry{Tags: map[string]string{}}
+			for i, column := range s.Columns {
+				if i >= len(row) || row[i] == nil {
+					continue
+				}
+				v := row[i]
+				switch column {
+				case "time":
+					ns, _ := influxQLTime(v)
+					a.Time = time.Unix(0, ns).UTC()
+				case "alertName":
+					a.Rule = fmt.Sprint(v)
+				case kapa.IDTag:
+					a.ID = fmt.Sprint(v)
+				case kapa.LevelTag:
+					a.Level = fmt.Sprint(v)
+				case "triggerType":
+					a.TriggerType = fmt.Sprint(v)
+				case "value":
+					a.Value = exportField(v)
+				case kapa.MessageField:
+					a.Message = fmt.Sprint(v)
+				case kapa.DurationField:
+					if n, ok := v.(json.Number); ok {
+						a.Duration, _ = n.Int64()
+					}
+				default:
+					a.Tags[column] = fmt.Sprint(v)
+				}
+			}
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts, nil
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_AlertHistory(t *testing.T) {
+	var command chronograf.Query
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+			QueryF: func(_ context.Context, q chronograf.Query) (chronograf.Response, error) {
+				command = q
+				return mocks.NewResponse(`[{"statement_id":0,"series":[{"name":"alerts","columns":["time","alertID","alertName","duration","host","level","message","triggerType","value"],"values":[[1767225600000000000,"cpu:host=a","cpu high",5400000000000,"a","CRITICAL","cpu is high","threshold",95.5]]}]}]`, nil), nil
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/1/alerts?rule=cpu+high&level=critical,warning&lower=2026-01-01T00:00:00Z&upper=2026-01-02T00:00:00Z&limit=10", nil)
+	r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{{Key: "id", Value: "1"}}))
+	w := httptest.NewRecorder()
+	s.AlertHistory(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	want := `{"alerts":[{"time":"2026-01-01T00:00:00Z","rule":"cpu high","id":"cpu:host=a","level":"CRITICAL","triggerType":"threshold","value":95.5,"message":"cpu is high","duration":5400000000000,"tags":{"host":"a"}}]}`
+	if eq, err := jsonEqual(w.Body.String(), want); err != nil || !eq {
+		t.Errorf("AlertHistory() =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+	wantCommand := `SELECT * FROM "chronograf"."autogen".alerts WHERE time >= 1767225600000000000 AND time < 1767312000000000000 AND (alertName = 'cpu high') AND (level = 'CRITICAL' OR level = 'WARNING') ORDER BY time DESC LIMIT 10`
+	if command.Command != wantCommand || command.Epoch != "ns" || command.DB != "chronograf" {
+		t.Errorf("query = %+v\nwant command\n%s", command, wantCommand)
+	}
+
+	for _, params := range []string{"level=major", "limit=1001", "lower=2026-01-02T00:00:00Z&upper=2026-01-01T00:00:00Z", "upper=yesterday"} {
+		r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/1/alerts?"+params, nil)
+		r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{{Key: "id", Value: "1"}}))
+		w := httptest.NewRecorder()
+		s.AlertHistory(w, r)
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("AlertHistory() of %s status = %d, want %d", params, w.Code, http.StatusUnprocessableEntity)
+		}
+	}
+}
+
+func TestService_KapacitorTopics(t *testing.T) {
+	kapa := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/kapacitor/v1/alerts/topics":
+			w.Write([]byte(`{"topics":[{"id":"main:cpu:alert2","level":"CRITICAL","collected":2}]}`))
+		case "/kapacitor/v1/alerts/topics/main:cpu:alert2/events":
+			w.Write([]byte(`{"topic":"main:cpu:alert2","events":[
+				{"id":"cpu:host=b","state":{"level":"OK","message":"cpu is ok","time":"2026-01-01T00:00:00Z","duration":"0s"}},
+				{"id":"cpu:host=a","state":{"level":"CRITICAL","message":"cpu is high","time":"2026-01-01T00:00:00Z","duration":"10m"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer kapa.Close()
+	s := &Service{
+		Store: &mocks.Store{
+			ServersStore: &mocks.ServersStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Server, error) {
+					return chronograf.Server{ID: ID, SrcID: 1, URL: kapa.URL}, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+	request := func(path string, params ...httprouter.Param) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", "http://any.url"+path, nil)
+		params = append(params, httprouter.Param{Key: "id", Value: "1"}, httprouter.Param{Key: "kid", Value: "2"})
+		r = r.WithContext(httprouter.WithParams(r.Context(), params))
+		w := httptest.NewRecorder()
+		switch len(params) {
+		case 2:
+			s.KapacitorTopics(w, r)
+		default:
+			s.KapacitorTopicEvents(w, r)
+		}
+		return w
+	}
+
+	w := request("/chronograf/v1/sources/1/kapacitors/2/alerts/topics")
+	want := `{"topics":[{"id":"main:cpu:alert2","level":"CRITICAL","collected":2,"links":{"events":"/chronograf/v1/sources/1/kapacitors/2/alerts/topics/main:cpu:alert2/events","handlers":"/chronograf/v1/sources/1/kapacitors/2/alerts/topics/main:cpu:alert2/handlers"}}]}`
+	if eq, err := jsonEqual(w.Body.String(), want); err != nil || !eq {
+		t.Errorf("KapacitorTopics() =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+
+	w = request("/chronograf/v1/sources/1/kapacitors/2/alerts/topics/main:cpu:alert2/events", httprouter.Param{Key: "topic", Value: "main:cpu:alert2"})
+	want = `{"topic":"main:cpu:alert2","events":[
+		{"id":"cpu:host=a","level":"CRITICAL","message":"cpu is high","time":"2026-01-01T00:00:00Z","duration":600000000000},
+		{"id":"cpu:host=b","level":"OK","message":"cpu is ok","time":"2026-01-01T00:00:00Z","duration":0}]}`
+	if eq, err := jsonEqual(w.Body.String(), want); err != nil || !eq {
+		t.Errorf("KapacitorTopicEvents() =\n%s\nwant\n%s", w.Body.String(), want)
+	}
+
+	w = request("/chronograf/v1/sources/1/kapacitors/2/alerts/topics/unknown/events", httprouter.Param{Key: "topic", Value: "unknown"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("KapacitorTopicEvents() of an unknown topic status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
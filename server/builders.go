@@ -13,7 +13,7 @@ import (
 
 // LayoutBuilder is responsible for building Layouts
 type LayoutBuilder interface {
-	Build() (*multistore.Layouts, error)
+	Build(chronograf.LayoutsStore) (*multistore.Layouts, error)
 }
 
 // MultiLayoutBuilder implements LayoutBuilder and will return a Layouts
@@ -23,8 +23,8 @@ type MultiLayoutBuilder struct {
 	CannedPath string
 }
 
-// Build will construct a Layouts of canned personalized layouts.
-func (builder *MultiLayoutBuilder) Build() (*multistore.Layouts, error) {
+// Build will construct a Layouts of db-backed and canned personalized layouts.
+func (builder *MultiLayoutBuilder) Build(db chronograf.LayoutsStore) (*multistore.Layouts, error) {
 	// These apps are those handled from a directory
 	apps := filestore.NewApps(builder.CannedPath, builder.UUID, builder.Logger)
 	// These apps are statically compiled into chronograf
@@ -34,11 +34,12 @@ func (builder *MultiLayoutBuilder) Build() (*multistore.Layouts, error) {
 	// Acts as a front-end to both the bolt layouts, filesystem layouts and binary statically compiled layouts.
 	// The idea here is that these stores form a hierarchy in which each is tried sequentially until
 	// the operation has success.  So, the database is preferred over filesystem over binary data.
+	stores := []chronograf.LayoutsStore{apps, binApps}
+	if db != nil {
+		stores = append([]chronograf.LayoutsStore{db}, stores...)
+	}
 	layouts := &multistore.Layouts{
-		Stores: []chronograf.LayoutsStore{
-			apps,
-			binApps,
-		},
+		Stores: stores,
 	}
 
 	return layouts, nil
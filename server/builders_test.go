@@ -8,7 +8,7 @@ import (
 
 func TestLayoutBuilder(t *testing.T) {
 	var l server.LayoutBuilder = &server.MultiLayoutBuilder{}
-	layout, err := l.Build()
+	layout, err := l.Build(nil)
 	if err != nil {
 		t.Fatalf("MultiLayoutBuilder can't build a MultiLayoutsStore: %v", err)
 	}
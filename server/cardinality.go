@@ -0,0 +1,711 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/influxql"
+)
+
+const (
+	defaultCardinalitySince  = 24 * time.Hour
+	defaultCardinalityLimit  = 10
+	defaultCardinalityTop    = 5
+	defaultCardinalitySample = 10000
+)
+
+type tagValueCardinality struct {
+	Value  string `json:"value"`
+	Series int64  `json:"series"` // Series is the number of series with the tag value
+}
+
+type tagKeyCardinality struct {
+	Key       string                `json:"key"`
+	Values    int64                 `json:"values"`    // Values is the number of values of the tag key
+	TopValues []tagValueCardinality `json:"topValues"` // TopValues are the tag values of the most series
+}
+
+type measurementCardinality struct {
+	Name    string              `json:"name"`
+	Series  int64               `json:"series"`
+	Sampled bool                `json:"sampled,omitempty"` // Sampled is true if the tag keys were computed from a sample of the series
+	TagKeys []tagKeyCardinality `json:"tagKeys,omitempty"` // TagKeys of the measurements of the most series
+}
+
+type cardinalityLinks struct {
+	Self string `json:"self"`
+}
+
+type cardinalityResponse struct {
+	Database     string                   `json:"database"`
+	Since        string                   `json:"since"`
+	Series       int64                    `json:"series"`
+	Measurements []measurementCardinality `json:"measurements"`
+	Links        cardinalityLinks         `json:"links"`
+}
+
+// cardinalityRequest are the query parameters of the cardinality of a
+// database
+type cardinalityRequest struct {
+	Measurement string        // Measurement restricts the cardinality to a single measurement
+	Since       time.Duration // Since bounds the series to those written within this duration
+	Limit       int           // Limit is the number of measurements whose tag keys are detailed
+	Top         int           // Top is the number of top values per tag key
+	Sample      int           // Sample is the maximum number of series scanned per measurement
+}
+
+// cardinalitySource computes the cardinality of the measurements of a
+// database
+type cardinalitySource interface {
+	// Series returns the number of series of each measurement
+	Series(ctx context.Context) (map[string]int64, error)
+	// TagValues returns the number of values of each tag key of a
+	// measurement, or nil if they are counted within the sampled series
+	TagValues(ctx context.Context, measurement string) (map[string]int64, error)
+	// SampleSeries returns the tag sets of at most n series of a measurement
+	// and whether there are more
+	SampleSeries(ctx context.Context, measurement string, n int) ([]map[string]string, bool, error)
+}
+
+// Cardinality returns the series cardinality of the measurements of a
+// database along with the cardinality and the top values of the tag keys of
+// the measurements of the most series
+func (s *Service) Cardinality(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	req, err := newCardinalityRequest(r.URL.Query())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	card, err := s.cardinalitySource(ctx, src, db, req)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+	measurements, err := measureCardinality(ctx, card, req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+
+	res := cardinalityResponse{
+		Database:     db,
+		Since:        influxql.FormatDuration(req.Since),
+		Measurements: measurements,
+		Links: cardinalityLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/dbs/%s/cardinality", srcID, db),
+		},
+	}
+	for _, m := range measurements {
+		res.Series += m.Series
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// newCardinalityRequest parses the query parameters of a cardinality request
+func newCardinalityRequest(params url.Values) (cardinalityRequest, error) {
+	req := cardinalityRequest{
+		Measurement: params.Get("measurement"),
+		Since:       defaultCardinalitySince,
+		Limit:       defaultCardinalityLimit,
+		Top:         defaultCardinalityTop,
+		Sample:      defaultCardinalitySample,
+	}
+	if since := params.Get("since"); since != "" {
+		d, err := influxql.ParseDuration(since)
+		if err != nil || d <= 0 {
+			return req, fmt.Errorf("since must be a positive duration")
+		}
+		req.Since = d
+	}
+	for name, v := range map[string]*int{"limit": &req.Limit, "top": &req.Top, "sample": &req.Sample} {
+		if p := params.Get(name); p != "" {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 1 {
+				return req, fmt.Errorf("%s must be a positive integer", name)
+			}
+			*v = n
+		}
+	}
+	return req, nil
+}
+
+// cardinalitySource connects to the source to compute the cardinality of a
+// database with InfluxQL on 1.x, Flux on 2.x and SQL on InfluxDB 3
+func (s *Service) cardinalitySource(ctx context.Context, src chronograf.Source, db string, req cardinalityRequest) (cardinalitySource, error) {
+	switch {
+	case src.Type == chronograf.InfluxDBv2:
+		u, err := url.ParseRequestURI(src.URL)
+		if err != nil {
+			return nil, err
+		}
+		return &fluxCardinality{
+			client: &flux.Client{
+				URL:                u,
+				InsecureSkipVerify: src.InsecureSkipVerify,
+				Org:                src.Username, // v2 organization name is stored in username
+				Authorizer:         influx.DefaultAuthorization(&src),
+			},
+			bucket:      db,
+			measurement: req.Measurement,
+			since:       req.Since,
+		}, nil
+	case chronograf.IsV3SrcType(src.Type):
+		cli := &influx.Client{
+			Logger:   s.Logger,
+			V3Config: s.V3Config,
+		}
+		if err := cli.Connect(ctx, &src); err != nil {
+			return nil, err
+		}
+		return &v3Cardinality{
+			client:      cli,
+			db:          db,
+			measurement: req.Measurement,
+			since:       req.Since,
+		}, nil
+	default:
+		ts, err := s.TimeSeries(src)
+		if err != nil {
+			return nil, err
+		}
+		if err := ts.Connect(ctx, &src); err != nil {
+			return nil, err
+		}
+		return &influxQLCardinality{
+			ts:          ts,
+			db:          db,
+			measurement: req.Measurement,
+			since:       req.Since,
+		}, nil
+	}
+}
+
+// measureCardinality ranks the measurements by their number of series and
+// details the tag keys of the first req.Limit of them
+func measureCardinality(ctx context.Context, card cardinalitySource, req cardinalityRequest) ([]measurementCardinality, error) {
+	series, err := card.Series(ctx)
+	if err != nil {
+		return nil, err
+	}
+	measurements := make([]measurementCardinality, 0, len(series))
+	for name, n := range series {
+		measurements = append(measurements, measurementCardinality{Name: name, Series: n})
+	}
+	sort.Slice(measurements, func(i, j int) bool {
+		if measurements[i].Series != measurements[j].Series {
+			return measurements[i].Series > measurements[j].Series
+		}
+		return measurements[i].Name < measurements[j].Name
+	})
+
+	for i := range measurements {
+		if i == req.Limit {
+			break
+		}
+		m := &measurements[i]
+		values, err := card.TagValues(ctx, m.Name)
+		if err != nil {
+			return nil, err
+		}
+		tagSets, more, err := card.SampleSeries(ctx, m.Name, req.Sample)
+		if err != nil {
+			return nil, err
+		}
+		m.Sampled = more
+		m.TagKeys = summarizeTagSets(tagSets, values, req.Top)
+	}
+	return measurements, nil
+}
+
+// summarizeTagSets counts the values of each tag key of the tag sets and
+// returns the tag keys by descending number of values. The number of values
+// are those of values if known.
+func summarizeTagSets(tagSets []map[string]string, values map[string]int64, top int) []tagKeyCardinality {
+	series := map[string]map[string]int64{}
+	for key := range values {
+		series[key] = map[string]int64{}
+	}
+	for _, tags := range tagSets {
+		for key, value := range tags {
+			if series[key] == nil {
+				series[key] = map[string]int64{}
+			}
+			series[key][value]++
+		}
+	}
+
+	keys := make([]tagKeyCardinality, 0, len(series))
+	for key, counts := range series {
+		tk := tagKeyCardinality{
+			Key:       key,
+			Values:    int64(len(counts)),
+			TopValues: make([]tagValueCardinality, 0, len(counts)),
+		}
+		if n, ok := values[key]; ok {
+			tk.Values = n
+		}
+		for value, n := range counts {
+			tk.TopValues = append(tk.TopValues, tagValueCardinality{Value: value, Series: n})
+		}
+		sort.Slice(tk.TopValues, func(i, j int) bool {
+			if tk.TopValues[i].Series != tk.TopValues[j].Series {
+				return tk.TopValues[i].Series > tk.TopValues[j].Series
+			}
+			return tk.TopValues[i].Value < tk.TopValues[j].Value
+		})
+		if len(tk.TopValues) > top {
+			tk.TopValues = tk.TopValues[:top]
+		}
+		keys = append(keys, tk)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Values != keys[j].Values {
+			return keys[i].Values > keys[j].Values
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
+
+// influxQLCardinality computes cardinality with the SHOW SERIES and SHOW TAG
+// VALUES commands of InfluxDB 1.x and Enterprise
+type influxQLCardinality struct {
+	ts          chronograf.TimeSeries
+	db          string
+	measurement string
+	since       time.Duration
+}
+
+type influxQLResult struct {
+	Series []struct {
+		Name    string          `json:"name"`
+		Columns []string        `json:"columns"`
+		Values  [][]interface{} `json:"values"`
+	} `json:"series"`
+	Error string `json:"error"`
+}
+
+// influxQLError returns the first error of the results of statements
+func influxQLError(results []influxQLResult) error {
+	for _, res := range results {
+		if res.Error != "" {
+			return fmt.Errorf("%s", res.Error)
+		}
+	}
+	return nil
+}
+
+func (c *influxQLCardinality) Series(ctx context.Context) (map[string]int64, error) {
+	from := ""
+	if c.measurement != "" {
+		from = " FROM " + influxql.QuoteIdent(c.measurement)
+	}
+	results, err := c.query(ctx, fmt.Sprintf("SHOW SERIES EXACT CARDINALITY ON %s%s%s", influxql.QuoteIdent(c.db), from, c.where()))
+	if err != nil {
+		return nil, err
+	}
+	series := map[string]int64{}
+	for _, res := range results {
+		for _, s := range res.Series {
+			if len(s.Values) > 0 && len(s.Values[0]) > 0 {
+				series[s.Name] = cardinalityCount(s.Values[0][0])
+			}
+		}
+	}
+	return series, nil
+}
+
+func (c *influxQLCardinality) TagValues(ctx context.Context, measurement string) (map[string]int64, error) {
+	on := fmt.Sprintf("ON %s FROM %s", influxql.QuoteIdent(c.db), influxql.QuoteIdent(measurement))
+	results, err := c.query(ctx, "SHOW TAG KEYS "+on+c.where())
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for _, res := range results {
+		for _, s := range res.Series {
+			for _, v := range s.Values {
+				if len(v) > 0 {
+					keys = append(keys, fmt.Sprint(v[0]))
+				}
+			}
+		}
+	}
+	values := map[string]int64{}
+	if len(keys) == 0 {
+		return values, nil
+	}
+
+	statements := make([]string, len(keys))
+	for i, key := range keys {
+		statements[i] = fmt.Sprintf("SHOW TAG VALUES EXACT CARDINALITY %s WITH KEY = %s%s", on, influxql.QuoteIdent(key), c.where())
+	}
+	results, err = c.query(ctx, strings.Join(statements, "; "))
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		values[key] = 0
+		if i < len(results) && len(results[i].Series) > 0 && len(results[i].Series[0].Values) > 0 {
+			if v := results[i].Series[0].Values[0]; len(v) > 0 {
+				values[key] = cardinalityCount(v[0])
+			}
+		}
+	}
+	return values, nil
+}
+
+func (c *influxQLCardinality) SampleSeries(ctx context.Context, measurement string, n int) ([]map[string]string, bool, error) {
+	results, err := c.query(ctx, fmt.Sprintf("SHOW SERIES ON %s FROM %s%s LIMIT %d", influxql.QuoteIdent(c.db), influxql.QuoteIdent(measurement), c.where(), n))
+	if err != nil {
+		return nil, false, err
+	}
+	tagSets := []map[string]string{}
+	for _, res := range results {
+		for _, s := range res.Series {
+			for _, v := range s.Values {
+				if len(v) > 0 {
+					_, tags := parseSeriesKey(fmt.Sprint(v[0]))
+					tagSets = append(tagSets, tags)
+				}
+			}
+		}
+	}
+	return tagSets, len(tagSets) >= n, nil
+}
+
+func (c *influxQLCardinality) where() string {
+	return " WHERE time > now() - " + influxql.FormatDuration(c.since)
+}
+
+func (c *influxQLCardinality) query(ctx context.Context, command string) ([]influxQLResult, error) {
+	results, _, err := influxQLResults(ctx, c.ts, chronograf.Query{Command: command, DB: c.db})
+	if err != nil {
+		return nil, err
+	}
+	return results, influxQLError(results)
+}
+
+// parseSeriesKey splits a series key such as cpu,host=a into its measurement
+// and tags
+func parseSeriesKey(key string) (string, map[string]string) {
+	tags := map[string]string{}
+	parts := splitUnescaped(key, ',')
+	for _, part := range parts[1:] {
+		kv := splitUnescaped(part, '=')
+		if len(kv) < 2 {
+			continue
+		}
+		tags[unescapeSeriesKey(kv[0])] = unescapeSeriesKey(strings.Join(kv[1:], "="))
+	}
+	return unescapeSeriesKey(parts[0]), tags
+}
+
+// splitUnescaped splits s at each sep not escaped by a backslash
+func splitUnescaped(s string, sep byte) []string {
+	parts := []string{}
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+var seriesKeyUnescaper = strings.NewReplacer(`\,`, ",", `\=`, "=", `\ `, " ", `\\`, `\`)
+
+func unescapeSeriesKey(s string) string {
+	return seriesKeyUnescaper.Replace(s)
+}
+
+// fluxCardinality computes cardinality with Flux queries of InfluxDB 2.x,
+// where a series is identified by its measurement, tags and field
+type fluxCardinality struct {
+	client      *flux.Client
+	bucket      string
+	measurement string
+	since       time.Duration
+}
+
+func (c *fluxCardinality) Series(ctx context.Context) (map[string]int64, error) {
+	query := c.from(c.measurement) + `
+  |> first()
+  |> keep(columns: ["_measurement", "_time"])
+  |> group(columns: ["_measurement"])
+  |> count(column: "_time")
+  |> group()`
+	rows, err := c.client.Rows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	series := map[string]int64{}
+	for _, row := range rows {
+		n, _ := strconv.ParseInt(row["_time"], 10, 64)
+		series[row["_measurement"]] = n
+	}
+	return series, nil
+}
+
+func (c *fluxCardinality) TagValues(ctx context.Context, measurement string) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (c *fluxCardinality) SampleSeries(ctx context.Context, measurement string, n int) ([]map[string]string, bool, error) {
+	query := c.from(measurement) + fmt.Sprintf(`
+  |> first()
+  |> drop(columns: ["_start", "_stop", "_time", "_value", "_field", "_measurement"])
+  |> group()
+  |> limit(n: %d)`, n)
+	rows, err := c.client.Rows(ctx, query)
+	if err != nil {
+		return nil, false, err
+	}
+	// rows of the fields of a series have the same tag set
+	seen := map[string]bool{}
+	tagSets := []map[string]string{}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for k, v := range row {
+			if v != "" {
+				tags[k] = v
+			}
+		}
+		id := tagSetID(tags)
+		if !seen[id] {
+			seen[id] = true
+			tagSets = append(tagSets, tags)
+		}
+	}
+	return tagSets, len(rows) >= n, nil
+}
+
+func (c *fluxCardinality) from(measurement string) string {
+	query := fmt.Sprintf(`from(bucket: %s)
+  |> range(start: -%s)`, influx.FluxString(c.bucket), influxql.FormatDuration(c.since))
+	if measurement != "" {
+		query += fmt.Sprintf(`
+  |> filter(fn: (r) => r._measurement == %s)`, influx.FluxString(measurement))
+	}
+	return query
+}
+
+// tagSetID identifies a tag set by its sorted tags
+func tagSetID(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(tags[k])
+		b.WriteByte(0)
+	}
+	return b.String()
+}
+
+// v3Cardinality computes cardinality with SQL queries of InfluxDB 3 Core and
+// Enterprise, where a series is identified by its table and tags
+type v3Cardinality struct {
+	client      *influx.Client
+	db          string
+	measurement string
+	since       time.Duration
+
+	tables map[string][]string // tables maps the tables to their tag columns
+}
+
+func (c *v3Cardinality) Series(ctx context.Context) (map[string]int64, error) {
+	tables, err := c.tags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	series := map[string]int64{}
+	if len(tables) == 0 {
+		return series, nil
+	}
+
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	selects := make([]string, len(names))
+	for i, name := range names {
+		distinct := "1"
+		limit := " LIMIT 1"
+		if tags := tables[name]; len(tags) > 0 {
+			distinct = "DISTINCT " + sqlIdents(tags)
+			limit = ""
+		}
+		selects[i] = fmt.Sprintf("SELECT %s AS name, COUNT(*) AS series FROM (SELECT %s FROM %s%s%s)",
+			sqlString(name), distinct, sqlIdent(name), c.where(), limit)
+	}
+	var rows []struct {
+		Name   string `json:"name"`
+		Series int64  `json:"series"`
+	}
+	if err := c.client.QuerySQL(ctx, c.db, strings.Join(selects, " UNION ALL "), &rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if row.Series > 0 {
+			series[row.Name] = row.Series
+		}
+	}
+	return series, nil
+}
+
+func (c *v3Cardinality) TagValues(ctx context.Context, measurement string) (map[string]int64, error) {
+	tables, err := c.tags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]int64{}
+	tags := tables[measurement]
+	if len(tags) == 0 {
+		return values, nil
+	}
+	counts := make([]string, len(tags))
+	for i, tag := range tags {
+		counts[i] = fmt.Sprintf("COUNT(DISTINCT %s) AS %s", sqlIdent(tag), sqlIdent(tag))
+	}
+	var rows []map[string]interface{}
+	q := fmt.Sprintf("SELECT %s FROM %s%s", strings.Join(counts, ", "), sqlIdent(measurement), c.where())
+	if err := c.client.QuerySQL(ctx, c.db, q, &rows); err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		values[tag] = 0
+		if len(rows) > 0 {
+			values[tag] = cardinalityCount(rows[0][tag])
+		}
+	}
+	return values, nil
+}
+
+func (c *v3Cardinality) SampleSeries(ctx context.Context, measurement string, n int) ([]map[string]string, bool, error) {
+	tables, err := c.tags(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	tags := tables[measurement]
+	if len(tags) == 0 {
+		return []map[string]string{}, false, nil
+	}
+	var rows []map[string]interface{}
+	q := fmt.Sprintf("SELECT DISTINCT %s FROM %s%s LIMIT %d", sqlIdents(tags), sqlIdent(measurement), c.where(), n)
+	if err := c.client.QuerySQL(ctx, c.db, q, &rows); err != nil {
+		return nil, false, err
+	}
+	tagSets := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		tagSets[i] = map[string]string{}
+		for k, v := range row {
+			if s, ok := v.(string); ok {
+				tagSets[i][k] = s
+			}
+		}
+	}
+	return tagSets, len(rows) >= n, nil
+}
+
+// tags returns the tables of the database with their tag columns, which are
+// the dictionary encoded columns
+func (c *v3Cardinality) tags(ctx context.Context) (map[string][]string, error) {
+	if c.tables != nil {
+		return c.tables, nil
+	}
+	q := "SELECT table_name, column_name, data_type FROM information_schema.columns WHERE table_schema = 'iox'"
+	if c.measurement != "" {
+		q += " AND table_name = " + sqlString(c.measurement)
+	}
+	var rows []struct {
+		Table    string `json:"table_name"`
+		Column   string `json:"column_name"`
+		DataType string `json:"data_type"`
+	}
+	if err := c.client.QuerySQL(ctx, c.db, q, &rows); err != nil {
+		return nil, err
+	}
+	tables := map[string][]string{}
+	for _, row := range rows {
+		tags := tables[row.Table]
+		if strings.HasPrefix(row.DataType, "Dictionary") {
+			tags = append(tags, row.Column)
+		}
+		tables[row.Table] = tags
+	}
+	for _, tags := range tables {
+		sort.Strings(tags)
+	}
+	c.tables = tables
+	return tables, nil
+}
+
+func (c *v3Cardinality) where() string {
+	return fmt.Sprintf(" WHERE time > now() - INTERVAL '%d seconds'", int64(c.since/time.Second))
+}
+
+func sqlIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+func sqlIdents(ss []string) string {
+	idents := make([]string, len(ss))
+	for i, s := range ss {
+		idents[i] = sqlIdent(s)
+	}
+	return strings.Join(idents, ", ")
+}
+
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// cardinalityCount converts a count of a JSON result to an integer
+func cardinalityCount(v interface{}) int64 {
+	switch n := v.(type) {
+	case float64:
+		return int64(n)
+	case json.Number:
+		i, _ := n.Int64()
+		return i
+	case string:
+		i, _ := strconv.ParseInt(n, 10, 64)
+		return i
+	}
+	return 0
+}
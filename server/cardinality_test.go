@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestParseSeriesKey(t *testing.T) {
+	tests := []struct {
+		key         string
+		measurement string
+		tags        map[string]string
+	}{
+		{
+			key:         "cpu",
+			measurement: "cpu",
+			tags:        map[string]string{},
+		},
+		{
+			key:         "cpu,host=a,region=us-west",
+			measurement: "cpu",
+			tags:        map[string]string{"host": "a", "region": "us-west"},
+		},
+		{
+			key:         `disk\ io,path=C:\\,label=a\,b\=c`,
+			measurement: "disk io",
+			tags:        map[string]string{"path": `C:\`, "label": "a,b=c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			m, tags := parseSeriesKey(tt.key)
+			if m != tt.measurement {
+				t.Errorf("parseSeriesKey() measurement = %q, want %q", m, tt.measurement)
+			}
+			if !reflect.DeepEqual(tags, tt.tags) {
+				t.Errorf("parseSeriesKey() tags = %v, want %v", tags, tt.tags)
+			}
+		})
+	}
+}
+
+func TestSummarizeTagSets(t *testing.T) {
+	tagSets := []map[string]string{
+		{"host": "a", "cpu": "cpu0"},
+		{"host": "a", "cpu": "cpu1"},
+		{"host": "b", "cpu": "cpu0"},
+		{"host": "c"},
+	}
+	got := summarizeTagSets(tagSets, map[string]int64{"host": 40, "dc": 0}, 2)
+	want := []tagKeyCardinality{
+		{Key: "host", Values: 40, TopValues: []tagValueCardinality{{Value: "a", Series: 2}, {Value: "b", Series: 1}}},
+		{Key: "cpu", Values: 2, TopValues: []tagValueCardinality{{Value: "cpu0", Series: 2}, {Value: "cpu1", Series: 1}}},
+		{Key: "dc", Values: 0, TopValues: []tagValueCardinality{}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summarizeTagSets() = %+v, want %+v", got, want)
+	}
+}
+
+func TestService_Cardinality(t *testing.T) {
+	influxQL := map[string]string{
+		`SHOW SERIES EXACT CARDINALITY ON telegraf WHERE time > now() - 1d`: `[{"statement_id":0,"series":[
+			{"name":"cpu","columns":["count"],"values":[[3]]},
+			{"name":"mem","columns":["count"],"values":[[1]]}
+		]}]`,
+		`SHOW TAG KEYS ON telegraf FROM cpu WHERE time > now() - 1d`: `[{"statement_id":0,"series":[
+			{"name":"cpu","columns":["tagKey"],"values":[["cpu"],["host"]]}
+		]}]`,
+		`SHOW TAG VALUES EXACT CARDINALITY ON telegraf FROM cpu WITH KEY = cpu WHERE time > now() - 1d; SHOW TAG VALUES EXACT CARDINALITY ON telegraf FROM cpu WITH KEY = host WHERE time > now() - 1d`: `[
+			{"statement_id":0,"series":[{"name":"cpu","columns":["count"],"values":[[2]]}]},
+			{"statement_id":1,"series":[{"name":"cpu","columns":["count"],"values":[[2]]}]}
+		]`,
+		`SHOW SERIES ON telegraf FROM cpu WHERE time > now() - 1d LIMIT 2`: `[{"statement_id":0,"series":[
+			{"columns":["key"],"values":[["cpu,cpu=cpu0,host=a"],["cpu,cpu=cpu1,host=a"]]}
+		]}]`,
+	}
+	ts := &mocks.TimeSeries{
+		ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+		QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+			res, ok := influxQL[q.Command]
+			if !ok {
+				t.Errorf("unexpected query %s", q.Command)
+				res = `[{"statement_id":0,"error":"unexpected query"}]`
+			}
+			return mocks.NewResponse(res, nil), nil
+		},
+	}
+
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/csv")
+		switch {
+		case strings.Contains(string(body), `count(column: \"_time\")`):
+			w.Write([]byte("#datatype,string,long,string,long\r\n" +
+				"#group,false,false,false,false\r\n" +
+				"#default,_result,,,\r\n" +
+				",result,table,_measurement,_time\r\n" +
+				",,0,cpu,4\r\n\r\n"))
+		case strings.Contains(string(body), `limit(n: 10000)`):
+			w.Write([]byte("#datatype,string,long,string\r\n" +
+				"#group,false,false,false\r\n" +
+				"#default,_result,,\r\n" +
+				",result,table,host\r\n" +
+				",,0,a\r\n" +
+				",,0,a\r\n" +
+				",,0,b\r\n\r\n"))
+		default:
+			t.Errorf("unexpected flux query %s", body)
+		}
+	}))
+	defer v2.Close()
+
+	v3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.HasPrefix(q, "SELECT table_name"):
+			w.Write([]byte(`[{"table_name":"cpu","column_name":"host","data_type":"Dictionary(Int32, Utf8)"},{"table_name":"cpu","column_name":"usage","data_type":"Float64"}]`))
+		case q == `SELECT 'cpu' AS name, COUNT(*) AS series FROM (SELECT DISTINCT "host" FROM "cpu" WHERE time > now() - INTERVAL '3600 seconds')`:
+			w.Write([]byte(`[{"name":"cpu","series":2}]`))
+		case q == `SELECT COUNT(DISTINCT "host") AS "host" FROM "cpu" WHERE time > now() - INTERVAL '3600 seconds'`:
+			w.Write([]byte(`[{"host":2}]`))
+		case q == `SELECT DISTINCT "host" FROM "cpu" WHERE time > now() - INTERVAL '3600 seconds' LIMIT 10000`:
+			w.Write([]byte(`[{"host":"a"},{"host":"b"}]`))
+		default:
+			t.Errorf("unexpected SQL query %s", q)
+		}
+	}))
+	defer v3.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: v2.URL, Type: chronograf.InfluxDBv2, Username: "org"}, nil
+			case 3:
+				return chronograf.Source{ID: 3, URL: v3.URL, Type: chronograf.InfluxDBv3Core}, nil
+			case 4:
+				return chronograf.Source{ID: 4, URL: v3.URL, Type: chronograf.InfluxDBv3Serverless}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "1.x",
+			id:         "1",
+			query:      "?limit=1&top=1&sample=2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"telegraf","since":"1d","series":4,"measurements":[
+				{"name":"cpu","series":3,"sampled":true,"tagKeys":[
+					{"key":"cpu","values":2,"topValues":[{"value":"cpu0","series":1}]},
+					{"key":"host","values":2,"topValues":[{"value":"a","series":2}]}
+				]},
+				{"name":"mem","series":1}
+			],"links":{"self":"/chronograf/v1/sources/1/dbs/telegraf/cardinality"}}`,
+		},
+		{
+			name:       "2.x",
+			id:         "2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"telegraf","since":"1d","series":4,"measurements":[
+				{"name":"cpu","series":4,"tagKeys":[
+					{"key":"host","values":2,"topValues":[{"value":"a","series":1},{"value":"b","series":1}]}
+				]}
+			],"links":{"self":"/chronograf/v1/sources/2/dbs/telegraf/cardinality"}}`,
+		},
+		{
+			name:       "InfluxDB 3 Core",
+			id:         "3",
+			query:      "?since=1h",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"telegraf","since":"1h","series":2,"measurements":[
+				{"name":"cpu","series":2,"tagKeys":[
+					{"key":"host","values":2,"topValues":[{"value":"a","series":1},{"value":"b","series":1}]}
+				]}
+			],"links":{"self":"/chronograf/v1/sources/3/dbs/telegraf/cardinality"}}`,
+		},
+		{
+			name:       "InfluxDB 3 Serverless",
+			id:         "4",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid since",
+			id:         "1",
+			query:      "?since=-1h",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid sample",
+			id:         "1",
+			query:      "?sample=0",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown source",
+			id:         "5",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store:            &mocks.Store{SourcesStore: sources},
+				TimeSeriesClient: ts,
+				Logger:           log.New(log.DebugLevel),
+			}
+			r := httptest.NewRequest("GET", "http://any.url"+tt.query, nil)
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{
+				{Key: "id", Value: tt.id},
+				{Key: "db", Value: "telegraf"},
+			}))
+			w := httptest.NewRecorder()
+			s.Cardinality(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
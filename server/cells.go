@@ -248,6 +248,10 @@ func (s *Service) DashboardCells(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, e, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, e.ID, s.Logger)
+		return
+	}
 
 	boards := newDashboardResponse(e)
 	cells := boards.Cells
@@ -268,6 +272,13 @@ func (s *Service) NewDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 	var cell chronograf.DashboardCell
 	if err := json.NewDecoder(r.Body).Decode(&cell); err != nil {
 		invalidJSON(w, s.Logger)
@@ -318,6 +329,10 @@ func (s *Service) DashboardCellID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
 
 	boards := newDashboardResponse(dash)
 	cid := httprouter.GetParamFromContext(ctx, "cid")
@@ -344,6 +359,13 @@ func (s *Service) RemoveDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 
 	cid := httprouter.GetParamFromContext(ctx, "cid")
 	cellid := -1
@@ -381,6 +403,13 @@ func (s *Service) ReplaceDashboardCell(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 
 	cid := httprouter.GetParamFromContext(ctx, "cid")
 	cellid := -1
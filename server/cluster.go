@@ -0,0 +1,446 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+	"github.com/influxdata/chronograf/influx"
+)
+
+// shardSizesQuery retrieves the size of each copy of a shard from the
+// monitoring data of the data nodes
+const shardSizesQuery = `SELECT last("diskBytes") FROM "_internal"."monitor"."shard" WHERE time > now() - 10m GROUP BY "id", "hostname"`
+
+type clusterLinks struct {
+	Self     string `json:"self"`     // Self link mapping to this resource
+	Shards   string `json:"shards"`   // Shards link to the shard groups of the cluster
+	Truncate string `json:"truncate"` // Truncate link to truncate the hot shards of the cluster
+}
+
+type clusterResponse struct {
+	MetaNodes []enterprise.Node     `json:"meta"`
+	DataNodes []enterprise.DataNode `json:"data"`
+	Links     clusterLinks          `json:"links"`
+}
+
+type shardOwnerResponse struct {
+	ID       uint64 `json:"id,string"`
+	TCPAddr  string `json:"tcpAddr,omitempty"`
+	HTTPAddr string `json:"httpAddr,omitempty"`
+	Size     *int64 `json:"size,omitempty"` // Size of the copy of the shard in bytes, if known
+}
+
+type shardResponse struct {
+	ID     uint64               `json:"id,string"`
+	Owners []shardOwnerResponse `json:"owners"`
+	Size   *int64               `json:"size,omitempty"` // Size of the largest copy of the shard in bytes, if known
+}
+
+type shardGroupResponse struct {
+	ID              uint64          `json:"id,string"`
+	Database        string          `json:"database"`
+	RetentionPolicy string          `json:"retentionPolicy"`
+	ReplicaN        int             `json:"replicaN"`
+	StartTime       time.Time       `json:"startTime"`
+	EndTime         time.Time       `json:"endTime"`
+	ExpireTime      time.Time       `json:"expireTime"`
+	TruncatedAt     time.Time       `json:"truncatedAt"`
+	Shards          []shardResponse `json:"shards"`
+}
+
+type shardGroupsResponse struct {
+	ShardGroups []shardGroupResponse `json:"shardGroups"`
+}
+
+type copyShardRequest struct {
+	Src  uint64 `json:"src,string"`  // Src is the ID of the data node the shard is copied from
+	Dest uint64 `json:"dest,string"` // Dest is the ID of the data node the shard is copied to
+}
+
+type truncateShardsRequest struct {
+	Delay string `json:"delay"` // Delay is the duration after which the shards are truncated
+}
+
+// Cluster returns the meta and data nodes of an InfluxDB Enterprise source
+func (s *Service) Cluster(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, meta, ok := s.sourceMetaClient(ctx, w, r)
+	if !ok {
+		return
+	}
+	cluster, err := meta.ShowCluster(ctx)
+	if err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+
+	base := fmt.Sprintf("/chronograf/v1/sources/%d/cluster", src.ID)
+	res := clusterResponse{
+		MetaNodes: cluster.MetaNodes,
+		DataNodes: cluster.DataNodes,
+		Links: clusterLinks{
+			Self:     base,
+			Shards:   base + "/shards",
+			Truncate: base + "/truncate",
+		},
+	}
+	if res.MetaNodes == nil {
+		res.MetaNodes = []enterprise.Node{}
+	}
+	if res.DataNodes == nil {
+		res.DataNodes = []enterprise.DataNode{}
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// ClusterShards returns the shard groups of an InfluxDB Enterprise source with
+// their shards, the data nodes owning them and their sizes. The shard groups
+// are optionally filtered by the db and rp query parameters.
+func (s *Service) ClusterShards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, meta, ok := s.sourceMetaClient(ctx, w, r)
+	if !ok {
+		return
+	}
+	cluster, err := meta.ShowCluster(ctx)
+	if err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+	shards, err := meta.ShowShards(ctx)
+	if err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+
+	nodes := map[uint64]enterprise.DataNode{}
+	for _, dn := range cluster.DataNodes {
+		nodes[dn.ID] = dn
+	}
+	sizes := s.shardSizes(ctx, src, cluster.DataNodes)
+
+	db := r.URL.Query().Get("db")
+	rp := r.URL.Query().Get("rp")
+	groups := map[uint64]*shardGroupResponse{}
+	for _, sh := range shards {
+		if (db != "" && sh.Database != db) || (rp != "" && sh.RetentionPolicy != rp) {
+			continue
+		}
+		group, ok := groups[sh.ShardGroupID]
+		if !ok {
+			group = &shardGroupResponse{
+				ID:              sh.ShardGroupID,
+				Database:        sh.Database,
+				RetentionPolicy: sh.RetentionPolicy,
+				ReplicaN:        sh.ReplicaN,
+				StartTime:       sh.StartTime,
+				EndTime:         sh.EndTime,
+				ExpireTime:      sh.ExpireTime,
+				TruncatedAt:     sh.TruncatedAt,
+				Shards:          []shardResponse{},
+			}
+			groups[sh.ShardGroupID] = group
+		}
+
+		shard := shardResponse{
+			ID:     sh.ID,
+			Owners: []shardOwnerResponse{},
+		}
+		for _, o := range sh.Owners {
+			owner := shardOwnerResponse{
+				ID:       o.ID,
+				TCPAddr:  nodes[o.ID].TCPAddr,
+				HTTPAddr: nodes[o.ID].HTTPAddr,
+			}
+			if size, ok := sizes[shardCopy{shard: sh.ID, node: o.ID}]; ok {
+				owner.Size = &size
+				if shard.Size == nil || size > *shard.Size {
+					shard.Size = &size
+				}
+			}
+			shard.Owners = append(shard.Owners, owner)
+		}
+		group.Shards = append(group.Shards, shard)
+	}
+
+	res := shardGroupsResponse{
+		ShardGroups: []shardGroupResponse{},
+	}
+	for _, group := range groups {
+		sort.Slice(group.Shards, func(i, j int) bool {
+			return group.Shards[i].ID < group.Shards[j].ID
+		})
+		res.ShardGroups = append(res.ShardGroups, *group)
+	}
+	sort.Slice(res.ShardGroups, func(i, j int) bool {
+		a, b := res.ShardGroups[i], res.ShardGroups[j]
+		if a.Database != b.Database {
+			return a.Database < b.Database
+		}
+		if a.RetentionPolicy != b.RetentionPolicy {
+			return a.RetentionPolicy < b.RetentionPolicy
+		}
+		return a.ID < b.ID
+	})
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// CopyClusterShard copies a shard from one data node to another
+func (s *Service) CopyClusterShard(w http.ResponseWriter, r *http.Request) {
+	var req copyShardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	_, meta, ok := s.sourceMetaClient(ctx, w, r)
+	if !ok {
+		return
+	}
+	shard, nodes, ok := s.clusterShard(ctx, w, meta)
+	if !ok {
+		return
+	}
+	src, ok := nodes[req.Src]
+	if !ok {
+		invalidData(w, fmt.Errorf("data node %d not found", req.Src), s.Logger)
+		return
+	}
+	dest, ok := nodes[req.Dest]
+	if !ok {
+		invalidData(w, fmt.Errorf("data node %d not found", req.Dest), s.Logger)
+		return
+	}
+	if !ownsShard(shard, req.Src) {
+		invalidData(w, fmt.Errorf("data node %d does not own shard %d", req.Src, shard.ID), s.Logger)
+		return
+	}
+	if ownsShard(shard, req.Dest) {
+		invalidData(w, fmt.Errorf("data node %d already owns shard %d", req.Dest, shard.ID), s.Logger)
+		return
+	}
+
+	if err := meta.CopyShard(ctx, src.TCPAddr, dest.TCPAddr, shard.ID); err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+	// the meta nodes copy the shard in the background
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// RemoveClusterShard removes a shard from a data node. The last copy of a
+// shard cannot be removed.
+func (s *Service) RemoveClusterShard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	nodeID, err := strconv.ParseUint(httprouter.GetParamFromContext(ctx, "nid"), 10, 64)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid data node ID: %v", err), s.Logger)
+		return
+	}
+
+	_, meta, ok := s.sourceMetaClient(ctx, w, r)
+	if !ok {
+		return
+	}
+	shard, nodes, ok := s.clusterShard(ctx, w, meta)
+	if !ok {
+		return
+	}
+	node, ok := nodes[nodeID]
+	if !ok || !ownsShard(shard, nodeID) {
+		Error(w, http.StatusNotFound, fmt.Sprintf("data node %d does not own shard %d", nodeID, shard.ID), s.Logger)
+		return
+	}
+	if len(shard.Owners) == 1 {
+		invalidData(w, fmt.Errorf("data node %d owns the only copy of shard %d", nodeID, shard.ID), s.Logger)
+		return
+	}
+
+	if err := meta.RemoveShard(ctx, node.TCPAddr, shard.ID); err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TruncateClusterShards truncates the hot shards of an InfluxDB Enterprise
+// source, optionally after a delay
+func (s *Service) TruncateClusterShards(w http.ResponseWriter, r *http.Request) {
+	var req truncateShardsRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			invalidJSON(w, s.Logger)
+			return
+		}
+	}
+	var delay time.Duration
+	if req.Delay != "" {
+		var err error
+		if delay, err = time.ParseDuration(req.Delay); err != nil || delay < 0 {
+			invalidData(w, fmt.Errorf("invalid delay %q", req.Delay), s.Logger)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	_, meta, ok := s.sourceMetaClient(ctx, w, r)
+	if !ok {
+		return
+	}
+	if err := meta.TruncateShards(ctx, delay); err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sourceMetaClient returns the source of the request and a client of its
+// meta nodes. It responds with an error and returns false if the source is
+// not an InfluxDB Enterprise cluster.
+func (s *Service) sourceMetaClient(ctx context.Context, w http.ResponseWriter, r *http.Request) (chronograf.Source, *enterprise.MetaClient, bool) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+	if src.MetaURL == "" {
+		Error(w, http.StatusNotFound, fmt.Sprintf("Source %d is not an InfluxDB Enterprise cluster", id), s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+	metaURL, err := url.Parse(src.MetaURL)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("Source %d has an invalid meta URL: %v", id, err), s.Logger)
+		return chronograf.Source{}, nil, false
+	}
+	return src, enterprise.NewMetaClient(metaURL, src.InsecureSkipVerify, influx.DefaultAuthorization(&src)), true
+}
+
+// clusterShard returns the shard of the request and the data nodes of the
+// cluster by ID. It responds with an error and returns false if the shard
+// does not exist.
+func (s *Service) clusterShard(ctx context.Context, w http.ResponseWriter, meta *enterprise.MetaClient) (enterprise.Shard, map[uint64]enterprise.DataNode, bool) {
+	shardID, err := strconv.ParseUint(httprouter.GetParamFromContext(ctx, "sid"), 10, 64)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("invalid shard ID: %v", err), s.Logger)
+		return enterprise.Shard{}, nil, false
+	}
+	cluster, err := meta.ShowCluster(ctx)
+	if err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return enterprise.Shard{}, nil, false
+	}
+	shards, err := meta.ShowShards(ctx)
+	if err != nil {
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+		return enterprise.Shard{}, nil, false
+	}
+
+	nodes := map[uint64]enterprise.DataNode{}
+	for _, dn := range cluster.DataNodes {
+		nodes[dn.ID] = dn
+	}
+	for _, sh := range shards {
+		if sh.ID == shardID {
+			return sh, nodes, true
+		}
+	}
+	Error(w, http.StatusNotFound, fmt.Sprintf("shard %d not found", shardID), s.Logger)
+	return enterprise.Shard{}, nil, false
+}
+
+func ownsShard(shard enterprise.Shard, nodeID uint64) bool {
+	for _, o := range shard.Owners {
+		if o.ID == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// shardCopy identifies the copy of a shard on a data node
+type shardCopy struct {
+	shard uint64
+	node  uint64
+}
+
+// shardSizes returns the sizes of the copies of the shards reported by the
+// monitoring data of the data nodes. Sizes are omitted if the data nodes do
+// not store monitoring data.
+func (s *Service) shardSizes(ctx context.Context, src chronograf.Source, dataNodes []enterprise.DataNode) map[shardCopy]int64 {
+	sizes := map[shardCopy]int64{}
+	logs := s.Logger.
+		WithField("component", "cluster").
+		WithField("source", src.ID)
+
+	// the monitoring data identifies data nodes by their host name
+	hosts := map[string]uint64{}
+	for _, dn := range dataNodes {
+		for _, addr := range []string{dn.TCPAddr, dn.HTTPAddr} {
+			if host, _, err := net.SplitHostPort(addr); err == nil {
+				hosts[host] = dn.ID
+			}
+		}
+	}
+
+	ts, err := s.TimeSeries(src)
+	if err != nil {
+		logs.Debug("Unable to retrieve shard sizes: ", err)
+		return sizes
+	}
+	if err := ts.Connect(ctx, &src); err != nil {
+		logs.Debug("Unable to retrieve shard sizes: ", err)
+		return sizes
+	}
+	response, err := ts.Query(ctx, chronograf.Query{Command: shardSizesQuery, DB: "_internal"})
+	if err != nil {
+		logs.Debug("Unable to retrieve shard sizes: ", err)
+		return sizes
+	}
+	octets, err := response.MarshalJSON()
+	if err != nil {
+		return sizes
+	}
+
+	var results []struct {
+		Series []struct {
+			Tags   map[string]string `json:"tags"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"series"`
+	}
+	if err := json.Unmarshal(octets, &results); err != nil {
+		logs.Debug("Unable to parse shard sizes: ", err)
+		return sizes
+	}
+	for _, result := range results {
+		for _, series := range result.Series {
+			shardID, err := strconv.ParseUint(series.Tags["id"], 10, 64)
+			if err != nil {
+				continue
+			}
+			nodeID, ok := hosts[series.Tags["hostname"]]
+			if !ok || len(series.Values) == 0 || len(series.Values[0]) < 2 {
+				continue
+			}
+			if size, ok := series.Values[0][1].(float64); ok {
+				sizes[shardCopy{shard: shardID, node: nodeID}] = int64(size)
+			}
+		}
+	}
+	return sizes
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+// metaNodeStandIn serves the parts of the meta node API used by the cluster
+// endpoints and records the shard operations it receives
+type metaNodeStandIn struct {
+	*httptest.Server
+	actions []string
+}
+
+func newMetaNodeStandIn() *metaNodeStandIn {
+	m := &metaNodeStandIn{}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/show-cluster":
+			w.Write([]byte(`{
+				"data": [
+					{"id": 4, "tcpAddr": "data-1:8088", "httpAddr": "data-1:8086", "httpScheme": "http", "status": "joined"},
+					{"id": 5, "tcpAddr": "data-2:8088", "httpAddr": "data-2:8086", "httpScheme": "http", "status": "joined"}
+				],
+				"meta": [{"id": 1, "addr": "meta-1:8091", "httpScheme": "http", "tcpAddr": "meta-1:8089"}]
+			}`))
+		case "/show-shards":
+			w.Write([]byte(`[
+				{"id": "2", "database": "telegraf", "retention-policy": "autogen", "replica-n": 2, "shard-group-id": "1",
+				 "start-time": "2026-10-12T00:00:00Z", "end-time": "2026-10-19T00:00:00Z", "expire-time": "0001-01-01T00:00:00Z",
+				 "truncated-at": "0001-01-01T00:00:00Z", "owners": [{"id": "4"}, {"id": "5"}]},
+				{"id": "1", "database": "telegraf", "retention-policy": "autogen", "replica-n": 2, "shard-group-id": "1",
+				 "start-time": "2026-10-12T00:00:00Z", "end-time": "2026-10-19T00:00:00Z", "expire-time": "0001-01-01T00:00:00Z",
+				 "truncated-at": "0001-01-01T00:00:00Z", "owners": [{"id": "4"}]},
+				{"id": "3", "database": "_internal", "retention-policy": "monitor", "replica-n": 1, "shard-group-id": "2",
+				 "start-time": "2026-10-19T00:00:00Z", "end-time": "2026-10-20T00:00:00Z", "expire-time": "2026-10-27T00:00:00Z",
+				 "truncated-at": "0001-01-01T00:00:00Z", "owners": [{"id": "5"}]}
+			]`))
+		case "/copy-shard", "/remove-shard", "/truncate-shards":
+			body, _ := ioutil.ReadAll(r.Body)
+			m.actions = append(m.actions, r.Method+" "+r.URL.Path+" "+string(body))
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+	return m
+}
+
+func TestService_Cluster(t *testing.T) {
+	meta := newMetaNodeStandIn()
+	defer meta.Close()
+
+	sizes := `[{"statement_id":0,"series":[
+		{"name":"shard","tags":{"hostname":"data-1","id":"1"},"columns":["time","last"],"values":[[1760832000000,1024]]},
+		{"name":"shard","tags":{"hostname":"data-1","id":"2"},"columns":["time","last"],"values":[[1760832000000,2048]]},
+		{"name":"shard","tags":{"hostname":"data-2","id":"2"},"columns":["time","last"],"values":[[1760832000000,4096]]}
+	]}]`
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, URL: "http://data-1:8086", MetaURL: meta.URL, Type: chronograf.InfluxDBv1Enterprise}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: "http://localhost:8086", Type: chronograf.InfluxDBv1}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+
+	tests := []struct {
+		name        string
+		handler     func(*Service) http.HandlerFunc
+		method      string
+		target      string
+		body        string
+		params      httprouter.Params
+		wantStatus  int
+		wantBody    string
+		wantActions []string
+	}{
+		{
+			name:       "nodes",
+			handler:    func(s *Service) http.HandlerFunc { return s.Cluster },
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"meta":[{"id":1,"addr":"meta-1:8091","httpScheme":"http","tcpAddr":"meta-1:8089"}],"data":[{"id":4,"tcpAddr":"data-1:8088","httpAddr":"data-1:8086","httpScheme":"http","status":"joined"},{"id":5,"tcpAddr":"data-2:8088","httpAddr":"data-2:8086","httpScheme":"http","status":"joined"}],"links":{"self":"/chronograf/v1/sources/1/cluster","shards":"/chronograf/v1/sources/1/cluster/shards","truncate":"/chronograf/v1/sources/1/cluster/truncate"}}`,
+		},
+		{
+			name:       "not an enterprise source",
+			handler:    func(s *Service) http.HandlerFunc { return s.Cluster },
+			params:     httprouter.Params{{Key: "id", Value: "2"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "shards of a database",
+			handler:    func(s *Service) http.HandlerFunc { return s.ClusterShards },
+			target:     "?db=telegraf",
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"shardGroups":[{"id":"1","database":"telegraf","retentionPolicy":"autogen","replicaN":2,"startTime":"2026-10-12T00:00:00Z","endTime":"2026-10-19T00:00:00Z","expireTime":"0001-01-01T00:00:00Z","truncatedAt":"0001-01-01T00:00:00Z","shards":[{"id":"1","owners":[{"id":"4","tcpAddr":"data-1:8088","httpAddr":"data-1:8086","size":1024}],"size":1024},{"id":"2","owners":[{"id":"4","tcpAddr":"data-1:8088","httpAddr":"data-1:8086","size":2048},{"id":"5","tcpAddr":"data-2:8088","httpAddr":"data-2:8086","size":4096}],"size":4096}]}]}`,
+		},
+		{
+			name:        "copy shard",
+			handler:     func(s *Service) http.HandlerFunc { return s.CopyClusterShard },
+			method:      "POST",
+			body:        `{"src":"4","dest":"5"}`,
+			params:      httprouter.Params{{Key: "id", Value: "1"}, {Key: "sid", Value: "1"}},
+			wantStatus:  http.StatusAccepted,
+			wantActions: []string{`POST /copy-shard {"src":"data-1:8088","dest":"data-2:8088","shard":"1"}`},
+		},
+		{
+			name:       "copy shard to an owner",
+			handler:    func(s *Service) http.HandlerFunc { return s.CopyClusterShard },
+			method:     "POST",
+			body:       `{"src":"4","dest":"5"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "sid", Value: "2"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "copy unknown shard",
+			handler:    func(s *Service) http.HandlerFunc { return s.CopyClusterShard },
+			method:     "POST",
+			body:       `{"src":"4","dest":"5"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "sid", Value: "9"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:        "remove shard copy",
+			handler:     func(s *Service) http.HandlerFunc { return s.RemoveClusterShard },
+			method:      "DELETE",
+			params:      httprouter.Params{{Key: "id", Value: "1"}, {Key: "sid", Value: "2"}, {Key: "nid", Value: "5"}},
+			wantStatus:  http.StatusNoContent,
+			wantActions: []string{`POST /remove-shard {"src":"data-2:8088","shard":"2"}`},
+		},
+		{
+			name:       "remove last shard copy",
+			handler:    func(s *Service) http.HandlerFunc { return s.RemoveClusterShard },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "sid", Value: "1"}, {Key: "nid", Value: "4"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "truncate shards",
+			handler:     func(s *Service) http.HandlerFunc { return s.TruncateClusterShards },
+			method:      "POST",
+			body:        `{"delay":"1m"}`,
+			params:      httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus:  http.StatusNoContent,
+			wantActions: []string{`POST /truncate-shards {"delay":60000000000}`},
+		},
+		{
+			name:       "truncate shards with invalid delay",
+			handler:    func(s *Service) http.HandlerFunc { return s.TruncateClusterShards },
+			method:     "POST",
+			body:       `{"delay":"soon"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta.actions = nil
+			s := &Service{
+				Store: &mocks.Store{SourcesStore: sources},
+				TimeSeriesClient: &mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+					QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+						if q.Command != shardSizesQuery {
+							t.Errorf("unexpected query %q", q.Command)
+						}
+						return mocks.NewResponse(sizes, nil), nil
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url"+tt.target, strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if len(meta.actions) != len(tt.wantActions) {
+				t.Fatalf("meta node actions = %v, want %v", meta.actions, tt.wantActions)
+			}
+			for i := range tt.wantActions {
+				if meta.actions[i] != tt.wantActions[i] {
+					t.Errorf("meta node action = %s, want %s", meta.actions[i], tt.wantActions[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInfluxClient_Forget(t *testing.T) {
+	meta := newMetaNodeStandIn()
+	defer meta.Close()
+
+	c := &InfluxClient{}
+	logger := log.New(log.DebugLevel)
+	src := chronograf.Source{ID: 1, URL: "http://data-1:8086", MetaURL: meta.URL, Type: chronograf.InfluxDBv1Enterprise}
+	first, err := c.New(src, logger, chronograf.V3Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := c.New(src, logger, chronograf.V3Config{}); again != first {
+		t.Error("New() did not reuse the client of the cluster")
+	}
+
+	c.Forget(src.ID)
+	c.mu.Lock()
+	n := len(c.clusters)
+	c.mu.Unlock()
+	if n != 0 {
+		t.Errorf("clusters = %d after Forget(), want 0", n)
+	}
+	next, err := c.New(src, logger, chronograf.V3Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == first {
+		t.Error("New() reused the client of a forgotten cluster")
+	}
+	c.Forget(src.ID)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/influxql"
+)
+
+type cqLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type cqResponse struct {
+	chronograf.ContinuousQuery
+	Links cqLinks `json:"links"` // Links are URI locations related to the continuous query
+}
+
+func newCQResponse(srcID int, db string, cq chronograf.ContinuousQuery) cqResponse {
+	base := "/chronograf/v1/sources"
+	return cqResponse{
+		ContinuousQuery: cq,
+		Links: cqLinks{
+			Self: fmt.Sprintf("%s/%d/dbs/%s/cqs/%s", base, srcID, db, cq.Name),
+		},
+	}
+}
+
+type cqsResponse struct {
+	ContinuousQueries []cqResponse `json:"continuousQueries"`
+}
+
+// ContinuousQueries lists the continuous queries of a database
+func (h *Service) ContinuousQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	cqs, err := dbsvc.AllCQ(ctx, db)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+
+	res := cqsResponse{
+		ContinuousQueries: make([]cqResponse, len(cqs)),
+	}
+	for i, cq := range cqs {
+		res.ContinuousQueries[i] = newCQResponse(srcID, db, cq)
+	}
+	encodeJSON(w, http.StatusOK, res, h.Logger)
+}
+
+// NewContinuousQuery creates a continuous query of a database
+func (h *Service) NewContinuousQuery(w http.ResponseWriter, r *http.Request) {
+	postedCQ := &chronograf.ContinuousQuery{}
+	if err := json.NewDecoder(r.Body).Decode(postedCQ); err != nil {
+		invalidJSON(w, h.Logger)
+		return
+	}
+	if err := ValidContinuousQueryRequest(postedCQ); err != nil {
+		invalidData(w, err, h.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	cq, err := dbsvc.CreateCQ(ctx, db, postedCQ)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+	res := newCQResponse(srcID, db, *cq)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, h.Logger)
+}
+
+// DropContinuousQuery removes a continuous query from a database
+func (h *Service) DropContinuousQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	cq := httprouter.GetParamFromContext(ctx, "cq")
+	if err := dbsvc.DropCQ(ctx, db, cq); err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidContinuousQueryRequest checks that the query of a continuous query is
+// a SELECT statement writing its aggregates INTO a measurement
+func ValidContinuousQueryRequest(cq *chronograf.ContinuousQuery) error {
+	if cq.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	stmt, err := influx.ParseContinuousQuery(cq.Query)
+	if err != nil {
+		return err
+	}
+	if interval, err := stmt.GroupByInterval(); err != nil || interval == 0 {
+		return fmt.Errorf("invalid query: continuous queries must GROUP BY time()")
+	}
+	for _, d := range []string{cq.ResampleEvery, cq.ResampleFor} {
+		if d == "" {
+			continue
+		}
+		if _, err := influxql.ParseDuration(d); err != nil {
+			return fmt.Errorf("invalid resample duration %q", d)
+		}
+	}
+	return nil
+}
+
+// sourceDatabases connects the database service to the source of the
+// request. It responds with an error and returns false if that fails.
+func (h *Service) sourceDatabases(ctx context.Context, w http.ResponseWriter, r *http.Request) (int, chronograf.Databases, bool) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), h.Logger)
+		return 0, nil, false
+	}
+
+	src, err := h.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, h.Logger)
+		return 0, nil, false
+	}
+
+	dbsvc := h.Databases
+	if err = dbsvc.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, h.Logger)
+		return 0, nil, false
+	}
+	return srcID, dbsvc, true
+}
@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestValidContinuousQueryRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		cq      chronograf.ContinuousQuery
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			cq: chronograf.ContinuousQuery{
+				Name:          "cpu_1h",
+				Query:         `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)`,
+				ResampleEvery: "30m",
+				ResampleFor:   "2h",
+			},
+		},
+		{
+			name:    "missing name",
+			cq:      chronograf.ContinuousQuery{Query: `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)`},
+			wantErr: true,
+		},
+		{
+			name:    "trailing statements",
+			cq:      chronograf.ContinuousQuery{Name: "cpu_1h", Query: `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h) END; DROP DATABASE telegraf; --`},
+			wantErr: true,
+		},
+		{
+			name:    "missing INTO",
+			cq:      chronograf.ContinuousQuery{Name: "cpu_1h", Query: `SELECT mean(usage_idle) FROM cpu GROUP BY time(1h)`},
+			wantErr: true,
+		},
+		{
+			name:    "missing GROUP BY time",
+			cq:      chronograf.ContinuousQuery{Name: "cpu_1h", Query: `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY host`},
+			wantErr: true,
+		},
+		{
+			name:    "not a query",
+			cq:      chronograf.ContinuousQuery{Name: "cpu_1h", Query: `DROP DATABASE telegraf`},
+			wantErr: true,
+		},
+		{
+			name: "invalid resample",
+			cq: chronograf.ContinuousQuery{
+				Name:        "cpu_1h",
+				Query:       `SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)`,
+				ResampleFor: "two hours",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidContinuousQueryRequest(&tt.cq); (err != nil) != tt.wantErr {
+				t.Errorf("ValidContinuousQueryRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_ContinuousQueries(t *testing.T) {
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			if ID != 1 {
+				return chronograf.Source{}, chronograf.ErrSourceNotFound
+			}
+			return chronograf.Source{ID: 1}, nil
+		},
+	}
+	cq := chronograf.ContinuousQuery{
+		Name:  "cpu_1h",
+		Query: `SELECT mean(usage_idle) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)`,
+	}
+
+	tests := []struct {
+		name       string
+		handler    func(*Service) http.HandlerFunc
+		method     string
+		body       string
+		params     httprouter.Params
+		wantStatus int
+		wantBody   string
+		wantDrop   string
+	}{
+		{
+			name:       "list",
+			handler:    func(s *Service) http.HandlerFunc { return s.ContinuousQueries },
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"continuousQueries":[{"name":"cpu_1h","query":"SELECT mean(usage_idle) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)","links":{"self":"/chronograf/v1/sources/1/dbs/telegraf/cqs/cpu_1h"}}]}`,
+		},
+		{
+			name:       "unknown source",
+			handler:    func(s *Service) http.HandlerFunc { return s.ContinuousQueries },
+			params:     httprouter.Params{{Key: "id", Value: "2"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "create",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewContinuousQuery },
+			method:     "POST",
+			body:       `{"name":"cpu_1h","query":"SELECT mean(usage_idle) INTO cpu_1h FROM cpu GROUP BY time(1h)"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"name":"cpu_1h","query":"SELECT mean(usage_idle) INTO telegraf.autogen.cpu_1h FROM telegraf.autogen.cpu GROUP BY time(1h)","links":{"self":"/chronograf/v1/sources/1/dbs/telegraf/cqs/cpu_1h"}}`,
+		},
+		{
+			name:       "create without INTO",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewContinuousQuery },
+			method:     "POST",
+			body:       `{"name":"cpu_1h","query":"SELECT mean(usage_idle) FROM cpu GROUP BY time(1h)"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "drop",
+			handler:    func(s *Service) http.HandlerFunc { return s.DropContinuousQuery },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}, {Key: "cq", Value: "cpu_1h"}},
+			wantStatus: http.StatusNoContent,
+			wantDrop:   "telegraf/cpu_1h",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dropped string
+			s := &Service{
+				Store: &mocks.Store{SourcesStore: sources},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+					AllCQF: func(context.Context, string) ([]chronograf.ContinuousQuery, error) {
+						return []chronograf.ContinuousQuery{cq}, nil
+					},
+					CreateCQF: func(context.Context, string, *chronograf.ContinuousQuery) (*chronograf.ContinuousQuery, error) {
+						return &cq, nil
+					},
+					DropCQF: func(ctx context.Context, db, name string) error {
+						dropped = db + "/" + name
+						return nil
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url", strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if dropped != tt.wantDrop {
+				t.Errorf("dropped %q, want %q", dropped, tt.wantDrop)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/influx/queries"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/parser"
+	"github.com/influxdata/influxql"
+)
+
+// DashboardGridColumns is the number of columns of the dashboard grid
+const DashboardGridColumns = 12
+
+// Severities of dashboard lint findings
+const (
+	LintError   = "error"   // LintError is a problem that breaks a cell or template
+	LintWarning = "warning" // LintWarning is a problem that likely leads to wrong or missing data
+	LintInfo    = "info"    // LintInfo is something that could not be checked
+)
+
+// fluxTemplateRef matches references to template values within flux, e.g. v.host or v["host"]
+var fluxTemplateRef = regexp.MustCompile(`\bv\.([A-Za-z_][A-Za-z0-9_]*)|\bv\["([^"]+)"\]`)
+
+// templateName matches names that can be given to dashboard templates; other
+// matches of influx.TemplateNames, e.g. within timestamps, are ignored
+var templateName = regexp.MustCompile(`^:[A-Za-z_][A-Za-z0-9_-]*:$`)
+
+// builtinTemplates are always provided to dashboard queries
+var builtinTemplates = map[string]bool{
+	influx.TemplateVarDashboardTime:      true,
+	influx.TemplateVarUpperDashboardTime: true,
+	influx.TemplateVarInterval:           true,
+}
+
+// builtinFluxTemplates are the fields of the flux v record that are always provided
+var builtinFluxTemplates = map[string]bool{
+	"timeRangeStart": true,
+	"timeRangeStop":  true,
+	"windowPeriod":   true,
+}
+
+// LintFinding is a problem found within a dashboard
+type LintFinding struct {
+	Severity string `json:"severity"`           // Severity is one of error, warning or info
+	Code     string `json:"code"`               // Code identifies the kind of problem, e.g. unknownMeasurement
+	Message  string `json:"message"`            // Message describes the problem
+	CellID   string `json:"cellID,omitempty"`   // CellID is the cell the problem was found in
+	Query    *int   `json:"query,omitempty"`    // Query is the index of the cell query the problem was found in
+	Template string `json:"template,omitempty"` // Template is the template variable the problem relates to
+}
+
+type lintSummary struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+	Infos    int `json:"infos"`
+}
+
+type lintLinks struct {
+	Self      string `json:"self"`      // Self link mapping to this resource
+	Dashboard string `json:"dashboard"` // Dashboard link to the linted dashboard
+}
+
+type lintResponse struct {
+	Findings []LintFinding `json:"findings"`
+	Summary  lintSummary   `json:"summary"`
+	Links    lintLinks     `json:"links"`
+}
+
+// DashboardLint analyzes the cells and templates of a dashboard and returns
+// all problems found. The sourceID query parameter specifies the source of
+// queries that do not name one; measurements and fields of InfluxQL queries
+// are verified against their source.
+func (s *Service) DashboardLint(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	l := &dashboardLinter{
+		s:        s,
+		sourceID: r.URL.Query().Get("sourceID"),
+		schemas:  map[string]*lintSchema{},
+		unusable: map[int]bool{},
+	}
+	findings := l.lint(ctx, d)
+
+	res := lintResponse{
+		Findings: findings,
+		Links: lintLinks{
+			Self:      fmt.Sprintf("/chronograf/v1/dashboards/%d/lint", d.ID),
+			Dashboard: fmt.Sprintf("/chronograf/v1/dashboards/%d", d.ID),
+		},
+	}
+	for _, f := range findings {
+		switch f.Severity {
+		case LintError:
+			res.Summary.Errors++
+		case LintWarning:
+			res.Summary.Warnings++
+		case LintInfo:
+			res.Summary.Infos++
+		}
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// lintSchema holds the measurements of a database, and fields and tags of
+// its measurements, as far as they have been looked up.
+type lintSchema struct {
+	measurements map[string]bool
+	keys         map[string]map[string]bool
+}
+
+type dashboardLinter struct {
+	s        *Service
+	sourceID string
+	findings []LintFinding
+	schemas  map[string]*lintSchema // keyed by source ID and database
+	unusable map[int]bool           // sources that could not be queried
+}
+
+func (l *dashboardLinter) add(f LintFinding) {
+	l.findings = append(l.findings, f)
+}
+
+func (l *dashboardLinter) lint(ctx context.Context, d chronograf.Dashboard) []LintFinding {
+	l.lintGrid(d.Cells)
+
+	defined := map[string]bool{}
+	for _, t := range d.Templates {
+		if defined[t.Var] {
+			l.add(LintFinding{Severity: LintError, Code: "duplicateTemplate", Template: t.Var,
+				Message: fmt.Sprintf("template %s is defined more than once", t.Var)})
+		}
+		defined[t.Var] = true
+	}
+	if _, err := influx.ResolveOrder(d.Templates); err != nil {
+		l.add(LintFinding{Severity: LintError, Code: "templateCycle", Message: err.Error()})
+	}
+
+	// templates are rendered with their selected value; templates without
+	// one get a placeholder so that the query can still be parsed
+	vars := make([]chronograf.TemplateVar, 0, len(d.Templates))
+	placeholders := map[string]bool{}
+	for _, t := range d.Templates {
+		tv := t.TemplateVar
+		if _, ok := influx.SelectedTemplateValue(tv); !ok {
+			placeholders[t.Var] = true
+			tv.Values = []chronograf.TemplateValue{{Value: "placeholder", Type: templateValueTypes[t.Type], Selected: true}}
+		}
+		vars = append(vars, tv)
+	}
+
+	used := map[string]bool{}
+	for _, t := range d.Templates {
+		refs := templateReferences(t)
+		for _, ref := range refs {
+			used[ref] = true
+			if !defined[ref] && !builtinTemplates[ref] && templateName.MatchString(ref) {
+				l.add(LintFinding{Severity: LintError, Code: "undefinedTemplate", Template: t.Var,
+					Message: fmt.Sprintf("template %s refers to undefined template %s", t.Var, ref)})
+			}
+		}
+	}
+
+	now := time.Now()
+	for _, cell := range d.Cells {
+		for i, q := range cell.Queries {
+			i := i
+			finding := func(severity, code, template, msg string) {
+				l.add(LintFinding{Severity: severity, Code: code, CellID: cell.ID, Query: &i, Template: template, Message: msg})
+			}
+			if strings.TrimSpace(q.Command) == "" {
+				finding(LintWarning, "emptyQuery", "", "query is empty")
+				continue
+			}
+
+			if q.Type == "flux" {
+				for _, name := range fluxTemplateNames(q.Command) {
+					ref := ":" + name + ":"
+					used[ref] = true
+					if !defined[ref] && !builtinFluxTemplates[name] {
+						finding(LintError, "undefinedTemplate", ref, fmt.Sprintf("query refers to undefined template %s", ref))
+					}
+				}
+				pkg := parser.ParseSource(q.Command)
+				if ast.Check(pkg) > 0 {
+					finding(LintError, "invalidQuery", "", fmt.Sprintf("invalid Flux: %v", ast.GetError(pkg)))
+				}
+				continue
+			}
+
+			usesPlaceholder, undefined := false, false
+			for _, ref := range influx.TemplateNames(q.Command) {
+				used[ref] = true
+				if !defined[ref] && !builtinTemplates[ref] && templateName.MatchString(ref) {
+					finding(LintError, "undefinedTemplate", ref, fmt.Sprintf("query refers to undefined template %s", ref))
+					undefined = true
+				}
+				usesPlaceholder = usesPlaceholder || placeholders[ref]
+			}
+			if undefined {
+				continue
+			}
+
+			command, err := renderInfluxQLQuery(q.Command, vars, "now() - 1h", "", now)
+			if err != nil {
+				finding(LintError, "invalidTemplate", "", err.Error())
+				continue
+			}
+			stmt, err := queries.ParseSelect(command)
+			if err != nil {
+				if _, perr := influxql.ParseQuery(command); perr != nil {
+					finding(LintError, "invalidQuery", "", fmt.Sprintf("invalid InfluxQL: %v", perr))
+				}
+				// valid statements other than a single SELECT are not analyzed further
+				continue
+			}
+			if usesPlaceholder {
+				finding(LintInfo, "unresolvedTemplate", "", "schema not verified because the query uses templates without selected value")
+				continue
+			}
+			l.lintSchema(ctx, stmt, q, finding)
+		}
+	}
+
+	for _, t := range d.Templates {
+		if !used[t.Var] {
+			l.add(LintFinding{Severity: LintWarning, Code: "unusedTemplate", Template: t.Var,
+				Message: fmt.Sprintf("template %s is not used by any query", t.Var)})
+		}
+	}
+
+	if l.findings == nil {
+		return []LintFinding{}
+	}
+	return l.findings
+}
+
+// lintGrid verifies that cell IDs are unique and that cells fit into the
+// dashboard grid without overlapping.
+func (l *dashboardLinter) lintGrid(cells []chronograf.DashboardCell) {
+	ids := map[string]bool{}
+	for i, c := range cells {
+		if ids[c.ID] {
+			l.add(LintFinding{Severity: LintError, Code: "duplicateCellID", CellID: c.ID,
+				Message: fmt.Sprintf("cell ID %s is used by more than one cell", c.ID)})
+		}
+		ids[c.ID] = true
+
+		switch {
+		case c.W <= 0 || c.H <= 0:
+			l.add(LintFinding{Severity: LintError, Code: "invalidCellSize", CellID: c.ID,
+				Message: fmt.Sprintf("cell has invalid size %dx%d", c.W, c.H)})
+			continue
+		case c.X < 0 || c.Y < 0:
+			l.add(LintFinding{Severity: LintError, Code: "invalidCellPosition", CellID: c.ID,
+				Message: fmt.Sprintf("cell has invalid position %d,%d", c.X, c.Y)})
+			continue
+		case c.X+c.W > DashboardGridColumns:
+			l.add(LintFinding{Severity: LintWarning, Code: "cellOutOfGrid", CellID: c.ID,
+				Message: fmt.Sprintf("cell exceeds the %d columns of the grid", DashboardGridColumns)})
+		}
+
+		for _, o := range cells[:i] {
+			if o.W <= 0 || o.H <= 0 {
+				continue
+			}
+			if c.X < o.X+o.W && o.X < c.X+c.W && c.Y < o.Y+o.H && o.Y < c.Y+c.H {
+				l.add(LintFinding{Severity: LintWarning, Code: "overlappingCells", CellID: c.ID,
+					Message: fmt.Sprintf("cell overlaps cell %s", o.ID)})
+			}
+		}
+	}
+}
+
+// lintSchema verifies that the measurements and fields selected by the
+// statement exist on the source of the query.
+func (l *dashboardLinter) lintSchema(ctx context.Context, stmt *queries.SelectStatement, q chronograf.DashboardQuery, finding func(severity, code, template, msg string)) {
+	srcID := l.sourceID
+	if q.Source != "" {
+		srcID = path.Base(q.Source)
+	}
+	id, err := strconv.Atoi(srcID)
+	if err != nil {
+		finding(LintInfo, "noSource", "", "schema not verified because the query has no source")
+		return
+	}
+	if l.unusable[id] {
+		return
+	}
+
+	refs := []string{}
+	influxql.WalkFunc(stmt.Fields, func(n influxql.Node) {
+		if ref, ok := n.(*influxql.VarRef); ok && ref.Val != "time" {
+			refs = append(refs, ref.Val)
+		}
+	})
+
+	for _, source := range stmt.Sources {
+		m, ok := source.(*influxql.Measurement)
+		if !ok || m.Regex != nil || m.Name == "" {
+			continue
+		}
+		db := m.Database
+		if db == "" {
+			db = q.QueryConfig.Database
+		}
+		if db == "" {
+			finding(LintInfo, "noDatabase", "", fmt.Sprintf("measurement %s not verified because the query has no database", m.Name))
+			continue
+		}
+
+		schema, err := l.schema(ctx, id, db)
+		if err != nil {
+			l.unusable[id] = true
+			l.add(LintFinding{Severity: LintInfo, Code: "sourceUnavailable",
+				Message: fmt.Sprintf("schema of source %d not verified: %v", id, err)})
+			return
+		}
+		if !schema.measurements[m.Name] {
+			finding(LintWarning, "unknownMeasurement", "", fmt.Sprintf("measurement %q does not exist in database %q", m.Name, db))
+			continue
+		}
+		keys, err := l.keys(ctx, id, db, schema, m.Name)
+		if err != nil {
+			finding(LintInfo, "sourceUnavailable", "", fmt.Sprintf("fields of measurement %q not verified: %v", m.Name, err))
+			continue
+		}
+		for _, ref := range refs {
+			if !keys[ref] {
+				finding(LintWarning, "unknownField", "", fmt.Sprintf("field %q does not exist in measurement %q", ref, m.Name))
+			}
+		}
+	}
+}
+
+// schema returns the measurements of the database.
+func (l *dashboardLinter) schema(ctx context.Context, srcID int, db string) (*lintSchema, error) {
+	key := strconv.Itoa(srcID) + "/" + db
+	if schema, ok := l.schemas[key]; ok {
+		return schema, nil
+	}
+	measurements, err := l.metaQuery(ctx, srcID, fmt.Sprintf("SHOW MEASUREMENTS ON %s", influxql.QuoteIdent(db)))
+	if err != nil {
+		return nil, err
+	}
+	schema := &lintSchema{
+		measurements: toSet(measurements),
+		keys:         map[string]map[string]bool{},
+	}
+	l.schemas[key] = schema
+	return schema, nil
+}
+
+// keys returns the field and tag keys of the measurement.
+func (l *dashboardLinter) keys(ctx context.Context, srcID int, db string, schema *lintSchema, measurement string) (map[string]bool, error) {
+	if keys, ok := schema.keys[measurement]; ok {
+		return keys, nil
+	}
+	from := fmt.Sprintf("ON %s FROM %s", influxql.QuoteIdent(db), influxql.QuoteIdent(measurement))
+	fields, err := l.metaQuery(ctx, srcID, "SHOW FIELD KEYS "+from)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := l.metaQuery(ctx, srcID, "SHOW TAG KEYS "+from)
+	if err != nil {
+		return nil, err
+	}
+	keys := toSet(append(fields, tags...))
+	schema.keys[measurement] = keys
+	return keys, nil
+}
+
+func (l *dashboardLinter) metaQuery(ctx context.Context, srcID int, command string) ([]string, error) {
+	src, err := l.s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		return nil, fmt.Errorf("source %d not found", srcID)
+	}
+	ts, err := l.s.TimeSeries(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.Connect(ctx, &src); err != nil {
+		return nil, err
+	}
+	response, err := ts.Query(ctx, chronograf.Query{Command: command})
+	if err != nil {
+		return nil, err
+	}
+	results, err := response.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return influx.MetaQueryValues(command, results)
+}
+
+// templateReferences returns the templates the query or the values of the
+// template refer to, apart from those always provided.
+func templateReferences(t chronograf.Template) []string {
+	refs := influx.TemplateDependencies(t.Values)
+	if t.Query != nil {
+		// meta queries refer to their database, measurement and tag key with
+		// placeholders that are filled in from the fields of the query
+		command := t.Query.Command
+		if t.Type != "flux" {
+			command = templateInternalQuery(t)
+		}
+		refs = append(refs, influx.TemplateNames(command)...)
+		for _, name := range fluxTemplateNames(t.Query.Flux) {
+			if !builtinFluxTemplates[name] {
+				refs = append(refs, ":"+name+":")
+			}
+		}
+	}
+	return refs
+}
+
+// fluxTemplateNames returns the names of the fields of the v record used
+// within the flux script.
+func fluxTemplateNames(script string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, m := range fluxTemplateRef.FindAllStringSubmatch(script, -1) {
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
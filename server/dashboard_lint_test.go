@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestService_DashboardLint(t *testing.T) {
+	influxql := func(command string) chronograf.DashboardQuery {
+		return chronograf.DashboardQuery{
+			Command:     command,
+			Type:        "influxql",
+			QueryConfig: chronograf.QueryConfig{Database: "telegraf"},
+		}
+	}
+	tests := []struct {
+		name      string
+		dashboard chronograf.Dashboard
+		want      []string // severity and code of the findings, sorted
+	}{
+		{
+			name: "valid dashboard",
+			dashboard: chronograf.Dashboard{
+				Cells: []chronograf.DashboardCell{
+					{
+						ID: "a", W: 6, H: 4,
+						Queries: []chronograf.DashboardQuery{
+							influxql(`SELECT mean("usage_idle") FROM "cpu" WHERE "host" = :host: AND time > :dashboardTime: GROUP BY time(:interval:), "host"`),
+						},
+					},
+					{
+						ID: "b", X: 6, W: 6, H: 4,
+						Queries: []chronograf.DashboardQuery{
+							influxql(`SELECT "used" FROM "mem" WHERE time > '2024-01-01T00:00:00Z'`),
+						},
+					},
+				},
+				Templates: []chronograf.Template{
+					{
+						TemplateVar: chronograf.TemplateVar{
+							Var:    ":host:",
+							Values: []chronograf.TemplateValue{{Value: "a", Type: "tagValue", Selected: true}},
+						},
+						Type: "tagValues",
+						Query: &chronograf.TemplateQuery{
+							Command:     `SHOW TAG VALUES ON :database: FROM :measurement: WITH KEY = :tagKey:`,
+							DB:          "telegraf",
+							Measurement: "cpu",
+							TagKey:      "host",
+						},
+					},
+				},
+			},
+			want: []string{},
+		},
+		{
+			name: "grid problems",
+			dashboard: chronograf.Dashboard{
+				Cells: []chronograf.DashboardCell{
+					{ID: "a", W: 6, H: 4},
+					{ID: "a", X: 3, Y: 2, W: 6, H: 4},
+					{ID: "b", X: 10, Y: 10, W: 4, H: 4},
+					{ID: "c", W: 0, H: 4},
+				},
+			},
+			want: []string{"error duplicateCellID", "error invalidCellSize", "warning cellOutOfGrid", "warning overlappingCells"},
+		},
+		{
+			name: "template problems",
+			dashboard: chronograf.Dashboard{
+				Cells: []chronograf.DashboardCell{
+					{
+						ID: "a", W: 4, H: 4,
+						Queries: []chronograf.DashboardQuery{
+							influxql(`SELECT "usage_idle" FROM "cpu" WHERE "host" = :server:`),
+						},
+					},
+				},
+				Templates: []chronograf.Template{
+					{
+						TemplateVar: chronograf.TemplateVar{
+							Var:    ":host:",
+							Values: []chronograf.TemplateValue{{Value: "a", Type: "tagValue", Selected: true}},
+						},
+						Type: "tagValues",
+					},
+				},
+			},
+			want: []string{"error undefinedTemplate", "warning unusedTemplate"},
+		},
+		{
+			name: "query problems",
+			dashboard: chronograf.Dashboard{
+				Cells: []chronograf.DashboardCell{
+					{
+						ID: "a", W: 4, H: 4,
+						Queries: []chronograf.DashboardQuery{
+							influxql(`SELECT "usage_idle" FRM "cpu"`),
+							influxql(`SELECT "usage_idle" FROM "disk"`),
+							influxql(`SELECT "usage_steal" FROM "cpu"`),
+							influxql(``),
+						},
+					},
+				},
+			},
+			want: []string{"error invalidQuery", "warning emptyQuery", "warning unknownField", "warning unknownMeasurement"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return tt.dashboard, nil
+						},
+					},
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+							return chronograf.Source{ID: id}, nil
+						},
+					},
+				},
+				TimeSeriesClient: &mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+						switch {
+						case strings.HasPrefix(q.Command, "SHOW MEASUREMENTS"):
+							return mocks.NewResponse(`[{"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["mem"]]}]}]`, nil), nil
+						case strings.HasPrefix(q.Command, "SHOW FIELD KEYS"):
+							return mocks.NewResponse(`[{"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["usage_idle","float"],["used","integer"]]}]}]`, nil), nil
+						default:
+							return mocks.NewResponse(`[{"series":[{"name":"cpu","columns":["tagKey"],"values":[["host"]]}]}]`, nil), nil
+						}
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://any.url?sourceID=1", nil)
+			ctx := dashboardACLContext(2, roles.ViewerRoleName)
+			s.DashboardLint(w, r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}})))
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("DashboardLint status = %d: %s", resp.StatusCode, body)
+			}
+			var res lintResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, f := range res.Findings {
+				got = append(got, f.Severity+" "+f.Code)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DashboardLint() findings = %v, want %v: %s", got, tt.want, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/roles"
+)
+
+type dashboardPermissionsLinks struct {
+	Self      string `json:"self"`      // Self link mapping to this resource
+	Dashboard string `json:"dashboard"` // Dashboard link to the dashboard these permissions protect
+}
+
+type dashboardPermissionsResponse struct {
+	chronograf.DashboardACL
+	Restricted bool                      `json:"restricted"` // Restricted is true if the dashboard has an ACL
+	Links      dashboardPermissionsLinks `json:"links"`
+}
+
+func newDashboardPermissionsResponse(d chronograf.Dashboard) *dashboardPermissionsResponse {
+	base := "/chronograf/v1/dashboards"
+	res := &dashboardPermissionsResponse{
+		DashboardACL: chronograf.DashboardACL{
+			Users: []chronograf.DashboardUserAccess{},
+			Roles: []chronograf.DashboardRoleAccess{},
+		},
+		Links: dashboardPermissionsLinks{
+			Self:      fmt.Sprintf("%s/%d/permissions", base, d.ID),
+			Dashboard: fmt.Sprintf("%s/%d", base, d.ID),
+		},
+	}
+	if d.ACL != nil {
+		res.Restricted = true
+		res.Owner = d.ACL.Owner
+		if d.ACL.Users != nil {
+			res.Users = d.ACL.Users
+		}
+		if d.ACL.Roles != nil {
+			res.Roles = d.ACL.Roles
+		}
+	}
+	return res
+}
+
+// dashboardAccess returns the access level the requester has on the dashboard.
+// Requests made with server context, super admins and organization admins are
+// never restricted; neither is any dashboard without an ACL. Otherwise the
+// highest access granted to the user, either as owner, directly or through
+// their role in the organization, is returned. An empty string means that no
+// access was granted.
+func dashboardAccess(ctx context.Context, d chronograf.Dashboard) string {
+	if d.ACL == nil || hasServerContext(ctx) || hasSuperAdminContext(ctx) {
+		return chronograf.DashboardAccessEdit
+	}
+	role, hasRole := hasRoleContext(ctx)
+	if hasRole && role == roles.AdminRoleName {
+		return chronograf.DashboardAccessEdit
+	}
+
+	access := ""
+	if u, ok := hasUserContext(ctx); ok {
+		if u.ID == d.ACL.Owner {
+			return chronograf.DashboardAccessEdit
+		}
+		for _, a := range d.ACL.Users {
+			if a.ID == u.ID {
+				access = maxDashboardAccess(access, a.Access)
+			}
+		}
+	}
+	if hasRole {
+		member := &chronograf.User{Roles: []chronograf.Role{{Name: role}}}
+		for _, a := range d.ACL.Roles {
+			if hasAuthorizedRole(member, a.Name) {
+				access = maxDashboardAccess(access, a.Access)
+			}
+		}
+	}
+	return access
+}
+
+func maxDashboardAccess(a, b string) string {
+	if a == chronograf.DashboardAccessEdit || b == chronograf.DashboardAccessEdit {
+		return chronograf.DashboardAccessEdit
+	}
+	if a == chronograf.DashboardAccessView || b == chronograf.DashboardAccessView {
+		return chronograf.DashboardAccessView
+	}
+	return ""
+}
+
+// hasDashboardAccess returns true if the requester has at least the access
+// level on the dashboard.
+func hasDashboardAccess(ctx context.Context, d chronograf.Dashboard, access string) bool {
+	switch dashboardAccess(ctx, d) {
+	case chronograf.DashboardAccessEdit:
+		return true
+	case chronograf.DashboardAccessView:
+		return access == chronograf.DashboardAccessView
+	}
+	return false
+}
+
+// canManageDashboardPermissions returns true if the requester may change the
+// ACL of the dashboard. Unrestricted dashboards may be restricted by anyone
+// allowed to edit them; once restricted, only the owner and admins may change
+// the ACL.
+func canManageDashboardPermissions(ctx context.Context, d chronograf.Dashboard) bool {
+	if d.ACL == nil {
+		return true
+	}
+	if hasServerContext(ctx) || hasSuperAdminContext(ctx) {
+		return true
+	}
+	if role, ok := hasRoleContext(ctx); ok && role == roles.AdminRoleName {
+		return true
+	}
+	u, ok := hasUserContext(ctx)
+	return ok && u.ID == d.ACL.Owner
+}
+
+func dashboardForbidden(w http.ResponseWriter, id chronograf.DashboardID, logger chronograf.Logger) {
+	Error(w, http.StatusForbidden, fmt.Sprintf("User is not authorized to access dashboard %d", id), logger)
+}
+
+// ValidDashboardACL verifies that all grants of the ACL name a valid access
+// level and role.
+func ValidDashboardACL(acl *chronograf.DashboardACL) error {
+	for _, u := range acl.Users {
+		if u.ID == 0 {
+			return fmt.Errorf("user access must specify a user id")
+		}
+		if !oneOf(u.Access, chronograf.DashboardAccessView, chronograf.DashboardAccessEdit) {
+			return fmt.Errorf("invalid access %q for user %d: must be 'view' or 'edit'", u.Access, u.ID)
+		}
+	}
+	for _, r := range acl.Roles {
+		if !oneOf(r.Name, roles.MemberRoleName, roles.ReaderRoleName, roles.ViewerRoleName, roles.EditorRoleName, roles.AdminRoleName) {
+			return fmt.Errorf("invalid role %q", r.Name)
+		}
+		if !oneOf(r.Access, chronograf.DashboardAccessView, chronograf.DashboardAccessEdit) {
+			return fmt.Errorf("invalid access %q for role %s: must be 'view' or 'edit'", r.Access, r.Name)
+		}
+	}
+	return nil
+}
+
+// DashboardPermissions returns the ACL of a dashboard
+func (s *Service) DashboardPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newDashboardPermissionsResponse(d), s.Logger)
+}
+
+// ReplaceDashboardPermissions replaces the ACL of a dashboard. If no owner is
+// given, the requesting user becomes the owner.
+func (s *Service) ReplaceDashboardPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !canManageDashboardPermissions(ctx, d) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	var acl chronograf.DashboardACL
+	if err := json.NewDecoder(r.Body).Decode(&acl); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if acl.Owner == 0 {
+		if u, ok := hasUserContext(ctx); ok {
+			acl.Owner = u.ID
+		}
+	}
+	if err := ValidDashboardACL(&acl); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	d.ACL = &acl
+	if err := s.Store.Dashboards(ctx).Update(ctx, d); err != nil {
+		msg := fmt.Sprintf("Error updating permissions of dashboard ID %d: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+
+	encodeJSON(w, http.StatusOK, newDashboardPermissionsResponse(d), s.Logger)
+}
+
+// RemoveDashboardPermissions removes the ACL of a dashboard so that it is
+// accessible according to organization roles again.
+func (s *Service) RemoveDashboardPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !canManageDashboardPermissions(ctx, d) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	d.ACL = nil
+	if err := s.Store.Dashboards(ctx).Update(ctx, d); err != nil {
+		msg := fmt.Sprintf("Error removing permissions of dashboard ID %d: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
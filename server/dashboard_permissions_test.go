@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func dashboardACLContext(userID uint64, role string) context.Context {
+	ctx := context.WithValue(context.Background(), UserContextKey, &chronograf.User{ID: userID})
+	return context.WithValue(ctx, roles.ContextKey, role)
+}
+
+func Test_dashboardAccess(t *testing.T) {
+	acl := &chronograf.DashboardACL{
+		Owner: 1,
+		Users: []chronograf.DashboardUserAccess{
+			{ID: 2, Access: chronograf.DashboardAccessView},
+			{ID: 3, Access: chronograf.DashboardAccessEdit},
+		},
+		Roles: []chronograf.DashboardRoleAccess{
+			{Name: roles.EditorRoleName, Access: chronograf.DashboardAccessView},
+		},
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		acl  *chronograf.DashboardACL
+		want string
+	}{
+		{
+			name: "dashboard without ACL is unrestricted",
+			ctx:  dashboardACLContext(9, roles.ViewerRoleName),
+			want: chronograf.DashboardAccessEdit,
+		},
+		{
+			name: "server context is unrestricted",
+			ctx:  serverContext(context.Background()),
+			acl:  acl,
+			want: chronograf.DashboardAccessEdit,
+		},
+		{
+			name: "owner can edit",
+			ctx:  dashboardACLContext(1, roles.ViewerRoleName),
+			acl:  acl,
+			want: chronograf.DashboardAccessEdit,
+		},
+		{
+			name: "organization admin can edit",
+			ctx:  dashboardACLContext(9, roles.AdminRoleName),
+			acl:  acl,
+			want: chronograf.DashboardAccessEdit,
+		},
+		{
+			name: "user granted view",
+			ctx:  dashboardACLContext(2, roles.ViewerRoleName),
+			acl:  acl,
+			want: chronograf.DashboardAccessView,
+		},
+		{
+			name: "user grant wins over role grant",
+			ctx:  dashboardACLContext(3, roles.EditorRoleName),
+			acl:  acl,
+			want: chronograf.DashboardAccessEdit,
+		},
+		{
+			name: "role grant applies to role",
+			ctx:  dashboardACLContext(9, roles.EditorRoleName),
+			acl:  acl,
+			want: chronograf.DashboardAccessView,
+		},
+		{
+			name: "role grant does not apply to lesser role",
+			ctx:  dashboardACLContext(9, roles.ViewerRoleName),
+			acl:  acl,
+			want: "",
+		},
+		{
+			name: "anonymous request has no access",
+			ctx:  context.Background(),
+			acl:  acl,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := chronograf.Dashboard{ID: 1, ACL: tt.acl}
+			if got := dashboardAccess(tt.ctx, d); got != tt.want {
+				t.Errorf("dashboardAccess() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_DashboardPermissions(t *testing.T) {
+	restricted := chronograf.Dashboard{
+		ID:   1,
+		Name: "Finance",
+		ACL: &chronograf.DashboardACL{
+			Owner: 1,
+			Roles: []chronograf.DashboardRoleAccess{
+				{Name: roles.EditorRoleName, Access: chronograf.DashboardAccessView},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		ctx        context.Context
+		dashboard  chronograf.Dashboard
+		handler    func(s *Service) http.HandlerFunc
+		wantStatus int
+		wantBody   string
+		wantACL    *chronograf.DashboardACL
+	}{
+		{
+			name:       "viewer cannot get a restricted dashboard",
+			method:     "GET",
+			ctx:        dashboardACLContext(2, roles.ViewerRoleName),
+			dashboard:  restricted,
+			handler:    func(s *Service) http.HandlerFunc { return s.DashboardID },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "editor with role grant can read permissions",
+			method:     "GET",
+			ctx:        dashboardACLContext(2, roles.EditorRoleName),
+			dashboard:  restricted,
+			handler:    func(s *Service) http.HandlerFunc { return s.DashboardPermissions },
+			wantStatus: http.StatusOK,
+			wantBody:   `{"owner":"1","users":[],"roles":[{"name":"editor","access":"view"}],"restricted":true,"links":{"self":"/chronograf/v1/dashboards/1/permissions","dashboard":"/chronograf/v1/dashboards/1"}}`,
+		},
+		{
+			name:       "editor with view grant cannot update cells",
+			method:     "PATCH",
+			body:       `{"name":"renamed"}`,
+			ctx:        dashboardACLContext(2, roles.EditorRoleName),
+			dashboard:  restricted,
+			handler:    func(s *Service) http.HandlerFunc { return s.UpdateDashboard },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "editor cannot change permissions of a restricted dashboard",
+			method:     "PUT",
+			body:       `{"users":[{"id":"2","access":"edit"}]}`,
+			ctx:        dashboardACLContext(2, roles.EditorRoleName),
+			dashboard:  restricted,
+			handler:    func(s *Service) http.HandlerFunc { return s.ReplaceDashboardPermissions },
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "restricting a dashboard makes the requester its owner",
+			method:     "PUT",
+			body:       `{"roles":[{"name":"viewer","access":"view"}]}`,
+			ctx:        dashboardACLContext(2, roles.EditorRoleName),
+			dashboard:  chronograf.Dashboard{ID: 1, Name: "Finance"},
+			handler:    func(s *Service) http.HandlerFunc { return s.ReplaceDashboardPermissions },
+			wantStatus: http.StatusOK,
+			wantACL: &chronograf.DashboardACL{
+				Owner: 2,
+				Roles: []chronograf.DashboardRoleAccess{
+					{Name: roles.ViewerRoleName, Access: chronograf.DashboardAccessView},
+				},
+			},
+		},
+		{
+			name:       "invalid access is rejected",
+			method:     "PUT",
+			body:       `{"roles":[{"name":"viewer","access":"own"}]}`,
+			ctx:        dashboardACLContext(1, roles.EditorRoleName),
+			dashboard:  restricted,
+			handler:    func(s *Service) http.HandlerFunc { return s.ReplaceDashboardPermissions },
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var updated *chronograf.Dashboard
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: &mocks.DashboardsStore{
+						GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+							return tt.dashboard, nil
+						},
+						UpdateF: func(ctx context.Context, d chronograf.Dashboard) error {
+							updated = &d
+							return nil
+						},
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+
+			ctx := httprouter.WithParams(tt.ctx, httprouter.Params{{Key: "id", Value: "1"}})
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, "http://any.url", strings.NewReader(tt.body)).WithContext(ctx)
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body = %s, want %s", body, tt.wantBody)
+				}
+			}
+			if tt.wantACL != nil {
+				if updated == nil || updated.ACL == nil {
+					t.Fatalf("dashboard ACL was not stored")
+				}
+				if updated.ACL.Owner != tt.wantACL.Owner || len(updated.ACL.Roles) != len(tt.wantACL.Roles) || updated.ACL.Roles[0] != tt.wantACL.Roles[0] {
+					t.Errorf("stored ACL = %+v, want %+v", updated.ACL, tt.wantACL)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	uuid "github.com/influxdata/chronograf/id"
+	"github.com/influxdata/chronograf/roles"
+)
+
+// DefaultShareDuration is how long a dashboard share is valid if no
+// expiration is requested
+const DefaultShareDuration = 30 * 24 * time.Hour
+
+// sharedPath is the prefix of the API routes that are accessible with a
+// share token instead of a session
+const sharedPath = "/chronograf/v1/shared/"
+
+var errInvalidShareToken = errors.New("share token is invalid, expired or revoked")
+
+// NewShareSecret derives the key used to sign dashboard share tokens from the
+// token secret. Without token secret a random key is used, in which case all
+// share tokens become invalid when chronograf restarts.
+func NewShareSecret(tokenSecret string) ([]byte, error) {
+	if tokenSecret == "" {
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	mac := hmac.New(sha256.New, []byte(tokenSecret))
+	mac.Write([]byte("chronograf dashboard shares"))
+	return mac.Sum(nil), nil
+}
+
+// shareClaims are the signed contents of a share token
+type shareClaims struct {
+	Dashboard chronograf.DashboardID `json:"d"`
+	Share     string                 `json:"s"`
+	Expires   int64                  `json:"e"`
+}
+
+func (s *Service) signShareToken(id chronograf.DashboardID, share chronograf.DashboardShare) (string, error) {
+	payload, err := json.Marshal(shareClaims{
+		Dashboard: id,
+		Share:     share.ID,
+		Expires:   share.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	claims := base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, s.ShareSecret)
+	mac.Write([]byte(claims))
+	return claims + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (s *Service) parseShareToken(token string, now time.Time) (shareClaims, error) {
+	var claims shareClaims
+	if len(s.ShareSecret) == 0 {
+		return claims, errInvalidShareToken
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return claims, errInvalidShareToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, errInvalidShareToken
+	}
+	mac := hmac.New(sha256.New, s.ShareSecret)
+	mac.Write([]byte(parts[0]))
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return claims, errInvalidShareToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, errInvalidShareToken
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, errInvalidShareToken
+	}
+	if now.Unix() >= claims.Expires {
+		return claims, errInvalidShareToken
+	}
+	return claims, nil
+}
+
+// sharedDashboard returns the dashboard and share that the token grants
+// access to. Tokens of shares that were revoked or that expired are rejected.
+func (s *Service) sharedDashboard(ctx context.Context, token string) (chronograf.Dashboard, chronograf.DashboardShare, error) {
+	now := time.Now()
+	claims, err := s.parseShareToken(token, now)
+	if err != nil {
+		return chronograf.Dashboard{}, chronograf.DashboardShare{}, err
+	}
+	d, err := s.Store.Dashboards(serverContext(ctx)).Get(ctx, claims.Dashboard)
+	if err != nil {
+		return chronograf.Dashboard{}, chronograf.DashboardShare{}, errInvalidShareToken
+	}
+	for _, share := range d.Shares {
+		if share.ID == claims.Share && now.Before(share.ExpiresAt) {
+			return d, share, nil
+		}
+	}
+	return chronograf.Dashboard{}, chronograf.DashboardShare{}, errInvalidShareToken
+}
+
+// isSharedPath returns true if the path belongs to a route that is
+// authorized with a share token.
+func isSharedPath(cleanPath, basepath string) bool {
+	return strings.HasPrefix(cleanPath, path.Join(basepath, sharedPath)+"/")
+}
+
+type dashboardShareLinks struct {
+	Self   string `json:"self"`   // Self link mapping to this resource
+	Shared string `json:"shared"` // Shared is the link to the dashboard that is accessible with the token
+}
+
+type dashboardShareResponse struct {
+	chronograf.DashboardShare
+	Token string              `json:"token"`
+	Links dashboardShareLinks `json:"links"`
+}
+
+type dashboardSharesResponse struct {
+	Shares []dashboardShareResponse `json:"shares"`
+}
+
+func (s *Service) newDashboardShareResponse(id chronograf.DashboardID, share chronograf.DashboardShare) (dashboardShareResponse, error) {
+	token, err := s.signShareToken(id, share)
+	if err != nil {
+		return dashboardShareResponse{}, err
+	}
+	return dashboardShareResponse{
+		DashboardShare: share,
+		Token:          token,
+		Links: dashboardShareLinks{
+			Self:   fmt.Sprintf("/chronograf/v1/dashboards/%d/shares/%s", id, share.ID),
+			Shared: sharedPath + token,
+		},
+	}, nil
+}
+
+// ValidDashboardShare verifies that the share has a time range that can be
+// used for both InfluxQL and Flux queries and that it has not yet expired.
+func ValidDashboardShare(share *chronograf.DashboardShare, now time.Time) error {
+	if share.SourceID == "" {
+		return fmt.Errorf("share must specify a sourceID")
+	}
+	if _, err := strconv.Atoi(share.SourceID); err != nil {
+		return fmt.Errorf("invalid sourceID %q", share.SourceID)
+	}
+	if err := validTimeRange(share.Lower, share.Upper, now); err != nil {
+		return err
+	}
+	if !share.ExpiresAt.After(now) {
+		return fmt.Errorf("share must expire in the future")
+	}
+	return nil
+}
+
+// DashboardShares returns all shares of a dashboard together with their tokens
+func (s *Service) DashboardShares(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	res := dashboardSharesResponse{
+		Shares: make([]dashboardShareResponse, 0, len(d.Shares)),
+	}
+	for _, share := range d.Shares {
+		sr, err := s.newDashboardShareResponse(d.ID, share)
+		if err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+		res.Shares = append(res.Shares, sr)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// NewDashboardShare creates a share of a dashboard and returns its token
+func (s *Service) NewDashboardShare(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	var share chronograf.DashboardShare
+	if err := json.NewDecoder(r.Body).Decode(&share); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	now := time.Now().UTC()
+	if share.Lower == "" {
+		share.Lower = "now() - 1h"
+	}
+	if share.ExpiresAt.IsZero() {
+		share.ExpiresAt = now.Add(DefaultShareDuration)
+	}
+	if err := ValidDashboardShare(&share, now); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	srcID, _ := strconv.Atoi(share.SourceID)
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil || src.Organization != d.Organization {
+		invalidData(w, fmt.Errorf("unknown source %s", share.SourceID), s.Logger)
+		return
+	}
+
+	if share.ID, err = (&uuid.UUID{}).Generate(); err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	share.CreatedAt = now
+	share.CreatedBy = 0
+	if u, ok := hasUserContext(ctx); ok {
+		share.CreatedBy = u.ID
+	}
+
+	d.Shares = append(d.Shares, share)
+	if err := s.Store.Dashboards(ctx).Update(ctx, d); err != nil {
+		msg := fmt.Sprintf("Error sharing dashboard ID %d: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+
+	res, err := s.newDashboardShareResponse(d.ID, share)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	w.Header().Add("Location", res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// RemoveDashboardShare revokes a share so that its token no longer grants
+// access to the dashboard
+func (s *Service) RemoveDashboardShare(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	sid, _ := paramStr("sid", r)
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	shares := make([]chronograf.DashboardShare, 0, len(d.Shares))
+	for _, share := range d.Shares {
+		if share.ID != sid {
+			shares = append(shares, share)
+		}
+	}
+	if len(shares) == len(d.Shares) {
+		notFound(w, sid, s.Logger)
+		return
+	}
+	d.Shares = shares
+
+	if err := s.Store.Dashboards(ctx).Update(ctx, d); err != nil {
+		msg := fmt.Sprintf("Error revoking share %s of dashboard ID %d: %v", sid, id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type sharedCellLinks struct {
+	Queries []string `json:"queries"` // Queries are the links executing the queries of the cell
+}
+
+type sharedCellResponse struct {
+	chronograf.DashboardCell
+	Links sharedCellLinks `json:"links"`
+}
+
+type sharedDashboardLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type sharedDashboardResponse struct {
+	ID        chronograf.DashboardID `json:"id"`
+	Name      string                 `json:"name"`
+	Cells     []sharedCellResponse   `json:"cells"`
+	Templates []chronograf.Template  `json:"templates"`
+	Lower     string                 `json:"lower"`
+	Upper     string                 `json:"upper,omitempty"`
+	ExpiresAt time.Time              `json:"expiresAt"`
+	Links     sharedDashboardLinks   `json:"links"`
+}
+
+func newSharedDashboardResponse(token string, d chronograf.Dashboard, share chronograf.DashboardShare) sharedDashboardResponse {
+	self := sharedPath + token
+	res := sharedDashboardResponse{
+		ID:        d.ID,
+		Name:      d.Name,
+		Cells:     make([]sharedCellResponse, len(d.Cells)),
+		Templates: d.Templates,
+		Lower:     share.Lower,
+		Upper:     share.Upper,
+		ExpiresAt: share.ExpiresAt,
+		Links:     sharedDashboardLinks{Self: self},
+	}
+	if res.Templates == nil {
+		res.Templates = []chronograf.Template{}
+	}
+	for i, cell := range d.Cells {
+		if cell.Queries == nil {
+			cell.Queries = []chronograf.DashboardQuery{}
+		}
+		links := sharedCellLinks{Queries: make([]string, len(cell.Queries))}
+		for j := range cell.Queries {
+			links.Queries[j] = fmt.Sprintf("%s/cells/%s/queries/%d", self, cell.ID, j)
+		}
+		res.Cells[i] = sharedCellResponse{DashboardCell: cell, Links: links}
+	}
+	return res
+}
+
+// SharedDashboard returns the dashboard that the share token grants access to
+func (s *Service) SharedDashboard(w http.ResponseWriter, r *http.Request) {
+	token, _ := paramStr("token", r)
+	d, share, err := s.sharedDashboard(r.Context(), token)
+	if err != nil {
+		Error(w, http.StatusNotFound, err.Error(), s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newSharedDashboardResponse(token, d, share), s.Logger)
+}
+
+// SharedDashboardQuery executes a query stored in a cell of the dashboard
+// that the share token grants access to. Only the stored query is executed,
+// rendered with the stored template values and the shared time range; it is
+// executed with the permissions of a reader.
+func (s *Service) SharedDashboardQuery(w http.ResponseWriter, r *http.Request) {
+	token, _ := paramStr("token", r)
+	cid, _ := paramStr("cid", r)
+	qid, err := paramID("qid", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, share, err := s.sharedDashboard(ctx, token)
+	if err != nil {
+		Error(w, http.StatusNotFound, err.Error(), s.Logger)
+		return
+	}
+
+	var query *chronograf.DashboardQuery
+	for _, cell := range d.Cells {
+		if cell.ID == cid && qid >= 0 && qid < len(cell.Queries) {
+			query = &cell.Queries[qid]
+			break
+		}
+	}
+	if query == nil {
+		Error(w, http.StatusNotFound, fmt.Sprintf("Query %d of cell %s not found", qid, cid), s.Logger)
+		return
+	}
+
+	srcID, err := sharedQuerySource(*query, share)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	src, err := s.Store.Sources(serverContext(ctx)).Get(ctx, srcID)
+	if err != nil || src.Organization != d.Organization {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	templates := make([]chronograf.TemplateVar, 0, len(d.Templates))
+	for _, t := range d.Templates {
+		templates = append(templates, t.TemplateVar)
+	}
+
+	now := time.Now()
+	if query.Type == "flux" {
+		body, err := json.Marshal(map[string]interface{}{
+			"query": renderFluxQuery(query.Command, templates, share.Lower, share.Upper, now),
+			"dialect": map[string]interface{}{
+				"annotations": []string{"group", "datatype", "default"},
+			},
+		})
+		if err != nil {
+			unknownErrorWithMessage(w, err, s.Logger)
+			return
+		}
+		target := r.URL.Path + "?path=" + url.QueryEscape("/api/v2/query")
+		s.ProxyFlux(w, sharedQueryRequest(r, srcID, target, body))
+		return
+	}
+
+	command, err := renderInfluxQLQuery(query.Command, templates, share.Lower, share.Upper, now)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	body, err := json.Marshal(chronograf.Query{
+		Command: command,
+		DB:      query.QueryConfig.Database,
+		RP:      query.QueryConfig.RetentionPolicy,
+	})
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	s.Influx(w, sharedQueryRequest(r, srcID, r.URL.Path, body))
+}
+
+// sharedQuerySource returns the ID of the source a shared query is executed
+// against: the source of the query if it names one, the source of the share
+// otherwise.
+func sharedQuerySource(q chronograf.DashboardQuery, share chronograf.DashboardShare) (int, error) {
+	src := share.SourceID
+	if q.Source != "" {
+		src = path.Base(q.Source)
+	}
+	id, err := strconv.Atoi(src)
+	if err != nil {
+		return 0, fmt.Errorf("invalid source %q", src)
+	}
+	return id, nil
+}
+
+// sharedQueryRequest creates the request that executes a shared query with
+// the source proxies. The request has access to all stores, but is limited to
+// the read-only queries allowed for readers.
+func sharedQueryRequest(r *http.Request, srcID int, target string, body []byte) *http.Request {
+	ctx := serverContext(r.Context())
+	ctx = context.WithValue(ctx, roles.ContextKey, roles.ReaderRoleName)
+	ctx = httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: strconv.Itoa(srcID)}})
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", JSONType)
+	return req
+}
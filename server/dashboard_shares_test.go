@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestService_parseShareToken(t *testing.T) {
+	s := &Service{ShareSecret: []byte("secret")}
+	now := time.Now()
+	share := chronograf.DashboardShare{ID: "abc", ExpiresAt: now.Add(time.Hour)}
+	token, err := s.signShareToken(7, share)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := s.parseShareToken(token, now)
+	if err != nil {
+		t.Fatalf("parseShareToken() error = %v", err)
+	}
+	if claims.Dashboard != 7 || claims.Share != "abc" {
+		t.Errorf("parseShareToken() = %+v", claims)
+	}
+
+	other := &Service{ShareSecret: []byte("other")}
+	forged, _ := json.Marshal(shareClaims{Dashboard: 8, Share: "abc", Expires: share.ExpiresAt.Unix()})
+	tampered := base64.RawURLEncoding.EncodeToString(forged) + token[strings.Index(token, "."):]
+	tests := []struct {
+		name  string
+		s     *Service
+		token string
+		now   time.Time
+	}{
+		{name: "expired token", s: s, token: token, now: now.Add(2 * time.Hour)},
+		{name: "token signed with another secret", s: other, token: token, now: now},
+		{name: "tampered claims", s: s, token: tampered, now: now},
+		{name: "malformed token", s: s, token: "abc", now: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.s.parseShareToken(tt.token, tt.now); err != errInvalidShareToken {
+				t.Errorf("parseShareToken() error = %v, want %v", err, errInvalidShareToken)
+			}
+		})
+	}
+}
+
+func TestValidDashboardShare(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		share   chronograf.DashboardShare
+		wantErr bool
+	}{
+		{
+			name:  "relative time range",
+			share: chronograf.DashboardShare{SourceID: "1", Lower: "now() - 1h", ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:  "absolute time range",
+			share: chronograf.DashboardShare{SourceID: "1", Lower: "2024-01-01T00:00:00Z", Upper: "2024-01-02T00:00:00Z", ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:    "arbitrary lower bound",
+			share:   chronograf.DashboardShare{SourceID: "1", Lower: "now() - 1h OR 1=1", ExpiresAt: now.Add(time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "empty time range",
+			share:   chronograf.DashboardShare{SourceID: "1", Lower: "2024-01-02T00:00:00Z", Upper: "2024-01-01T00:00:00Z", ExpiresAt: now.Add(time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "already expired",
+			share:   chronograf.DashboardShare{SourceID: "1", Lower: "now() - 1h", ExpiresAt: now.Add(-time.Hour)},
+			wantErr: true,
+		},
+		{
+			name:    "missing source",
+			share:   chronograf.DashboardShare{Lower: "now() - 1h", ExpiresAt: now.Add(time.Hour)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidDashboardShare(&tt.share, now); (err != nil) != tt.wantErr {
+				t.Errorf("ValidDashboardShare() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_DashboardShares(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Name:         "Status",
+		Organization: "default",
+		Cells: []chronograf.DashboardCell{
+			{
+				ID: "c1",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean("usage_idle") FROM "cpu" WHERE "host" = :host: AND time > :dashboardTime: GROUP BY time(:interval:)`,
+						QueryConfig: chronograf.QueryConfig{
+							Database:        "telegraf",
+							RetentionPolicy: "autogen",
+						},
+						Type: "influxql",
+					},
+				},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":host:",
+					Values: []chronograf.TemplateValue{
+						{Value: "a", Type: "tagValue"},
+						{Value: "b", Type: "tagValue", Selected: true},
+					},
+				},
+				ID:   "t1",
+				Type: "tagValues",
+			},
+		},
+	}
+
+	var queried chronograf.Query
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+					return dashboard, nil
+				},
+				UpdateF: func(ctx context.Context, d chronograf.Dashboard) error {
+					dashboard = d
+					return nil
+				},
+			},
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+					return chronograf.Source{ID: id, Organization: "default"}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+				queried = q
+				return mocks.NewResponse(`[]`, nil), nil
+			},
+		},
+		Logger:      &mocks.TestLogger{},
+		ShareSecret: []byte("secret"),
+	}
+
+	serve := func(handler http.HandlerFunc, ctx context.Context, method string, params httprouter.Params, body string) (int, []byte) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, "http://any.url", strings.NewReader(body))
+		handler(w, r.WithContext(httprouter.WithParams(ctx, params)))
+		resp := w.Result()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, b
+	}
+	editor := dashboardACLContext(2, roles.EditorRoleName)
+	id := httprouter.Params{{Key: "id", Value: "1"}}
+
+	status, body := serve(s.NewDashboardShare, editor, "POST", id, `{"sourceID":"1","lower":"now() - 1h"}`)
+	if status != http.StatusCreated {
+		t.Fatalf("NewDashboardShare status = %d: %s", status, body)
+	}
+	var created dashboardShareResponse
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Token == "" || created.CreatedBy != 2 || len(dashboard.Shares) != 1 {
+		t.Fatalf("share was not created: %s", body)
+	}
+	token := httprouter.Params{{Key: "token", Value: created.Token}}
+
+	status, body = serve(s.SharedDashboard, context.Background(), "GET", token, "")
+	if status != http.StatusOK {
+		t.Fatalf("SharedDashboard status = %d: %s", status, body)
+	}
+
+	query := append(token, httprouter.Param{Key: "cid", Value: "c1"}, httprouter.Param{Key: "qid", Value: "0"})
+	status, body = serve(s.SharedDashboardQuery, context.Background(), "GET", query, "")
+	if status != http.StatusOK {
+		t.Fatalf("SharedDashboardQuery status = %d: %s", status, body)
+	}
+	want := `SELECT mean("usage_idle") FROM "cpu" WHERE "host" = 'b' AND time > now() - 1h GROUP BY time(10000ms)`
+	if queried.Command != want || queried.DB != "telegraf" || queried.RP != "autogen" {
+		t.Errorf("executed query = %+v, want %s", queried, want)
+	}
+
+	missing := append(token, httprouter.Param{Key: "cid", Value: "c1"}, httprouter.Param{Key: "qid", Value: "1"})
+	if status, body = serve(s.SharedDashboardQuery, context.Background(), "GET", missing, ""); status != http.StatusNotFound {
+		t.Errorf("SharedDashboardQuery of unknown query status = %d: %s", status, body)
+	}
+
+	revoke := append(id, httprouter.Param{Key: "sid", Value: created.ID})
+	if status, body = serve(s.RemoveDashboardShare, editor, "DELETE", revoke, ""); status != http.StatusNoContent {
+		t.Fatalf("RemoveDashboardShare status = %d: %s", status, body)
+	}
+	if status, body = serve(s.SharedDashboard, context.Background(), "GET", token, ""); status != http.StatusNotFound {
+		t.Errorf("SharedDashboard of revoked share status = %d: %s", status, body)
+	}
+}
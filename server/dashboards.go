@@ -9,18 +9,20 @@ import (
 )
 
 type dashboardLinks struct {
-	Self      string `json:"self"`      // Self link mapping to this resource
-	Cells     string `json:"cells"`     // Cells link to the cells endpoint
-	Templates string `json:"templates"` // Templates link to the templates endpoint
+	Self        string `json:"self"`        // Self link mapping to this resource
+	Cells       string `json:"cells"`       // Cells link to the cells endpoint
+	Templates   string `json:"templates"`   // Templates link to the templates endpoint
+	Permissions string `json:"permissions"` // Permissions link to the access control list of the dashboard
 }
 
 type dashboardResponse struct {
-	ID           chronograf.DashboardID  `json:"id,string"`
-	Cells        []dashboardCellResponse `json:"cells"`
-	Templates    []templateResponse      `json:"templates"`
-	Name         string                  `json:"name"`
-	Organization string                  `json:"organization"`
-	Links        dashboardLinks          `json:"links"`
+	ID           chronograf.DashboardID    `json:"id,string"`
+	Cells        []dashboardCellResponse   `json:"cells"`
+	Templates    []templateResponse        `json:"templates"`
+	Name         string                    `json:"name"`
+	Organization string                    `json:"organization"`
+	Managed      *chronograf.DashboardSync `json:"managed,omitempty"`
+	Links        dashboardLinks            `json:"links"`
 }
 
 type getDashboardsResponse struct {
@@ -39,10 +41,12 @@ func newDashboardResponse(d chronograf.Dashboard) *dashboardResponse {
 		Cells:        cells,
 		Templates:    templates,
 		Organization: d.Organization,
+		Managed:      d.Managed,
 		Links: dashboardLinks{
-			Self:      fmt.Sprintf("%s/%d", base, dd.ID),
-			Cells:     fmt.Sprintf("%s/%d/cells", base, dd.ID),
-			Templates: fmt.Sprintf("%s/%d/templates", base, dd.ID),
+			Self:        fmt.Sprintf("%s/%d", base, dd.ID),
+			Cells:       fmt.Sprintf("%s/%d/cells", base, dd.ID),
+			Templates:   fmt.Sprintf("%s/%d/templates", base, dd.ID),
+			Permissions: fmt.Sprintf("%s/%d/permissions", base, dd.ID),
 		},
 	}
 }
@@ -61,6 +65,9 @@ func (s *Service) Dashboards(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, dashboard := range dashboards {
+		if !hasDashboardAccess(ctx, dashboard, chronograf.DashboardAccessView) {
+			continue
+		}
 		res.Dashboards = append(res.Dashboards, newDashboardResponse(dashboard))
 	}
 	encodeJSON(w, http.StatusOK, res, s.Logger)
@@ -80,6 +87,10 @@ func (s *Service) DashboardID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, e, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, e.ID, s.Logger)
+		return
+	}
 
 	res := newDashboardResponse(e)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
@@ -131,6 +142,13 @@ func (s *Service) RemoveDashboard(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, e, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, e.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, e) {
+		return
+	}
 
 	if err := s.Store.Dashboards(ctx).Delete(ctx, e); err != nil {
 		unknownErrorWithMessage(w, err, s.Logger)
@@ -149,11 +167,18 @@ func (s *Service) ReplaceDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 	id := chronograf.DashboardID(idParam)
 
-	_, err = s.Store.Dashboards(ctx).Get(ctx, id)
+	orig, err := s.Store.Dashboards(ctx).Get(ctx, id)
 	if err != nil {
 		Error(w, http.StatusNotFound, fmt.Sprintf("ID %d not found", id), s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, orig, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, id, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, orig) {
+		return
+	}
 
 	var req chronograf.Dashboard
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -161,6 +186,10 @@ func (s *Service) ReplaceDashboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	req.ID = id
+	// the ACL and shares are managed through their own endpoints only
+	req.ACL = orig.ACL
+	req.Shares = orig.Shares
+	req.Managed = orig.Managed
 
 	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
 	if err != nil {
@@ -199,6 +228,13 @@ func (s *Service) UpdateDashboard(w http.ResponseWriter, r *http.Request) {
 		Error(w, http.StatusNotFound, fmt.Sprintf("ID %d not found", id), s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, orig, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, id, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, orig) {
+		return
+	}
 
 	var req chronograf.Dashboard
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -266,6 +302,9 @@ func DashboardDefaults(d chronograf.Dashboard) (newDash chronograf.Dashboard) {
 	newDash.Templates = d.Templates
 	newDash.Name = d.Name
 	newDash.Organization = d.Organization
+	newDash.ACL = d.ACL
+	newDash.Shares = d.Shares
+	newDash.Managed = d.Managed
 	newDash.Cells = make([]chronograf.DashboardCell, len(d.Cells))
 
 	for i, c := range d.Cells {
@@ -0,0 +1,373 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/filestore"
+)
+
+// Modes of handling changes to synced dashboards made outside of their
+// definition files
+const (
+	// DashboardSyncRefuse refuses changes to synced dashboards
+	DashboardSyncRefuse = "refuse"
+	// DashboardSyncDrift allows changes to synced dashboards and reports
+	// them as drift until the definition changes
+	DashboardSyncDrift = "drift"
+)
+
+// DefaultDashboardSyncInterval is used when a DashboardSyncer has no Interval
+const DefaultDashboardSyncInterval = 30 * time.Second
+
+// States of synced dashboards
+const (
+	DashboardSyncCreated   = "created"
+	DashboardSyncUpdated   = "updated"
+	DashboardSyncUnchanged = "unchanged"
+	DashboardSyncDrifted   = "drifted"
+	DashboardSyncPruned    = "pruned"
+	DashboardSyncFailed    = "failed"
+)
+
+// DashboardSyncResult is the outcome of syncing a single dashboard definition
+type DashboardSyncResult struct {
+	Slug         string                 `json:"slug"`
+	Organization string                 `json:"organization"`
+	File         string                 `json:"file,omitempty"`
+	ID           chronograf.DashboardID `json:"id,string,omitempty"`
+	State        string                 `json:"state"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// DashboardSyncStatus is the outcome of the last sync of the dashboards sync
+// directory
+type DashboardSyncStatus struct {
+	Dir      string                               `json:"dir"`
+	Mode     string                               `json:"mode"`
+	Prune    bool                                 `json:"prune"`
+	LastSync time.Time                            `json:"lastSync"`
+	Results  []DashboardSyncResult                `json:"dashboards"`
+	Errors   []filestore.DashboardDefinitionError `json:"errors"`
+}
+
+// DashboardSyncer reconciles the dashboards of all organizations with the
+// definitions of a directory. Dashboards created from definitions are
+// managed: they are updated when their definition changes and, with Prune,
+// deleted when their definition is removed.
+type DashboardSyncer struct {
+	Dir      string        // Dir is the directory containing the definitions
+	Mode     string        // Mode is DashboardSyncRefuse or DashboardSyncDrift
+	Prune    bool          // Prune deletes managed dashboards without definition
+	Interval time.Duration // Interval at which Dir is checked for changes
+	Store    DataStore
+	Logger   chronograf.Logger
+
+	syncMu      sync.Mutex // syncMu serializes syncs so that they do not add the same dashboards
+	mu          sync.Mutex
+	fingerprint string
+	status      DashboardSyncStatus
+}
+
+// Run syncs the directory whenever its files change until ctx is done.
+func (s *DashboardSyncer) Run(ctx context.Context) {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultDashboardSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fingerprint, err := dirFingerprint(s.Dir)
+		if err != nil {
+			s.Logger.
+				WithField("component", "dashboards_sync").
+				WithField("dir", s.Dir).
+				Error("Unable to read dashboards sync directory: ", err)
+		}
+		s.mu.Lock()
+		changed := fingerprint != s.fingerprint
+		s.mu.Unlock()
+		if err == nil && changed {
+			status := s.Sync(ctx)
+			s.mu.Lock()
+			s.fingerprint = fingerprint
+			s.mu.Unlock()
+			s.Logger.
+				WithField("component", "dashboards_sync").
+				WithField("dashboards", len(status.Results)).
+				WithField("errors", len(status.Errors)).
+				Info("Synced dashboards")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Status returns the outcome of the last sync
+func (s *DashboardSyncer) Status() DashboardSyncStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Sync reconciles all dashboards with the definitions of the directory.
+// Concurrent syncs run one after the other.
+func (s *DashboardSyncer) Sync(ctx context.Context) DashboardSyncStatus {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	ctx = serverContext(ctx)
+	defs, errs := filestore.LoadDashboardDefinitions(s.Dir)
+	status := DashboardSyncStatus{
+		Dir:      s.Dir,
+		Mode:     s.Mode,
+		Prune:    s.Prune,
+		LastSync: time.Now().UTC(),
+		Results:  []DashboardSyncResult{},
+		Errors:   errs,
+	}
+
+	fail := func(err error) DashboardSyncStatus {
+		status.Errors = append(status.Errors, filestore.DashboardDefinitionError{File: ".", Err: err.Error()})
+		s.mu.Lock()
+		s.status = status
+		s.mu.Unlock()
+		return status
+	}
+	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	all, err := s.Store.Dashboards(ctx).All(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	managed := map[string]chronograf.Dashboard{}
+	for _, d := range all {
+		if d.Managed != nil {
+			managed[d.Organization+"/"+d.Managed.Slug] = d
+		}
+	}
+
+	defined := map[string]bool{}
+	for _, def := range defs {
+		if def.Organization == "" {
+			def.Organization = defaultOrg.ID
+			def.Dashboard.Organization = defaultOrg.ID
+		}
+		key := def.Organization + "/" + def.Slug
+		defined[key] = true
+
+		res := DashboardSyncResult{
+			Slug:         def.Slug,
+			Organization: def.Organization,
+			File:         def.File,
+		}
+		existing, ok := managed[key]
+		res.ID, res.State, err = s.syncDefinition(ctx, def, existing, ok)
+		if err != nil {
+			res.State = DashboardSyncFailed
+			res.Error = err.Error()
+		}
+		status.Results = append(status.Results, res)
+	}
+
+	// a definition that could not be loaded must not delete its dashboard
+	if s.Prune && len(errs) == 0 {
+		for key, d := range managed {
+			if defined[key] {
+				continue
+			}
+			res := DashboardSyncResult{
+				Slug:         d.Managed.Slug,
+				Organization: d.Organization,
+				ID:           d.ID,
+				State:        DashboardSyncPruned,
+			}
+			if err := s.Store.Dashboards(ctx).Delete(ctx, d); err != nil {
+				res.State = DashboardSyncFailed
+				res.Error = err.Error()
+			}
+			status.Results = append(status.Results, res)
+		}
+	}
+	sort.Slice(status.Results, func(i, j int) bool {
+		a, b := status.Results[i], status.Results[j]
+		return a.Organization+"/"+a.Slug < b.Organization+"/"+b.Slug
+	})
+
+	s.mu.Lock()
+	s.status = status
+	s.mu.Unlock()
+	return status
+}
+
+// syncDefinition creates or updates the dashboard of a definition and returns
+// its ID and state.
+func (s *DashboardSyncer) syncDefinition(ctx context.Context, def filestore.DashboardDefinition, existing chronograf.Dashboard, exists bool) (chronograf.DashboardID, string, error) {
+	if exists {
+		drifted := s.Drifted(existing)
+		if existing.Managed.DefinitionHash == def.Hash {
+			if !drifted {
+				return existing.ID, DashboardSyncUnchanged, nil
+			}
+			if s.Mode == DashboardSyncDrift {
+				return existing.ID, DashboardSyncDrifted, nil
+			}
+		}
+	} else if _, err := s.Store.Organizations(ctx).Get(ctx, chronograf.OrganizationQuery{ID: &def.Organization}); err != nil {
+		return 0, "", fmt.Errorf("organization %s not found", def.Organization)
+	}
+
+	d := def.Dashboard
+	if err := ValidDashboardRequest(&d, def.Organization); err != nil {
+		return existing.ID, "", err
+	}
+	d.Managed = &chronograf.DashboardSync{
+		Slug:           def.Slug,
+		File:           def.File,
+		DefinitionHash: def.Hash,
+		SyncedAt:       time.Now().UTC(),
+	}
+
+	store := s.Store.Dashboards(ctx)
+	state := DashboardSyncCreated
+	if exists {
+		state = DashboardSyncUpdated
+		d.ID = existing.ID
+		d.ACL = existing.ACL
+		d.Shares = existing.Shares
+		if err := store.Update(ctx, d); err != nil {
+			return d.ID, "", err
+		}
+	} else {
+		added, err := store.Add(ctx, d)
+		if err != nil {
+			return 0, "", err
+		}
+		d.ID = added.ID
+	}
+
+	// the content hash is taken from the stored dashboard so that drift is
+	// not caused by the store normalizing the definition
+	stored, err := store.Get(ctx, d.ID)
+	if err != nil {
+		return d.ID, "", err
+	}
+	stored.Managed = d.Managed
+	stored.Managed.ContentHash = dashboardContentHash(stored)
+	if err := store.Update(ctx, stored); err != nil {
+		return d.ID, "", err
+	}
+	return d.ID, state, nil
+}
+
+// Drifted returns true if the managed dashboard was changed since it was
+// last synced.
+func (s *DashboardSyncer) Drifted(d chronograf.Dashboard) bool {
+	return d.Managed != nil && dashboardContentHash(d) != d.Managed.ContentHash
+}
+
+// dashboardContentHash hashes the parts of a dashboard that are declared by
+// its definition.
+func dashboardContentHash(d chronograf.Dashboard) string {
+	content, _ := json.Marshal([]interface{}{d.Name, d.Cells, d.Templates})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// dirFingerprint identifies the state of the files within dir and its
+// sub-directories by their names, sizes and modification times.
+func dirFingerprint(dir string) (string, error) {
+	var b strings.Builder
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return b.String(), err
+}
+
+// refuseManagedEdit responds with 409 Conflict and returns true if the
+// dashboard is managed by a sync directory that refuses changes.
+func (s *Service) refuseManagedEdit(w http.ResponseWriter, d chronograf.Dashboard) bool {
+	if d.Managed == nil || s.DashboardSync == nil || s.DashboardSync.Mode != DashboardSyncRefuse {
+		return false
+	}
+	msg := fmt.Sprintf("dashboard %d is managed by definition %s and cannot be changed", d.ID, d.Managed.File)
+	Error(w, http.StatusConflict, msg, s.Logger)
+	return true
+}
+
+// DashboardsSyncStatus returns the outcome of the last sync of the dashboards
+// of the current organization. Drift is determined at the time of the request.
+func (s *Service) DashboardsSyncStatus(w http.ResponseWriter, r *http.Request) {
+	if s.DashboardSync == nil {
+		Error(w, http.StatusNotFound, "dashboards sync is not enabled", s.Logger)
+		return
+	}
+	ctx := r.Context()
+	encodeJSON(w, http.StatusOK, s.dashboardsSyncStatus(ctx, s.DashboardSync.Status()), s.Logger)
+}
+
+// SyncDashboards syncs the dashboards sync directory immediately.
+func (s *Service) SyncDashboards(w http.ResponseWriter, r *http.Request) {
+	if s.DashboardSync == nil {
+		Error(w, http.StatusNotFound, "dashboards sync is not enabled", s.Logger)
+		return
+	}
+	ctx := r.Context()
+	status := s.DashboardSync.Sync(ctx)
+	encodeJSON(w, http.StatusOK, s.dashboardsSyncStatus(ctx, status), s.Logger)
+}
+
+func (s *Service) dashboardsSyncStatus(ctx context.Context, status DashboardSyncStatus) DashboardSyncStatus {
+	orgID, scoped := hasOrganizationContext(ctx)
+	results := []DashboardSyncResult{}
+	for _, res := range status.Results {
+		if scoped && res.Organization != orgID {
+			continue
+		}
+		if res.State != DashboardSyncFailed && res.State != DashboardSyncPruned {
+			d, err := s.Store.Dashboards(serverContext(ctx)).Get(ctx, res.ID)
+			switch {
+			case err != nil:
+				res.State = DashboardSyncFailed
+				res.Error = "dashboard was deleted"
+			case s.DashboardSync.Drifted(d):
+				res.State = DashboardSyncDrifted
+			}
+		}
+		results = append(results, res)
+	}
+	status.Results = results
+
+	errs := []filestore.DashboardDefinitionError{}
+	for _, e := range status.Errors {
+		// definitions in the directory itself belong to the default organization
+		if dir := filepath.Dir(e.File); scoped && dir != "." && dir != orgID {
+			continue
+		}
+		errs = append(errs, e)
+	}
+	status.Errors = errs
+	return status
+}
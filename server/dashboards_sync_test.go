@@ -0,0 +1,267 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+// memDashboardsStore keeps dashboards in memory. Like the stores of the
+// server, each of its operations is atomic.
+func memDashboardsStore(dashboards map[chronograf.DashboardID]chronograf.Dashboard) *mocks.DashboardsStore {
+	var mu sync.Mutex
+	nextID := chronograf.DashboardID(len(dashboards))
+	return &mocks.DashboardsStore{
+		AllF: func(ctx context.Context) ([]chronograf.Dashboard, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			all := []chronograf.Dashboard{}
+			for _, d := range dashboards {
+				all = append(all, d)
+			}
+			return all, nil
+		},
+		AddF: func(ctx context.Context, d chronograf.Dashboard) (chronograf.Dashboard, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			nextID++
+			d.ID = nextID
+			dashboards[d.ID] = d
+			return d, nil
+		},
+		GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			d, ok := dashboards[id]
+			if !ok {
+				return d, chronograf.ErrDashboardNotFound
+			}
+			return d, nil
+		},
+		UpdateF: func(ctx context.Context, d chronograf.Dashboard) error {
+			mu.Lock()
+			defer mu.Unlock()
+			dashboards[d.ID] = d
+			return nil
+		},
+		DeleteF: func(ctx context.Context, d chronograf.Dashboard) error {
+			mu.Lock()
+			defer mu.Unlock()
+			delete(dashboards, d.ID)
+			return nil
+		},
+	}
+}
+
+func TestDashboardSyncer_Sync(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		file := path.Join(dir, name)
+		if err := os.MkdirAll(path.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("system.yaml", "name: System\ncells:\n  - name: CPU\n    w: 4\n    h: 4\n")
+	write("2/cpu.yaml", "name: CPU\n")
+
+	dashboards := map[chronograf.DashboardID]chronograf.Dashboard{
+		1: {ID: 1, Name: "Unmanaged", Organization: "default"},
+	}
+	store := &mocks.Store{
+		DashboardsStore: memDashboardsStore(dashboards),
+		OrganizationsStore: &mocks.OrganizationsStore{
+			DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+				return &chronograf.Organization{ID: "default"}, nil
+			},
+			GetF: func(ctx context.Context, q chronograf.OrganizationQuery) (*chronograf.Organization, error) {
+				if *q.ID != "default" && *q.ID != "2" {
+					return nil, chronograf.ErrOrganizationNotFound
+				}
+				return &chronograf.Organization{ID: *q.ID}, nil
+			},
+		},
+	}
+	syncer := &DashboardSyncer{
+		Dir:    dir,
+		Mode:   DashboardSyncDrift,
+		Prune:  true,
+		Store:  store,
+		Logger: &mocks.TestLogger{},
+	}
+	ctx := context.Background()
+
+	states := func(status DashboardSyncStatus) string {
+		s := []string{}
+		for _, res := range status.Results {
+			s = append(s, res.Organization+"/"+res.Slug+":"+res.State)
+		}
+		return strings.Join(s, " ")
+	}
+	bySlug := func(slug string) chronograf.Dashboard {
+		for _, d := range dashboards {
+			if d.Managed != nil && d.Managed.Slug == slug {
+				return d
+			}
+		}
+		t.Fatalf("no dashboard with slug %s", slug)
+		return chronograf.Dashboard{}
+	}
+
+	if got, want := states(syncer.Sync(ctx)), "2/cpu:created default/system:created"; got != want {
+		t.Fatalf("first sync = %s, want %s", got, want)
+	}
+	system := bySlug("system")
+	if system.Organization != "default" || len(system.Cells) != 1 || system.Managed.File != "system.yaml" {
+		t.Errorf("synced dashboard = %+v", system)
+	}
+	if got, want := states(syncer.Sync(ctx)), "2/cpu:unchanged default/system:unchanged"; got != want {
+		t.Errorf("unchanged sync = %s, want %s", got, want)
+	}
+
+	// changes made outside of the definition are kept as drift
+	system.Name = "Edited"
+	dashboards[system.ID] = system
+	if got, want := states(syncer.Sync(ctx)), "2/cpu:unchanged default/system:drifted"; got != want {
+		t.Errorf("drifted sync = %s, want %s", got, want)
+	}
+	if bySlug("system").Name != "Edited" {
+		t.Errorf("drift was overwritten")
+	}
+
+	// a changed definition replaces the drifted dashboard
+	write("system.yaml", "name: System v2\n")
+	if got, want := states(syncer.Sync(ctx)), "2/cpu:unchanged default/system:updated"; got != want {
+		t.Errorf("updated sync = %s, want %s", got, want)
+	}
+	if d := bySlug("system"); d.Name != "System v2" || d.ID != system.ID {
+		t.Errorf("updated dashboard = %+v", d)
+	}
+
+	// definitions that cannot be loaded prevent pruning
+	if err := os.Remove(path.Join(dir, "2/cpu.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	write("broken.yaml", "name: [")
+	if status := syncer.Sync(ctx); len(status.Errors) != 1 || states(status) != "default/system:unchanged" {
+		t.Errorf("sync with broken definition = %s, errors %v", states(status), status.Errors)
+	}
+	if err := os.Remove(path.Join(dir, "broken.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := states(syncer.Sync(ctx)), "2/cpu:pruned default/system:unchanged"; got != want {
+		t.Errorf("pruning sync = %s, want %s", got, want)
+	}
+	if len(dashboards) != 2 {
+		t.Errorf("dashboards after pruning = %+v", dashboards)
+	}
+}
+
+func TestDashboardSyncer_SyncConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(path.Join(dir, "system.yaml"), []byte("name: System\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dashboards := map[chronograf.DashboardID]chronograf.Dashboard{}
+	syncer := &DashboardSyncer{
+		Dir:  dir,
+		Mode: DashboardSyncDrift,
+		Store: &mocks.Store{
+			DashboardsStore: memDashboardsStore(dashboards),
+			OrganizationsStore: &mocks.OrganizationsStore{
+				DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+					return &chronograf.Organization{ID: "default"}, nil
+				},
+				GetF: func(ctx context.Context, q chronograf.OrganizationQuery) (*chronograf.Organization, error) {
+					return &chronograf.Organization{ID: *q.ID}, nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	const syncs = 8
+	statuses := make([]DashboardSyncStatus, syncs)
+	var wg sync.WaitGroup
+	for i := 0; i < syncs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i] = syncer.Sync(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	if len(dashboards) != 1 {
+		t.Errorf("dashboards after concurrent syncs = %+v, want a single dashboard", dashboards)
+	}
+	created := 0
+	latest := statuses[0]
+	for _, status := range statuses {
+		if len(status.Results) == 1 && status.Results[0].State == DashboardSyncCreated {
+			created++
+		}
+		if status.LastSync.After(latest.LastSync) {
+			latest = status
+		}
+	}
+	if created != 1 {
+		t.Errorf("concurrent syncs created the dashboard %d times, want once", created)
+	}
+	if got := syncer.Status().LastSync; !got.Equal(latest.LastSync) {
+		t.Errorf("Status().LastSync = %v, want the last sync %v", got, latest.LastSync)
+	}
+}
+
+func TestService_refuseManagedEdit(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Name:         "System",
+		Organization: "default",
+		Managed:      &chronograf.DashboardSync{Slug: "system", File: "system.yaml"},
+	}
+	tests := []struct {
+		name string
+		mode string
+		want int
+	}{
+		{name: "refused", mode: DashboardSyncRefuse, want: http.StatusConflict},
+		{name: "drift", mode: DashboardSyncDrift, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					DashboardsStore: memDashboardsStore(map[chronograf.DashboardID]chronograf.Dashboard{1: dashboard}),
+					OrganizationsStore: &mocks.OrganizationsStore{
+						DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+							return &chronograf.Organization{ID: "default"}, nil
+						},
+					},
+				},
+				Logger:        &mocks.TestLogger{},
+				DashboardSync: &DashboardSyncer{Mode: tt.mode},
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PUT", "http://any.url", strings.NewReader(`{"name":"Edited","cells":[]}`))
+			ctx := context.WithValue(context.Background(), roles.ContextKey, roles.EditorRoleName)
+			s.ReplaceDashboard(w, r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}})))
+			if got := w.Result().StatusCode; got != tt.want {
+				t.Errorf("ReplaceDashboard() status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -411,9 +411,10 @@ func Test_newDashboardResponse(t *testing.T) {
 					},
 				},
 				Links: dashboardLinks{
-					Self:      "/chronograf/v1/dashboards/0",
-					Cells:     "/chronograf/v1/dashboards/0/cells",
-					Templates: "/chronograf/v1/dashboards/0/templates",
+					Self:        "/chronograf/v1/dashboards/0",
+					Cells:       "/chronograf/v1/dashboards/0/cells",
+					Templates:   "/chronograf/v1/dashboards/0/templates",
+					Permissions: "/chronograf/v1/dashboards/0/permissions",
 				},
 			},
 		},
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+var v3FieldTypes = []string{"utf8", "int64", "uint64", "float64", "bool"}
+
+type databaseCachesResponse struct {
+	LastValueCaches     []influx.LastValueCache     `json:"lastValueCaches"`
+	DistinctValueCaches []influx.DistinctValueCache `json:"distinctValueCaches"`
+}
+
+// NewTable creates a table in a database of an InfluxDB 3 source
+func (s *Service) NewTable(w http.ResponseWriter, r *http.Request) {
+	var req influx.Table
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidTableRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	t, err := cli.CreateTable(ctx, httprouter.GetParamFromContext(ctx, "db"), &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusCreated, t, s.Logger)
+}
+
+// DropTable deletes a table of a database of an InfluxDB 3 Core or Enterprise
+// source
+func (s *Service) DropTable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	db := httprouter.GetParamFromContext(ctx, "db")
+	table := httprouter.GetParamFromContext(ctx, "table")
+	if err := cli.DropTable(ctx, db, table); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DatabaseCaches lists the last value and distinct value caches of a
+// database of an InfluxDB 3 Core or Enterprise source
+func (s *Service) DatabaseCaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	db := httprouter.GetParamFromContext(ctx, "db")
+	lvcs, err := cli.LastValueCaches(ctx, db)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	dvcs, err := cli.DistinctValueCaches(ctx, db)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, databaseCachesResponse{
+		LastValueCaches:     lvcs,
+		DistinctValueCaches: dvcs,
+	}, s.Logger)
+}
+
+// NewLastValueCache creates a last value cache of a table of an InfluxDB 3
+// Core or Enterprise source
+func (s *Service) NewLastValueCache(w http.ResponseWriter, r *http.Request) {
+	var req influx.LastValueCache
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if req.Count < 0 || req.TTL < 0 {
+		invalidData(w, fmt.Errorf("count and ttl must not be negative"), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	req.Table = httprouter.GetParamFromContext(ctx, "table")
+	lvc, err := cli.CreateLastValueCache(ctx, httprouter.GetParamFromContext(ctx, "db"), &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusCreated, lvc, s.Logger)
+}
+
+// DropLastValueCache deletes a last value cache of a table of an InfluxDB 3
+// Core or Enterprise source
+func (s *Service) DropLastValueCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	db := httprouter.GetParamFromContext(ctx, "db")
+	table := httprouter.GetParamFromContext(ctx, "table")
+	name := httprouter.GetParamFromContext(ctx, "name")
+	if err := cli.DropLastValueCache(ctx, db, table, name); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NewDistinctValueCache creates a distinct value cache of columns of a table
+// of an InfluxDB 3 Core or Enterprise source
+func (s *Service) NewDistinctValueCache(w http.ResponseWriter, r *http.Request) {
+	var req influx.DistinctValueCache
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if len(req.Columns) == 0 {
+		invalidData(w, fmt.Errorf("at least one column is required"), s.Logger)
+		return
+	}
+	if req.MaxCardinality < 0 || req.MaxAge < 0 {
+		invalidData(w, fmt.Errorf("maxCardinality and maxAge must not be negative"), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	req.Table = httprouter.GetParamFromContext(ctx, "table")
+	dvc, err := cli.CreateDistinctValueCache(ctx, httprouter.GetParamFromContext(ctx, "db"), &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusCreated, dvc, s.Logger)
+}
+
+// DropDistinctValueCache deletes a distinct value cache of a table of an
+// InfluxDB 3 Core or Enterprise source
+func (s *Service) DropDistinctValueCache(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV3Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	db := httprouter.GetParamFromContext(ctx, "db")
+	table := httprouter.GetParamFromContext(ctx, "table")
+	name := httprouter.GetParamFromContext(ctx, "name")
+	if err := cli.DropDistinctValueCache(ctx, db, table, name); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidTableRequest checks that a table has a name and that its fields have
+// InfluxDB 3 column types
+func ValidTableRequest(t *influx.Table) error {
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	for _, f := range t.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("field name is required")
+		}
+		if !oneOf(f.Type, v3FieldTypes...) {
+			return fmt.Errorf("invalid type %q of field %s", f.Type, f.Name)
+		}
+	}
+	return nil
+}
+
+// sourceV3Client connects a client to the InfluxDB 3 source of the request.
+// It responds with an error and returns false if the source is not an
+// InfluxDB 3 source.
+func (s *Service) sourceV3Client(ctx context.Context, w http.ResponseWriter, r *http.Request) (int, *influx.Client, bool) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return 0, nil, false
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return 0, nil, false
+	}
+	if !chronograf.IsV3SrcType(src.Type) {
+		Error(w, http.StatusNotFound, fmt.Sprintf("Source %d is not an InfluxDB 3 source", srcID), s.Logger)
+		return 0, nil, false
+	}
+	cli := &influx.Client{
+		Logger:   s.Logger,
+		V3Config: s.V3Config,
+	}
+	if err := cli.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return 0, nil, false
+	}
+	return srcID, cli, true
+}
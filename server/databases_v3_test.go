@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestValidTableRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   influx.Table
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			table: influx.Table{Name: "cpu", Tags: []string{"host"}, Fields: []influx.TableField{{Name: "usage", Type: "float64"}}},
+		},
+		{
+			name:    "missing name",
+			table:   influx.Table{Tags: []string{"host"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid field type",
+			table:   influx.Table{Name: "cpu", Fields: []influx.TableField{{Name: "usage", Type: "double"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidTableRequest(&tt.table); (err != nil) != tt.wantErr {
+				t.Errorf("ValidTableRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_DatabasesV3(t *testing.T) {
+	v3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path + "?" + r.URL.Query().Get("q") {
+		case "GET /api/v3/query_sql?SELECT * FROM system.last_caches":
+			w.Write([]byte(`[{"table":"cpu","name":"cpu_last","key_column_names":["host"],"value_column_names":["usage"],"count":1,"ttl":60}]`))
+		case "GET /api/v3/query_sql?SELECT * FROM system.distinct_caches":
+			w.Write([]byte(`[]`))
+		case "POST /api/v3/configure/table?":
+			w.WriteHeader(http.StatusOK)
+		case "DELETE /api/v3/configure/last_cache?":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"cache not found"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer v3.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, URL: v3.URL, Type: chronograf.InfluxDBv3Core}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: v3.URL, Type: chronograf.InfluxDBv3CloudDedicated}, nil
+			case 3:
+				return chronograf.Source{ID: 3, URL: v3.URL, Type: chronograf.InfluxDBv1}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+
+	tests := []struct {
+		name       string
+		handler    func(*Service) http.HandlerFunc
+		method     string
+		body       string
+		params     httprouter.Params
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "caches",
+			handler:    func(s *Service) http.HandlerFunc { return s.DatabaseCaches },
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"lastValueCaches":[{"table":"cpu","name":"cpu_last","keyColumns":["host"],"valueColumns":["usage"],"count":1,"ttl":60}],"distinctValueCaches":[]}`,
+		},
+		{
+			name:       "caches of a Cloud Dedicated source",
+			handler:    func(s *Service) http.HandlerFunc { return s.DatabaseCaches },
+			params:     httprouter.Params{{Key: "id", Value: "2"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "caches of a v1 source",
+			handler:    func(s *Service) http.HandlerFunc { return s.DatabaseCaches },
+			params:     httprouter.Params{{Key: "id", Value: "3"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "create table",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewTable },
+			method:     "POST",
+			body:       `{"name":"cpu","tags":["host"],"fields":[{"name":"usage","type":"float64"}]}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"name":"cpu","tags":["host"],"fields":[{"name":"usage","type":"float64"}]}`,
+		},
+		{
+			name:       "create table without name",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewTable },
+			method:     "POST",
+			body:       `{"tags":["host"]}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "create distinct value cache without columns",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewDistinctValueCache },
+			method:     "POST",
+			body:       `{"name":"hosts"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}, {Key: "table", Value: "cpu"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "drop unknown last value cache",
+			handler:    func(s *Service) http.HandlerFunc { return s.DropLastValueCache },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}, {Key: "table", Value: "cpu"}, {Key: "name", Value: "mem_last"}},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store:  &mocks.Store{SourcesStore: sources},
+				Logger: log.New(log.DebugLevel),
+			}
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url", strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,552 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/roles"
+)
+
+// ExportRequest is a query whose results are downloaded
+type ExportRequest struct {
+	Query      string `json:"query"`
+	Type       string `json:"type,omitempty"`       // Type is the language of the query, influxql (default) or flux
+	DB         string `json:"db,omitempty"`         // DB is the database of InfluxQL queries that do not specify one
+	RP         string `json:"rp,omitempty"`         // RP is the retention policy of InfluxQL queries that do not specify one
+	Format     string `json:"format,omitempty"`     // Format is csv (default), jsonl or lp
+	TimeFormat string `json:"timeFormat,omitempty"` // TimeFormat is rfc3339 (default) or the epoch precision ns, us, ms or s
+	Timezone   string `json:"timezone,omitempty"`   // Timezone is the IANA name of the timezone of RFC3339 times, UTC by default
+	Limit      int    `json:"limit,omitempty"`      // Limit is the maximum number of exported rows; zero exports all rows
+	Filename   string `json:"filename,omitempty"`   // Filename is the name of the downloaded file
+}
+
+// exportFormats are the content types and file extensions of the formats
+var exportFormats = map[string]struct{ contentType, ext string }{
+	"csv":   {"text/csv; charset=utf-8", ".csv"},
+	"jsonl": {"application/x-ndjson", ".jsonl"},
+	"lp":    {"text/plain; charset=utf-8", ".lp"},
+}
+
+// exportEpochs are the units of epoch times
+var exportEpochs = map[string]int64{
+	"ns": 1,
+	"us": int64(time.Microsecond),
+	"ms": int64(time.Millisecond),
+	"s":  int64(time.Second),
+}
+
+// ValidExportRequest checks the export request and sets its defaults
+func ValidExportRequest(req *ExportRequest) error {
+	if strings.TrimSpace(req.Query) == "" {
+		return fmt.Errorf("query field required")
+	}
+	if req.Type == "" {
+		req.Type = "influxql"
+	}
+	if !oneOf(req.Type, "influxql", "flux") {
+		return fmt.Errorf("invalid type %q: must be influxql or flux", req.Type)
+	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	if _, ok := exportFormats[req.Format]; !ok {
+		return fmt.Errorf("invalid format %q: must be one of csv, jsonl or lp", req.Format)
+	}
+	if req.TimeFormat == "" {
+		req.TimeFormat = "rfc3339"
+	}
+	if _, ok := exportEpochs[req.TimeFormat]; !ok && req.TimeFormat != "rfc3339" {
+		return fmt.Errorf("invalid timeFormat %q: must be one of rfc3339, ns, us, ms or s", req.TimeFormat)
+	}
+	if _, err := time.LoadLocation(req.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q", req.Timezone)
+	}
+	if req.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	return nil
+}
+
+// Export executes an InfluxQL or Flux query and streams its results as CSV,
+// JSON Lines or line protocol, which can be imported into another source.
+func (s *Service) Export(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	var req ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidExportRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	if req.Type == "flux" {
+		if role, ok := hasRoleContext(ctx); ok && role == roles.ReaderRoleName {
+			if err := readerFluxQueryReadOnly(req.Query); err != nil {
+				Error(w, readerFluxErrorStatus(err), readerFluxErrorMessage(err), s.Logger)
+				return
+			}
+		}
+	} else if err := enforceReaderInfluxQLReadOnly(ctx, req.Query); err != nil {
+		if errors.Is(err, errReaderInfluxQLParse) {
+			Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+			return
+		}
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+
+	exp := newExporter(w, req)
+	if req.Type == "flux" {
+		err = s.exportFlux(ctx, src, exp)
+	} else {
+		err = s.exportInfluxQL(ctx, src, exp)
+	}
+	if err != nil && !exp.started {
+		s.influxQueryError(w, err)
+		return
+	}
+	if err != nil {
+		s.Logger.
+			WithField("component", "export").
+			WithField("source", id).
+			Error("Export of query results ended early: ", err)
+		return
+	}
+	if err := exp.flush(); err != nil {
+		s.Logger.WithField("component", "export").Error("Unable to write export: ", err)
+	}
+}
+
+// exportInfluxQL exports the series of the results of an InfluxQL query
+func (s *Service) exportInfluxQL(ctx context.Context, src chronograf.Source, exp *exporter) error {
+	ts, err := s.TimeSeries(src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to connect to source %d: %v", src.ID, err)
+	}
+	q := chronograf.Query{
+		Command: exp.req.Query,
+		DB:      exp.req.DB,
+		RP:      exp.req.RP,
+		Epoch:   "ns",
+	}
+	setupQueryFromCommand(&q)
+	response, err := ts.Query(ctx, q)
+	if err != nil {
+		return err
+	}
+	octets, err := response.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	// numbers are decoded as json.Number to keep the precision of times
+	var results []struct {
+		Series []struct {
+			Name    string            `json:"name"`
+			Tags    map[string]string `json:"tags"`
+			Columns []string          `json:"columns"`
+			Values  [][]interface{}   `json:"values"`
+		} `json:"series"`
+		Error string `json:"error"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(octets))
+	dec.UseNumber()
+	if err := dec.Decode(&results); err != nil {
+		return err
+	}
+	for _, res := range results {
+		if res.Error != "" {
+			return fmt.Errorf("%s", res.Error)
+		}
+	}
+
+	for _, res := range results {
+		for _, series := range res.Series {
+			tagKeys := make([]string, 0, len(series.Tags))
+			for k := range series.Tags {
+				tagKeys = append(tagKeys, k)
+			}
+			sort.Strings(tagKeys)
+			header := append(append([]string{"name"}, tagKeys...), series.Columns...)
+
+			for _, row := range series.Values {
+				values := make([]interface{}, 0, len(header))
+				values = append(values, series.Name)
+				for _, k := range tagKeys {
+					values = append(values, series.Tags[k])
+				}
+				p := chronograf.Point{
+					Measurement: series.Name,
+					Tags:        series.Tags,
+					Fields:      map[string]interface{}{},
+				}
+				for i, column := range series.Columns {
+					var v interface{}
+					if i < len(row) {
+						v = row[i]
+					}
+					if column == "time" {
+						if t, ok := influxQLTime(v); ok {
+							p.Time = t
+							v = exp.time(t)
+						}
+					} else if f := exportField(v); f != nil {
+						p.Fields[column] = f
+					}
+					values = append(values, v)
+				}
+				if done, err := exp.row(header, values, &p); done || err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// influxQLTime returns the time in nanoseconds of an epoch or RFC3339 time
+func influxQLTime(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		t, err := v.Int64()
+		return t, err == nil
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t.UnixNano(), err == nil
+	}
+	return 0, false
+}
+
+// exportField returns the line protocol field value of an InfluxQL value.
+// InfluxQL responses do not distinguish integers and floats, all numbers
+// are floats.
+func exportField(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		return f
+	case string, bool:
+		return v
+	}
+	return nil
+}
+
+// exportFlux exports the records of the tables of a Flux query
+func (s *Service) exportFlux(ctx context.Context, src chronograf.Source, exp *exporter) error {
+	fluxEnabled, err := hasFlux(ctx, src)
+	if err != nil {
+		return fmt.Errorf("flux service unavailable: %v", err)
+	}
+	if !fluxEnabled {
+		return fmt.Errorf("flux is not enabled for source %d", src.ID)
+	}
+	u, err := url.ParseRequestURI(src.URL)
+	if err != nil {
+		return err
+	}
+
+	limits := s.QueryLimits.Of(&src)
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
+	release, err := influx.AcquireQuerySlot(ctx, src.ID, limits.MaxConcurrent)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	client := &flux.Client{
+		URL:                u,
+		InsecureSkipVerify: src.InsecureSkipVerify,
+		Org:                src.Username, // v2 organization name is stored in username
+		Authorizer:         influx.DefaultAuthorization(&src),
+	}
+	body, err := client.Query(ctx, exp.req.Query)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return chronograf.ErrUpstreamTimeout
+		}
+		return err
+	}
+	defer body.Close()
+	var csvBody io.Reader = body
+	if limits.MaxResponseBytes > 0 {
+		// the export is only started once the response is known not to
+		// exceed the limit
+		b, err := influx.ReadResponse(body, limits.MaxResponseBytes)
+		if err != nil {
+			return err
+		}
+		csvBody = bytes.NewReader(b)
+	}
+
+	errDone := errors.New("row limit reached")
+	err = flux.ReadTables(csvBody, func(columns []flux.Column, record []string) error {
+		header := make([]string, 0, len(columns))
+		values := make([]interface{}, 0, len(columns))
+		p := chronograf.Point{
+			Tags:   map[string]string{},
+			Fields: map[string]interface{}{},
+		}
+		field := ""
+		var value interface{}
+		for i, c := range columns {
+			if c.Name == "" || c.Name == "result" || c.Name == "table" {
+				continue
+			}
+			v := fluxValue(c.DataType, record[i])
+			switch {
+			case c.Name == "_measurement":
+				p.Measurement = record[i]
+			case c.Name == "_field":
+				field = record[i]
+			case c.Name == "_value":
+				value = v
+			case c.Name == "_time" || c.Name == "_start" || c.Name == "_stop":
+			case c.Group:
+				p.Tags[c.Name] = record[i]
+			default:
+				// the non group key columns of pivoted tables are fields
+				if _, isTime := v.(time.Time); v != nil && !isTime {
+					p.Fields[c.Name] = v
+				}
+			}
+			if t, ok := v.(time.Time); ok {
+				if c.Name == "_time" {
+					p.Time = t.UnixNano()
+				}
+				v = exp.time(t.UnixNano())
+			}
+			header = append(header, c.Name)
+			values = append(values, v)
+		}
+		if field != "" && value != nil {
+			p.Fields = map[string]interface{}{field: value}
+		}
+		done, err := exp.row(header, values, &p)
+		if done && err == nil {
+			return errDone
+		}
+		return err
+	})
+	if err == errDone {
+		return nil
+	}
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return chronograf.ErrUpstreamTimeout
+	}
+	return err
+}
+
+// fluxValue converts a value of an annotated CSV column to its datatype.
+// Empty values are null.
+func fluxValue(datatype, v string) interface{} {
+	if v == "" && datatype != "string" {
+		return nil
+	}
+	var res interface{}
+	var err error
+	switch {
+	case datatype == "double":
+		res, err = strconv.ParseFloat(v, 64)
+	case datatype == "long":
+		res, err = strconv.ParseInt(v, 10, 64)
+	case datatype == "unsignedLong":
+		res, err = strconv.ParseUint(v, 10, 64)
+	case datatype == "boolean":
+		res, err = strconv.ParseBool(v)
+	case strings.HasPrefix(datatype, "dateTime"):
+		res, err = time.Parse(time.RFC3339Nano, v)
+	default:
+		return v
+	}
+	if err != nil {
+		return v
+	}
+	return res
+}
+
+// exporter writes the rows of query results in the format of the request
+type exporter struct {
+	w        http.ResponseWriter
+	req      ExportRequest
+	loc      *time.Location
+	rows     int
+	started  bool
+	csv      *csv.Writer
+	header   []string
+	enc      *json.Encoder
+	lpWriter io.Writer
+}
+
+func newExporter(w http.ResponseWriter, req ExportRequest) *exporter {
+	loc, _ := time.LoadLocation(req.Timezone)
+	return &exporter{w: w, req: req, loc: loc}
+}
+
+// start writes the headers of the response
+func (e *exporter) start() {
+	e.started = true
+	format := exportFormats[e.req.Format]
+	e.w.Header().Set("Content-Type", format.contentType)
+	e.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": exportFilename(e.req.Filename, format.ext),
+	}))
+	e.w.WriteHeader(http.StatusOK)
+	switch e.req.Format {
+	case "csv":
+		e.csv = csv.NewWriter(e.w)
+	case "jsonl":
+		e.enc = json.NewEncoder(e.w)
+	default:
+		e.lpWriter = e.w
+	}
+}
+
+// row writes a row of values with the column names of header, or the point
+// of the row as line protocol. It returns true once the row limit is
+// reached.
+func (e *exporter) row(header []string, values []interface{}, p *chronograf.Point) (bool, error) {
+	if !e.started {
+		e.start()
+	}
+	switch e.req.Format {
+	case "csv":
+		if !equalStrings(header, e.header) {
+			e.header = header
+			if err := e.csv.Write(header); err != nil {
+				return true, err
+			}
+		}
+		record := make([]string, len(values))
+		for i, v := range values {
+			record[i] = exportString(v)
+		}
+		if err := e.csv.Write(record); err != nil {
+			return true, err
+		}
+	case "jsonl":
+		obj := make(map[string]interface{}, len(header))
+		for i, name := range header {
+			obj[name] = values[i]
+		}
+		if err := e.enc.Encode(obj); err != nil {
+			return true, err
+		}
+	default:
+		if p.Measurement == "" || len(p.Fields) == 0 {
+			// rows without a measurement or fields are not points
+			return false, nil
+		}
+		line, err := influx.FormatLineProtocol(p)
+		if err != nil {
+			return false, nil
+		}
+		if _, err := io.WriteString(e.lpWriter, line+"\n"); err != nil {
+			return true, err
+		}
+	}
+	e.rows++
+	return e.req.Limit > 0 && e.rows >= e.req.Limit, nil
+}
+
+// flush writes an empty export without rows and the buffered rows
+func (e *exporter) flush() error {
+	if !e.started {
+		e.start()
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	return nil
+}
+
+// time formats a time in nanoseconds
+func (e *exporter) time(ns int64) interface{} {
+	if unit, ok := exportEpochs[e.req.TimeFormat]; ok {
+		return ns / unit
+	}
+	return time.Unix(0, ns).In(e.loc).Format(time.RFC3339Nano)
+}
+
+// exportString formats a value of a CSV cell
+func exportString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(v)
+}
+
+// exportFilename returns the file name of a download with the extension ext
+func exportFilename(name, ext string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || r == '"' || r == '/' {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		name = "export"
+	}
+	if !strings.HasSuffix(name, ext) {
+		name += ext
+	}
+	return name
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
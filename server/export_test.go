@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestValidExportRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ExportRequest
+		wantErr bool
+	}{
+		{name: "defaults", req: ExportRequest{Query: "SELECT * FROM cpu"}},
+		{name: "flux", req: ExportRequest{Query: `from(bucket: "b")`, Type: "flux", Format: "lp", TimeFormat: "ms", Timezone: "Europe/Prague"}},
+		{name: "missing query", req: ExportRequest{}, wantErr: true},
+		{name: "invalid format", req: ExportRequest{Query: "SELECT 1", Format: "parquet"}, wantErr: true},
+		{name: "invalid time format", req: ExportRequest{Query: "SELECT 1", TimeFormat: "h"}, wantErr: true},
+		{name: "invalid timezone", req: ExportRequest{Query: "SELECT 1", Timezone: "Mars/Olympus"}, wantErr: true},
+		{name: "negative limit", req: ExportRequest{Query: "SELECT 1", Limit: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidExportRequest(&tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidExportRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (tt.req.Type == "" || tt.req.Format == "" || tt.req.TimeFormat == "") {
+				t.Errorf("ValidExportRequest() did not set the defaults: %+v", tt.req)
+			}
+		})
+	}
+}
+
+func TestExportFilename(t *testing.T) {
+	tests := []struct {
+		name, ext, want string
+	}{
+		{name: "", ext: ".csv", want: "export.csv"},
+		{name: "cpu", ext: ".csv", want: "cpu.csv"},
+		{name: "cpu.jsonl", ext: ".jsonl", want: "cpu.jsonl"},
+		{name: `../../etc/passwd`, ext: ".lp", want: "passwd.lp"},
+		{name: `C:\data\"cpu"`, ext: ".csv", want: "_cpu_.csv"},
+	}
+	for _, tt := range tests {
+		if got := exportFilename(tt.name, tt.ext); got != tt.want {
+			t.Errorf("exportFilename(%q, %q) = %q, want %q", tt.name, tt.ext, got, tt.want)
+		}
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	influxQL := &mocks.TimeSeries{
+		ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+		QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+			if q.Epoch != "ns" || q.DB != "telegraf" {
+				t.Errorf("unexpected query %+v", q)
+			}
+			if strings.Contains(q.Command, "nope") {
+				return mocks.NewResponse(`[{"statement_id":0,"error":"measurement not found"}]`, nil), nil
+			}
+			return mocks.NewResponse(`[{"statement_id":0,"series":[
+				{"name":"cpu","tags":{"host":"a"},"columns":["time","usage","state"],"values":[[1577836800000000001,1.5,"ok"],[1577836860000000000,null,"idle"]]},
+				{"name":"cpu","tags":{"host":"b b"},"columns":["time","usage","state"],"values":[[1577836800000000000,3,null]]}
+			]}]`, nil), nil
+		},
+	}
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/api/v2/query" || r.URL.Query().Get("org") != "org" {
+			t.Errorf("unexpected flux request %s", r.URL)
+		}
+		if strings.Contains(string(body), "nope") {
+			w.Write([]byte("#datatype,string,string\r\n#group,true,true\r\n#default,,\r\n,error,reference\r\n,bucket not found,\r\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("#group,false,false,true,true,false,false,true\r\n" +
+			"#datatype,string,long,string,string,dateTime:RFC3339,long,string\r\n" +
+			"#default,_result,,,,,,\r\n" +
+			",result,table,_measurement,_field,_time,_value,host\r\n" +
+			",,0,cpu,count,2020-01-01T00:00:00Z,2,a\r\n" +
+			",,0,cpu,count,2020-01-01T00:01:00Z,3,a\r\n"))
+	}))
+	defer v2.Close()
+
+	tests := []struct {
+		name            string
+		req             string
+		role            string
+		wantStatus      int
+		wantContentType string
+		wantFilename    string
+		wantBody        string
+	}{
+		{
+			name:            "influxql csv",
+			req:             `{"query":"SELECT usage, state FROM cpu GROUP BY host","db":"telegraf","filename":"cpu"}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantFilename:    "cpu.csv",
+			wantBody: "name,host,time,usage,state\n" +
+				"cpu,a,2020-01-01T00:00:00.000000001Z,1.5,ok\n" +
+				"cpu,a,2020-01-01T00:01:00Z,,idle\n" +
+				"cpu,b b,2020-01-01T00:00:00Z,3,\n",
+		},
+		{
+			name:            "influxql json lines with epoch times and a limit",
+			req:             `{"query":"SELECT usage, state FROM cpu GROUP BY host","db":"telegraf","format":"jsonl","timeFormat":"s","limit":2}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			wantFilename:    "export.jsonl",
+			wantBody: `{"host":"a","name":"cpu","state":"ok","time":1577836800,"usage":1.5}` + "\n" +
+				`{"host":"a","name":"cpu","state":"idle","time":1577836860,"usage":null}` + "\n",
+		},
+		{
+			name:            "influxql line protocol",
+			req:             `{"query":"USE telegraf; SELECT usage, state FROM cpu GROUP BY host","format":"lp"}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantFilename:    "export.lp",
+			wantBody: `cpu,host=a state="ok",usage=1.5 1577836800000000001` + "\n" +
+				`cpu,host=a state="idle" 1577836860000000000` + "\n" +
+				`cpu,host=b\ b usage=3 1577836800000000000` + "\n",
+		},
+		{
+			name:            "influxql csv in a timezone",
+			req:             `{"query":"SELECT usage, state FROM cpu GROUP BY host","db":"telegraf","timezone":"America/New_York","limit":1}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "name,host,time,usage,state\n" +
+				"cpu,a,2019-12-31T19:00:00.000000001-05:00,1.5,ok\n",
+		},
+		{
+			name:       "influxql error",
+			req:        `{"query":"SELECT * FROM nope","db":"telegraf"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "reader cannot export a write",
+			req:        `{"query":"DROP DATABASE telegraf","db":"telegraf"}`,
+			role:       roles.ReaderRoleName,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:            "flux csv",
+			req:             `{"query":"from(bucket: \"b\")","type":"flux","timeFormat":"ms"}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			wantBody: "_measurement,_field,_time,_value,host\n" +
+				"cpu,count,1577836800000,2,a\n" +
+				"cpu,count,1577836860000,3,a\n",
+		},
+		{
+			name:            "flux line protocol",
+			req:             `{"query":"from(bucket: \"b\")","type":"flux","format":"lp"}`,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+			wantBody: "cpu,host=a count=2i 1577836800000000000\n" +
+				"cpu,host=a count=3i 1577836860000000000\n",
+		},
+		{
+			name:       "flux error",
+			req:        `{"query":"from(bucket: \"nope\")","type":"flux"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{ID: 1, URL: v2.URL, Type: chronograf.InfluxDBv2, Username: "org"}, nil
+						},
+					},
+				},
+				TimeSeriesClient: influxQL,
+				Logger:           log.New(log.DebugLevel),
+			}
+			ctx := context.Background()
+			if tt.role != "" {
+				ctx = context.WithValue(ctx, roles.ContextKey, tt.role)
+			}
+			r := httptest.NewRequest("POST", "http://any.url/chronograf/v1/sources/1/export", bytes.NewBufferString(tt.req))
+			r = r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}}))
+			w := httptest.NewRecorder()
+			s.Export(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Export() status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				var e map[string]interface{}
+				if err := json.Unmarshal(body, &e); err != nil || e["message"] == "" {
+					t.Errorf("Export() error body = %s", body)
+				}
+				return
+			}
+			if got := resp.Header.Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("Export() Content-Type = %q, want %q", got, tt.wantContentType)
+			}
+			if tt.wantFilename != "" {
+				want := `attachment; filename=` + tt.wantFilename
+				if got := resp.Header.Get("Content-Disposition"); got != want {
+					t.Errorf("Export() Content-Disposition = %q, want %q", got, want)
+				}
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Export() body =\n%s\nwant\n%s", body, tt.wantBody)
+			}
+		})
+	}
+}
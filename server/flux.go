@@ -1,16 +1,22 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/influx"
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/complete"
@@ -182,6 +188,19 @@ func (s *Service) ProxyFlux(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	limits := s.QueryLimits.Of(&src)
+	if limits.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), limits.Timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+	}
+	release, err := influx.AcquireQuerySlot(r.Context(), src.ID, limits.MaxConcurrent)
+	if err != nil {
+		Error(w, http.StatusGatewayTimeout, "Timeout waiting for a free query slot of the source", s.Logger)
+		return
+	}
+	defer release()
+
 	// To preserve any HTTP query arguments to the kapacitor path,
 	// we concat and parse them into u.
 	uri := singleJoiningSlash(src.URL, path)
@@ -213,6 +232,30 @@ func (s *Service) ProxyFlux(w http.ResponseWriter, r *http.Request) {
 	proxy := &httputil.ReverseProxy{
 		Director:      director,
 		FlushInterval: time.Second,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			switch {
+			case errors.Is(err, chronograf.ErrResponseTooLarge):
+				Error(w, http.StatusRequestEntityTooLarge, "Flux response exceeds the maximum response size of the source", s.Logger)
+			case errors.Is(err, context.DeadlineExceeded) || r.Context().Err() == context.DeadlineExceeded:
+				Error(w, http.StatusGatewayTimeout, "Timeout waiting for Flux response", s.Logger)
+			default:
+				Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+			}
+		},
+	}
+	if limits.MaxResponseBytes > 0 {
+		// the response is only sent once it is known not to exceed the limit
+		proxy.ModifyResponse = func(resp *http.Response) error {
+			defer resp.Body.Close()
+			b, err := influx.ReadResponse(resp.Body, limits.MaxResponseBytes)
+			if err != nil {
+				return err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+			resp.ContentLength = int64(len(b))
+			resp.Header.Set("Content-Length", strconv.Itoa(len(b)))
+			return nil
+		}
 	}
 
 	// The connection to kapacitor is using a self-signed certificate.
@@ -60,7 +60,13 @@ func enforceReaderFluxReadOnly(r *http.Request) error {
 		return fmt.Errorf("%w: %v", errReaderFluxInvalidJSON, err)
 	}
 
-	query := strings.TrimSpace(req.Query)
+	return readerFluxQueryReadOnly(req.Query)
+}
+
+// readerFluxQueryReadOnly checks that the Flux query of a Reader has no
+// write-capable calls
+func readerFluxQueryReadOnly(query string) error {
+	query = strings.TrimSpace(query)
 	if query == "" {
 		return errReaderFluxQueryRequired
 	}
@@ -78,6 +84,14 @@ func enforceReaderFluxReadOnly(r *http.Request) error {
 	return nil
 }
 
+// isReaderFluxError reports whether err denies a Reader to execute a Flux
+// query, as returned by readerFluxQueryReadOnly
+func isReaderFluxError(err error) bool {
+	return errors.Is(err, errReaderFluxQueryRequired) ||
+		errors.Is(err, errReaderFluxParse) ||
+		errors.Is(err, errReaderFluxWriteForbidden)
+}
+
 func readerFluxErrorStatus(err error) int {
 	switch {
 	case errors.Is(err, errReaderBodyTooLarge):
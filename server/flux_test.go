@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_ProxyFluxLimits(t *testing.T) {
+	csv := "#datatype,string,long,double\r\n,result,table,_value\r\n,_result,0,1\r\n,_result,0,2\r\n\r\n"
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "slow") {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte(csv))
+	}))
+	defer v2.Close()
+
+	tests := []struct {
+		name       string
+		src        chronograf.Source
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "within limits",
+			src:        chronograf.Source{MaxResponseBytes: 1024},
+			query:      `from(bucket: "b")`,
+			wantStatus: http.StatusOK,
+			wantBody:   csv,
+		},
+		{
+			name:       "response too large",
+			query:      `from(bucket: "b")`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "timeout",
+			src:        chronograf.Source{QueryTimeout: 1, MaxResponseBytes: 1024},
+			query:      `from(bucket: "slow")`,
+			wantStatus: http.StatusGatewayTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := tt.src
+			src.ID = 1
+			src.URL = v2.URL
+			src.Type = chronograf.InfluxDBv2
+			src.Username = "org"
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return src, nil
+						},
+					},
+				},
+				QueryLimits: influx.QueryLimits{MaxResponseBytes: 16},
+				Logger:      log.New(log.DebugLevel),
+			}
+			r := httptest.NewRequest("POST", "http://any.url?path=/api/v2/query", strings.NewReader(tt.query))
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+			w := httptest.NewRecorder()
+			s.ProxyFlux(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" && string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
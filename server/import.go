@@ -0,0 +1,504 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+const (
+	// defaultImportBatchSize is the default number of points written at once
+	defaultImportBatchSize = 5000
+	// maxImportErrors is the maximum number of rejected lines reported
+	maxImportErrors = 100
+)
+
+// importError is the error of a rejected line, CSV row or JSON point
+type importError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type importResponse struct {
+	Accepted int           `json:"accepted"`        // Accepted is the number of points written
+	Rejected int           `json:"rejected"`        // Rejected is the number of invalid lines, rows or points
+	Errors   []importError `json:"errors"`          // Errors are the errors of the first rejected lines
+	Error    string        `json:"error,omitempty"` // Error ended the import before all points were written
+}
+
+// importOptions are the query parameters of an import
+type importOptions struct {
+	Format      string        // Format is lp, csv or json
+	DB          string        // DB is the database of the points, unless set by JSON points
+	RP          string        // RP is the retention policy of the points, unless set by JSON points
+	Precision   time.Duration // Precision is the unit of the numeric timestamps
+	BatchSize   int           // BatchSize is the number of points written at once
+	Measurement string        // Measurement of the CSV rows without a measurement column
+	Tags        []string      // Tags are the CSV columns imported as tags
+	Fields      []string      // Fields are the CSV columns imported as fields
+	Time        string        // Time is the CSV column of the timestamps
+	Ignore      []string      // Ignore are the CSV columns that are not imported
+}
+
+// importOptionsOf parses the query parameters of an import request
+func importOptionsOf(r *http.Request) (importOptions, error) {
+	query := r.URL.Query()
+	opts := importOptions{
+		Format:      query.Get("format"),
+		DB:          query.Get("db"),
+		RP:          query.Get("rp"),
+		Precision:   time.Nanosecond,
+		BatchSize:   defaultImportBatchSize,
+		Measurement: query.Get("measurement"),
+		Tags:        query["tag"],
+		Fields:      query["field"],
+		Time:        query.Get("time"),
+		Ignore:      query["ignore"],
+	}
+	if opts.Format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv", "application/csv":
+			opts.Format = "csv"
+		case "application/json":
+			opts.Format = "json"
+		default:
+			opts.Format = "lp"
+		}
+	}
+	if !oneOf(opts.Format, "lp", "csv", "json") {
+		return opts, fmt.Errorf("invalid format %q: must be one of lp, csv or json", opts.Format)
+	}
+	if opts.DB == "" && opts.Format != "json" {
+		return opts, errors.New("db query parameter required")
+	}
+	if p := query.Get("precision"); p != "" {
+		var ok bool
+		if opts.Precision, ok = importPrecisions[p]; !ok {
+			return opts, fmt.Errorf("invalid precision %q: must be one of ns, us, ms or s", p)
+		}
+	}
+	if b := query.Get("batchSize"); b != "" {
+		n, err := strconv.Atoi(b)
+		if err != nil || n < 1 {
+			return opts, fmt.Errorf("invalid batchSize %q", b)
+		}
+		opts.BatchSize = n
+	}
+	return opts, nil
+}
+
+// importPrecisions are the units of the timestamps of imported points
+var importPrecisions = map[string]time.Duration{
+	"n":  time.Nanosecond,
+	"ns": time.Nanosecond,
+	"u":  time.Microsecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// Import validates points given as line protocol, CSV or a JSON array of
+// points and writes the valid ones to the source in batches. Invalid lines
+// are reported and skipped.
+func (s *Service) Import(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	opts, err := importOptionsOf(r)
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	ts, err := s.TimeSeries(src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+
+	imp := &importer{
+		ctx:  ctx,
+		ts:   ts,
+		opts: opts,
+		res:  importResponse{Errors: []importError{}},
+	}
+	switch opts.Format {
+	case "lp":
+		err = imp.lineProtocol(r.Body)
+	case "csv":
+		err = imp.csv(r.Body)
+	case "json":
+		err = imp.json(r.Body)
+	}
+	if err == nil {
+		err = imp.flush()
+	}
+	if err != nil {
+		imp.res.Error = err.Error()
+		encodeJSON(w, http.StatusBadGateway, imp.res, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, imp.res, s.Logger)
+}
+
+// importer validates imported points and writes them in batches
+type importer struct {
+	ctx   context.Context
+	ts    chronograf.TimeSeries
+	opts  importOptions
+	batch []chronograf.Point
+	res   importResponse
+}
+
+// add validates the point of a line and writes the batch once it is full
+func (imp *importer) add(line int, p chronograf.Point, err error) error {
+	if err == nil {
+		err = validImportPoint(&p)
+	}
+	if err != nil {
+		imp.reject(line, err)
+		return nil
+	}
+	if p.Database == "" {
+		p.Database, p.RetentionPolicy = imp.opts.DB, imp.opts.RP
+	}
+	imp.batch = append(imp.batch, p)
+	if len(imp.batch) >= imp.opts.BatchSize {
+		return imp.flush()
+	}
+	return nil
+}
+
+func (imp *importer) reject(line int, err error) {
+	imp.res.Rejected++
+	if len(imp.res.Errors) < maxImportErrors {
+		imp.res.Errors = append(imp.res.Errors, importError{Line: line, Error: err.Error()})
+	}
+}
+
+// flush writes the batch of points
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	if err := imp.ts.Write(imp.ctx, imp.batch); err != nil {
+		return fmt.Errorf("unable to write points: %v", err)
+	}
+	imp.res.Accepted += len(imp.batch)
+	imp.batch = nil
+	return nil
+}
+
+// lineProtocol imports a line of line protocol per line. Empty lines and
+// comments are skipped.
+func (imp *importer) lineProtocol(body io.Reader) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		p, err := influx.ParseLineProtocol(text, imp.opts.Precision)
+		if err := imp.add(line, p, err); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read line protocol: %v", err)
+	}
+	return nil
+}
+
+// json imports a JSON array of points
+func (imp *importer) json(body io.Reader) error {
+	dec := json.NewDecoder(body)
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil || t != json.Delim('[') {
+		imp.reject(1, errors.New("expected a JSON array of points"))
+		return nil
+	}
+	for i := 1; dec.More(); i++ {
+		var p chronograf.Point
+		if err := dec.Decode(&p); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				// the rest of the array cannot be read
+				imp.reject(i, fmt.Errorf("invalid JSON: %v", err))
+				return nil
+			}
+			imp.reject(i, fmt.Errorf("invalid point: %v", err))
+			continue
+		}
+		err := jsonPointFields(&p)
+		if err == nil && p.Time != 0 {
+			p.Time, err = scaleImportTime(p.Time, imp.opts.Precision)
+		}
+		if err := imp.add(i, p, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPointFields converts the numbers of the fields of a JSON point to
+// floats, and rejects values that are not numbers, booleans or strings
+func jsonPointFields(p *chronograf.Point) error {
+	for k, v := range p.Fields {
+		switch v := v.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return fmt.Errorf("invalid value of field %q: %v", k, err)
+			}
+			p.Fields[k] = f
+		case bool, string:
+		default:
+			return fmt.Errorf("invalid value of field %q: must be a number, boolean or string", k)
+		}
+	}
+	return nil
+}
+
+// csvColumn is the role of a CSV column
+type csvColumn struct {
+	name     string
+	role     string // role is measurement, tag, field, time or ignored
+	datatype string // datatype is the annotated type of a field or time column
+}
+
+// csv imports the rows of a CSV with a header row, optionally preceded by
+// #datatype annotations as written by the influx CLI. Columns are fields
+// unless annotated or mapped to tags, the time or the measurement.
+func (imp *importer) csv(body io.Reader) error {
+	r := csv.NewReader(body)
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+	var datatypes []string
+	var columns []csvColumn
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		line, _ := r.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				imp.reject(parseErr.Line, parseErr.Err)
+				continue
+			}
+			return fmt.Errorf("unable to read CSV: %v", err)
+		}
+		if len(record) > 0 && strings.HasPrefix(record[0], "#") {
+			if columns == nil && strings.HasPrefix(record[0], "#datatype") {
+				datatypes = append([]string{strings.TrimSpace(strings.TrimPrefix(record[0], "#datatype"))}, record[1:]...)
+			}
+			continue
+		}
+		if columns == nil {
+			if columns, err = imp.csvColumns(record, datatypes); err != nil {
+				imp.reject(line, err)
+				return nil
+			}
+			continue
+		}
+		if len(record) != len(columns) {
+			imp.reject(line, fmt.Errorf("expected %d columns, got %d", len(columns), len(record)))
+			continue
+		}
+		p, err := imp.csvPoint(columns, record)
+		if err := imp.add(line, p, err); err != nil {
+			return err
+		}
+	}
+}
+
+// csvColumns returns the roles of the columns of the header
+func (imp *importer) csvColumns(header, datatypes []string) ([]csvColumn, error) {
+	roles := map[string]string{}
+	for _, c := range imp.opts.Tags {
+		roles[c] = "tag"
+	}
+	for _, c := range imp.opts.Fields {
+		roles[c] = "field"
+	}
+	for _, c := range imp.opts.Ignore {
+		roles[c] = "ignored"
+	}
+	if imp.opts.Time != "" {
+		roles[imp.opts.Time] = "time"
+	}
+
+	columns := make([]csvColumn, len(header))
+	hasMeasurement := imp.opts.Measurement != ""
+	for i, name := range header {
+		c := csvColumn{name: name, role: "field"}
+		if i < len(datatypes) {
+			c.datatype = datatypes[i]
+		}
+		switch {
+		case roles[name] != "":
+			c.role = roles[name]
+		case name == "":
+			c.role = "ignored"
+		case c.datatype == "measurement":
+			c.role = "measurement"
+		case c.datatype == "tag":
+			c.role = "tag"
+		case c.datatype == "ignored", c.datatype == "ignore":
+			c.role = "ignored"
+		case strings.HasPrefix(c.datatype, "dateTime"):
+			c.role = "time"
+		case c.datatype == "" && imp.opts.Time == "" && (name == "time" || name == "_time"):
+			c.role = "time"
+		case c.datatype == "" && name == "_measurement":
+			c.role = "measurement"
+		}
+		if c.role == "field" {
+			switch c.datatype {
+			case "", "double", "long", "unsignedLong", "boolean", "string":
+			default:
+				return nil, fmt.Errorf("unknown datatype %q of column %q", c.datatype, name)
+			}
+		}
+		if c.role == "measurement" {
+			hasMeasurement = true
+		}
+		columns[i] = c
+	}
+	if !hasMeasurement {
+		return nil, errors.New("the measurement query parameter or a measurement column is required")
+	}
+	return columns, nil
+}
+
+// csvPoint returns the point of a CSV row
+func (imp *importer) csvPoint(columns []csvColumn, record []string) (chronograf.Point, error) {
+	p := chronograf.Point{
+		Measurement: imp.opts.Measurement,
+		Tags:        map[string]string{},
+		Fields:      map[string]interface{}{},
+	}
+	for i, c := range columns {
+		v := record[i]
+		if v == "" {
+			continue
+		}
+		switch c.role {
+		case "measurement":
+			p.Measurement = v
+		case "tag":
+			p.Tags[c.name] = v
+		case "time":
+			t, err := imp.csvTime(v)
+			if err != nil {
+				return p, fmt.Errorf("invalid time %q: %v", v, err)
+			}
+			p.Time = t
+		case "field":
+			f, err := csvFieldValue(v, c.datatype)
+			if err != nil {
+				return p, fmt.Errorf("invalid value of field %q: %v", c.name, err)
+			}
+			p.Fields[c.name] = f
+		}
+	}
+	return p, nil
+}
+
+// csvTime parses a numeric timestamp in units of the precision, or an
+// RFC3339 time
+func (imp *importer) csvTime(v string) (int64, error) {
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return scaleImportTime(n, imp.opts.Precision)
+	}
+	t, err := time.Parse(time.RFC3339Nano, v)
+	if err != nil {
+		return 0, errors.New("must be a number or an RFC3339 time")
+	}
+	return t.UnixNano(), nil
+}
+
+// csvFieldValue parses the value of a field of the datatype. The type of
+// fields without a datatype is inferred: booleans, floats or strings.
+func csvFieldValue(v, datatype string) (interface{}, error) {
+	switch datatype {
+	case "double":
+		return strconv.ParseFloat(v, 64)
+	case "long":
+		return strconv.ParseInt(v, 10, 64)
+	case "unsignedLong":
+		return strconv.ParseUint(v, 10, 64)
+	case "boolean":
+		return strconv.ParseBool(v)
+	case "string":
+		return v, nil
+	}
+	if b, err := strconv.ParseBool(v); err == nil && len(v) > 1 {
+		return b, nil
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return f, nil
+	}
+	return v, nil
+}
+
+// scaleImportTime converts a timestamp in units of precision to nanoseconds
+func scaleImportTime(ts int64, precision time.Duration) (int64, error) {
+	unit := int64(precision)
+	if ts > math.MaxInt64/unit || ts < math.MinInt64/unit {
+		return 0, fmt.Errorf("timestamp %d out of range", ts)
+	}
+	return ts * unit, nil
+}
+
+// validImportPoint checks that a point can be written
+func validImportPoint(p *chronograf.Point) error {
+	if p.Measurement == "" {
+		return errors.New("missing measurement")
+	}
+	if len(p.Fields) == 0 {
+		return errors.New("at least one field is required")
+	}
+	for k := range p.Tags {
+		if k == "" || k == "time" {
+			return fmt.Errorf("invalid tag key %q", k)
+		}
+	}
+	for k, v := range p.Fields {
+		if k == "" || k == "time" {
+			return fmt.Errorf("invalid field key %q", k)
+		}
+		if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			return fmt.Errorf("invalid value of field %q", k)
+		}
+	}
+	return nil
+}
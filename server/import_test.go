@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_Import(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		contentType  string
+		body         string
+		writeErr     error
+		wantStatus   int
+		wantResponse importResponse
+		wantPoints   []chronograf.Point
+		wantBatches  int
+	}{
+		{
+			name:  "line protocol",
+			query: "db=telegraf&rp=autogen&precision=s&batchSize=2",
+			body: "# comment\n" +
+				"cpu,host=a value=1 10\n" +
+				"\n" +
+				"cpu,host=b value=2i 20\r\n" +
+				"cpu value=\n" +
+				"mem free=true 30\n",
+			wantStatus: http.StatusOK,
+			wantResponse: importResponse{
+				Accepted: 3,
+				Rejected: 1,
+				Errors:   []importError{{Line: 5}},
+			},
+			wantPoints: []chronograf.Point{
+				{Database: "telegraf", RetentionPolicy: "autogen", Measurement: "cpu", Time: 10e9, Tags: map[string]string{"host": "a"}, Fields: map[string]interface{}{"value": float64(1)}},
+				{Database: "telegraf", RetentionPolicy: "autogen", Measurement: "cpu", Time: 20e9, Tags: map[string]string{"host": "b"}, Fields: map[string]interface{}{"value": int64(2)}},
+				{Database: "telegraf", RetentionPolicy: "autogen", Measurement: "mem", Time: 30e9, Tags: map[string]string{}, Fields: map[string]interface{}{"free": true}},
+			},
+			wantBatches: 2,
+		},
+		{
+			name:  "annotated csv",
+			query: "format=csv&db=telegraf",
+			body: "#datatype measurement,tag,dateTime:RFC3339,double,long,ignored\n" +
+				"m,host,time,usage,count,note\n" +
+				"cpu,a,2020-01-01T00:00:00Z,1.5,2,x\n" +
+				"cpu,b,2020-01-01T00:00:01Z,oops,3,y\n" +
+				"cpu,c,2020-01-01T00:00:02Z,,4,z\n",
+			wantStatus: http.StatusOK,
+			wantResponse: importResponse{
+				Accepted: 2,
+				Rejected: 1,
+				Errors:   []importError{{Line: 4}},
+			},
+			wantPoints: []chronograf.Point{
+				{Database: "telegraf", Measurement: "cpu", Time: 1577836800e9, Tags: map[string]string{"host": "a"}, Fields: map[string]interface{}{"usage": 1.5, "count": int64(2)}},
+				{Database: "telegraf", Measurement: "cpu", Time: 1577836802e9, Tags: map[string]string{"host": "c"}, Fields: map[string]interface{}{"count": int64(4)}},
+			},
+			wantBatches: 1,
+		},
+		{
+			name:        "mapped csv columns",
+			query:       "db=telegraf&measurement=weather&tag=city&time=ts&ignore=id&precision=ms",
+			contentType: "text/csv; charset=utf-8",
+			body: "id,city,ts,temp,raining,station\n" +
+				"1,paris,1000,21.5,false,north\n" +
+				"2,rome,2000,25,true,south,extra\n",
+			wantStatus: http.StatusOK,
+			wantResponse: importResponse{
+				Accepted: 1,
+				Rejected: 1,
+				Errors:   []importError{{Line: 3}},
+			},
+			wantPoints: []chronograf.Point{
+				{Database: "telegraf", Measurement: "weather", Time: 1000e6, Tags: map[string]string{"city": "paris"}, Fields: map[string]interface{}{"temp": 21.5, "raining": false, "station": "north"}},
+			},
+			wantBatches: 1,
+		},
+		{
+			name:        "csv without measurement",
+			query:       "db=telegraf",
+			contentType: "text/csv",
+			body:        "a,b\n1,2\n",
+			wantStatus:  http.StatusOK,
+			wantResponse: importResponse{
+				Rejected: 1,
+				Errors:   []importError{{Line: 1}},
+			},
+		},
+		{
+			name:        "json points",
+			query:       "db=telegraf&precision=s",
+			contentType: "application/json",
+			body: `[
+				{"measurement":"cpu","tags":{"host":"a"},"fields":{"value":1,"ok":true},"time":10},
+				{"database":"other","retentionPolicy":"rp","measurement":"cpu","fields":{"value":"up"}},
+				{"measurement":"cpu","fields":{"value":{"nested":1}}},
+				{"measurement":"cpu","fields":{}},
+				{"measurement":"cpu","tags":"invalid","fields":{"value":1}}
+			]`,
+			wantStatus: http.StatusOK,
+			wantResponse: importResponse{
+				Accepted: 2,
+				Rejected: 3,
+				Errors:   []importError{{Line: 3}, {Line: 4}, {Line: 5}},
+			},
+			wantPoints: []chronograf.Point{
+				{Database: "telegraf", Measurement: "cpu", Time: 10e9, Tags: map[string]string{"host": "a"}, Fields: map[string]interface{}{"value": float64(1), "ok": true}},
+				{Database: "other", RetentionPolicy: "rp", Measurement: "cpu", Fields: map[string]interface{}{"value": "up"}},
+			},
+			wantBatches: 1,
+		},
+		{
+			name:       "write failure",
+			query:      "db=telegraf",
+			body:       "cpu value=1\n",
+			writeErr:   errors.New("database not writable"),
+			wantStatus: http.StatusBadGateway,
+			wantResponse: importResponse{
+				Errors: []importError{},
+			},
+			wantBatches: 1,
+		},
+		{
+			name:       "missing db",
+			body:       "cpu value=1\n",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid precision",
+			query:      "db=telegraf&precision=h",
+			body:       "cpu value=1\n",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var points []chronograf.Point
+			batches := 0
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{ID: 1}, nil
+						},
+					},
+				},
+				TimeSeriesClient: &mocks.TimeSeries{
+					ConnectF: func(context.Context, *chronograf.Source) error {
+						return nil
+					},
+					WriteF: func(ctx context.Context, ps []chronograf.Point) error {
+						batches++
+						if tt.writeErr != nil {
+							return tt.writeErr
+						}
+						points = append(points, ps...)
+						return nil
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+			r := httptest.NewRequest("POST", "http://any.url/chronograf/v1/sources/1/import?"+tt.query, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+			w := httptest.NewRecorder()
+			s.Import(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Import() status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusUnprocessableEntity {
+				return
+			}
+			var got importResponse
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Accepted != tt.wantResponse.Accepted || got.Rejected != tt.wantResponse.Rejected {
+				t.Errorf("Import() accepted %d, rejected %d, want %d, %d: %v", got.Accepted, got.Rejected, tt.wantResponse.Accepted, tt.wantResponse.Rejected, got.Errors)
+			}
+			if len(got.Errors) != len(tt.wantResponse.Errors) {
+				t.Fatalf("Import() errors = %v, want %v", got.Errors, tt.wantResponse.Errors)
+			}
+			for i, e := range got.Errors {
+				if e.Line != tt.wantResponse.Errors[i].Line || e.Error == "" {
+					t.Errorf("Import() error %d = %+v, want line %d", i, e, tt.wantResponse.Errors[i].Line)
+				}
+			}
+			if (tt.writeErr != nil) != (got.Error != "") {
+				t.Errorf("Import() error = %q, want write error %v", got.Error, tt.writeErr)
+			}
+			if !reflect.DeepEqual(points, tt.wantPoints) {
+				t.Errorf("Import() wrote %+v, want %+v", points, tt.wantPoints)
+			}
+			if batches != tt.wantBatches {
+				t.Errorf("Import() wrote %d batches, want %d", batches, tt.wantBatches)
+			}
+		})
+	}
+}
@@ -7,10 +7,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/http/httputil"
-	"net/url"
 	"strings"
 	"time"
 
@@ -18,7 +19,6 @@ import (
 	uuid "github.com/influxdata/chronograf/id"
 	"github.com/influxdata/chronograf/influx"
 	"github.com/influxdata/chronograf/roles"
-	"github.com/influxdata/chronograf/util"
 )
 
 // ValidInfluxRequest checks if queries specify a command.
@@ -93,18 +93,6 @@ func (s *Service) Influx(w http.ResponseWriter, r *http.Request) {
 
 	// inspect request command to specify additional request parameters
 	setupQueryFromCommand(&req)
-	response, err := ts.Query(ctx, req)
-	if err != nil {
-		if err == chronograf.ErrUpstreamTimeout {
-			msg := "Timeout waiting for Influx response"
-			Error(w, http.StatusRequestTimeout, msg, s.Logger)
-			return
-		}
-		// TODO: Here I want to return the error code from influx.
-		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
-		return
-	}
-
 	uniqueID := req.UUID
 	if uniqueID == "" {
 		newUUID, err := (&uuid.UUID{}).Generate()
@@ -115,6 +103,17 @@ func (s *Service) Influx(w http.ResponseWriter, r *http.Request) {
 		uniqueID = newUUID
 	}
 
+	if req.Chunked {
+		s.streamInflux(w, r, ts, req, uniqueID)
+		return
+	}
+
+	response, err := ts.Query(ctx, req)
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+
 	res := postInfluxResponse{
 		Results: response,
 		UUID:    uniqueID,
@@ -122,6 +121,89 @@ func (s *Service) Influx(w http.ResponseWriter, r *http.Request) {
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
 
+// influxQueryError responds with the error of a query to a source
+func (s *Service) influxQueryError(w http.ResponseWriter, err error) {
+	switch err {
+	case chronograf.ErrUpstreamTimeout:
+		msg := "Timeout waiting for Influx response"
+		Error(w, http.StatusGatewayTimeout, msg, s.Logger)
+	case chronograf.ErrResponseTooLarge:
+		msg := "Influx response exceeds the maximum response size of the source"
+		Error(w, http.StatusRequestEntityTooLarge, msg, s.Logger)
+	default:
+		// TODO: Here I want to return the error code from influx.
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+	}
+}
+
+// influxChunk is a line of a streamed query response
+type influxChunk struct {
+	Results json.RawMessage `json:"results,omitempty"` // results of the chunk from influx
+	Error   string          `json:"error,omitempty"`   // error that ended the response
+	UUID    string          `json:"uuid,omitempty"`    // uuid passed from client to identify results
+}
+
+// streamInflux responds to a chunked query with newline-delimited JSON, one
+// line per chunk of results. A chunk is only read from the source once the
+// previous one is written, and the query is cancelled when the client goes
+// away. Errors after the first chunk end the response with an error line.
+// Sources that cannot stream respond with a single line.
+func (s *Service) streamInflux(w http.ResponseWriter, r *http.Request, ts chronograf.TimeSeries, req chronograf.Query, uniqueID string) {
+	ctx := r.Context()
+	var body io.ReadCloser
+	err := influx.ErrStreamingUnsupported
+	if st, ok := ts.(influx.QueryStreamer); ok {
+		body, err = st.QueryStream(ctx, req)
+	}
+	if err == influx.ErrStreamingUnsupported {
+		var response chronograf.Response
+		if response, err = ts.Query(ctx, req); err == nil {
+			var b []byte
+			if b, err = response.MarshalJSON(); err == nil {
+				body = ioutil.NopCloser(bytes.NewReader(append(append([]byte(`{"results":`), b...), '}')))
+			}
+		}
+	}
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	dec := json.NewDecoder(body)
+	for {
+		var chunk struct {
+			Results json.RawMessage `json:"results"`
+			Error   string          `json:"error"`
+		}
+		err := dec.Decode(&chunk)
+		if err == io.EOF || ctx.Err() != nil {
+			return
+		}
+		line := influxChunk{Results: chunk.Results, Error: chunk.Error, UUID: uniqueID}
+		if err != nil {
+			line = influxChunk{Error: err.Error(), UUID: uniqueID}
+			if err == chronograf.ErrResponseTooLarge {
+				line.Error = "Influx response exceeds the maximum response size of the source"
+			}
+		}
+		if encErr := enc.Encode(line); encErr != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if err != nil || chunk.Error != "" {
+			return
+		}
+	}
+}
+
+// Write proxies line protocol writes to the write API of the source
 func (s *Service) Write(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("id", r)
 	if err != nil {
@@ -136,25 +218,11 @@ func (s *Service) Write(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	u, err := url.Parse(src.URL)
+	u, err := writeURL(&src, r.URL.Query())
 	if err != nil {
-		msg := fmt.Sprintf("Error parsing source url: %v", err)
-		Error(w, http.StatusUnprocessableEntity, msg, s.Logger)
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
 		return
 	}
-	query := r.URL.Query()
-	version := query.Get("v")
-	query.Del("v")
-	if strings.HasPrefix(version, "2") {
-		u = util.AppendPath(u, "/api/v2/write")
-		// v2 organization name is stored in username (org does not matter against v1)
-		query.Set("org", src.Username)
-		query.Set("bucket", query.Get("db"))
-		query.Del("db")
-	} else {
-		u = util.AppendPath(u, "/write")
-	}
-	u.RawQuery = query.Encode()
 
 	director := func(req *http.Request) {
 		// Set the Host header of the original source URL
@@ -169,6 +237,9 @@ func (s *Service) Write(w http.ResponseWriter, r *http.Request) {
 	proxy := &httputil.ReverseProxy{
 		Director: director,
 	}
+	if src.Type == chronograf.InfluxDBv3Core || src.Type == chronograf.InfluxDBv3Enterprise {
+		proxy.ModifyResponse = rewriteV3WriteError
+	}
 
 	// The connection to influxdb is using a self-signed certificate.
 	// This modifies uses the same values as http.DefaultTransport but specifies
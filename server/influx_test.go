@@ -465,3 +465,92 @@ func TestService_Influx_Write(t *testing.T) {
 		}
 	}
 }
+
+func TestService_Influx_Chunked(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("chunked") != "true" {
+			t.Errorf("query is not chunked: %v", r.URL.Query())
+		}
+		w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[0,1]]}],"partial":true}]}` + "\n"))
+		if r.URL.Query().Get("db") == "broken" {
+			w.Write([]byte(`{"error":"shard is unavailable"}` + "\n"))
+			return
+		}
+		w.Write([]byte(`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[1,2]]}]}]}` + "\n"))
+	}))
+	defer upstream.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			return chronograf.Source{ID: ID, URL: upstream.URL}, nil
+		},
+	}
+	buffered := &mocks.TimeSeries{
+		ConnectF: func(ctx context.Context, src *chronograf.Source) error {
+			return nil
+		},
+		QueryF: func(ctx context.Context, query chronograf.Query) (chronograf.Response, error) {
+			return mocks.NewResponse(`[{"statement_id":0}]`, nil), nil
+		},
+	}
+
+	tests := []struct {
+		name       string
+		timeSeries TimeSeriesClient
+		db         string
+		want       []string
+	}{
+		{
+			name:       "streamed chunks",
+			timeSeries: &InfluxClient{},
+			db:         "telegraf",
+			want: []string{
+				`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[0,1]]}],"partial":true}],"uuid":"tst"}`,
+				`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[1,2]]}]}],"uuid":"tst"}`,
+			},
+		},
+		{
+			name:       "error after the first chunk",
+			timeSeries: &InfluxClient{},
+			db:         "broken",
+			want: []string{
+				`{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[[0,1]]}],"partial":true}],"uuid":"tst"}`,
+				`{"error":"shard is unavailable","uuid":"tst"}`,
+			},
+		},
+		{
+			name:       "source that cannot stream",
+			timeSeries: buffered,
+			db:         "telegraf",
+			want:       []string{`{"results":[{"statement_id":0}],"uuid":"tst"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Service{
+				Store:            &mocks.Store{SourcesStore: sources},
+				TimeSeriesClient: tt.timeSeries,
+				Logger:           log.New(log.ErrorLevel),
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(
+				`{"uuid":"tst","query":"SELECT value FROM cpu","db":"`+tt.db+`","chunked":true}`,
+			))
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+			h.Influx(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d: %s", resp.StatusCode, body)
+			}
+			if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+				t.Errorf("Content-Type = %s", ct)
+			}
+			got := strings.Split(strings.TrimSpace(string(body)), "\n")
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Influx() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
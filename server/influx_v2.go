@@ -0,0 +1,740 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/influx"
+)
+
+type bucketLinks struct {
+	Self  string `json:"self"`  // Self link mapping to this resource
+	DBRPs string `json:"dbrps"` // DBRPs link to the DBRP mappings of the bucket
+}
+
+type bucketResponse struct {
+	influx.Bucket
+	Links bucketLinks `json:"links"`
+}
+
+func newBucketResponse(srcID int, b influx.Bucket) bucketResponse {
+	base := fmt.Sprintf("/chronograf/v1/sources/%d/v2", srcID)
+	if b.RetentionRules == nil {
+		b.RetentionRules = []influx.RetentionRule{}
+	}
+	return bucketResponse{
+		Bucket: b,
+		Links: bucketLinks{
+			Self:  fmt.Sprintf("%s/buckets/%s", base, b.ID),
+			DBRPs: fmt.Sprintf("%s/dbrps?bucketID=%s", base, b.ID),
+		},
+	}
+}
+
+type bucketsResponse struct {
+	Buckets []bucketResponse `json:"buckets"`
+}
+
+type v2SelfLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type dbrpResponse struct {
+	influx.DBRPMapping
+	Links v2SelfLinks `json:"links"`
+}
+
+func newDBRPResponse(srcID int, m influx.DBRPMapping) dbrpResponse {
+	return dbrpResponse{
+		DBRPMapping: m,
+		Links: v2SelfLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/v2/dbrps/%s", srcID, m.ID),
+		},
+	}
+}
+
+type dbrpsResponse struct {
+	DBRPs []dbrpResponse `json:"dbrps"`
+}
+
+type authorizationResponse struct {
+	influx.Authorization
+	Links v2SelfLinks `json:"links"`
+}
+
+func newAuthorizationResponse(srcID int, a influx.Authorization) authorizationResponse {
+	if a.Permissions == nil {
+		a.Permissions = []influx.Permission{}
+	}
+	return authorizationResponse{
+		Authorization: a,
+		Links: v2SelfLinks{
+			Self: fmt.Sprintf("/chronograf/v1/sources/%d/v2/authorizations/%s", srcID, a.ID),
+		},
+	}
+}
+
+type authorizationsResponse struct {
+	Authorizations []authorizationResponse `json:"authorizations"`
+}
+
+type taskLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+	Runs string `json:"runs"` // Runs link to the run history of the task
+}
+
+type taskResponse struct {
+	influx.Task
+	Links taskLinks `json:"links"`
+}
+
+func newTaskResponse(srcID int, t influx.Task) taskResponse {
+	self := fmt.Sprintf("/chronograf/v1/sources/%d/v2/tasks/%s", srcID, t.ID)
+	return taskResponse{
+		Task: t,
+		Links: taskLinks{
+			Self: self,
+			Runs: self + "/runs",
+		},
+	}
+}
+
+type tasksResponse struct {
+	Tasks []taskResponse `json:"tasks"`
+}
+
+type taskRunLinks struct {
+	Logs string `json:"logs"` // Logs link to the log events of the run
+}
+
+type taskRunResponse struct {
+	influx.TaskRun
+	Links taskRunLinks `json:"links"`
+}
+
+type taskRunsResponse struct {
+	Runs []taskRunResponse `json:"runs"`
+}
+
+type taskRunLogsResponse struct {
+	Events []influx.TaskRunLog `json:"events"`
+}
+
+// Buckets lists the buckets of an InfluxDB v2 source
+func (s *Service) Buckets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	buckets, err := cli.Buckets(ctx)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := bucketsResponse{
+		Buckets: make([]bucketResponse, len(buckets)),
+	}
+	for i, b := range buckets {
+		res.Buckets[i] = newBucketResponse(srcID, b)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// NewBucket creates a bucket in an InfluxDB v2 source
+func (s *Service) NewBucket(w http.ResponseWriter, r *http.Request) {
+	var req influx.Bucket
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidBucketRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	b, err := cli.CreateBucket(ctx, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := newBucketResponse(srcID, *b)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateBucket changes the name, description and retention rules of a bucket
+// of an InfluxDB v2 source
+func (s *Service) UpdateBucket(w http.ResponseWriter, r *http.Request) {
+	var req influx.BucketUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := validRetentionRules(req.RetentionRules); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	bid, ok := v2IDParam(ctx, w, "bid", s.Logger)
+	if !ok {
+		return
+	}
+	b, err := cli.UpdateBucket(ctx, bid, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newBucketResponse(srcID, *b), s.Logger)
+}
+
+// RemoveBucket deletes a bucket and its data from an InfluxDB v2 source
+func (s *Service) RemoveBucket(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	bid, ok := v2IDParam(ctx, w, "bid", s.Logger)
+	if !ok {
+		return
+	}
+	if err := cli.DeleteBucket(ctx, bid); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DBRPs lists the DBRP mappings of an InfluxDB v2 source, optionally only
+// those of the bucket given by the bucketID query parameter
+func (s *Service) DBRPs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	mappings, err := cli.DBRPs(ctx, r.URL.Query().Get("bucketID"))
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := dbrpsResponse{
+		DBRPs: make([]dbrpResponse, len(mappings)),
+	}
+	for i, m := range mappings {
+		res.DBRPs[i] = newDBRPResponse(srcID, m)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// NewDBRP maps a database and retention policy to a bucket of an InfluxDB
+// v2 source
+func (s *Service) NewDBRP(w http.ResponseWriter, r *http.Request) {
+	var req influx.DBRPMapping
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidDBRPRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	m, err := cli.CreateDBRP(ctx, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := newDBRPResponse(srcID, *m)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateDBRP changes the retention policy and default flag of a DBRP mapping
+// of an InfluxDB v2 source
+func (s *Service) UpdateDBRP(w http.ResponseWriter, r *http.Request) {
+	var req influx.DBRPMapping
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	did, ok := v2IDParam(ctx, w, "did", s.Logger)
+	if !ok {
+		return
+	}
+	m, err := cli.UpdateDBRP(ctx, did, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newDBRPResponse(srcID, *m), s.Logger)
+}
+
+// RemoveDBRP deletes a DBRP mapping of an InfluxDB v2 source
+func (s *Service) RemoveDBRP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	did, ok := v2IDParam(ctx, w, "did", s.Logger)
+	if !ok {
+		return
+	}
+	if err := cli.DeleteDBRP(ctx, did); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Authorizations lists the API tokens of an InfluxDB v2 source. The token
+// values are only returned when they are created.
+func (s *Service) Authorizations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	auths, err := cli.Authorizations(ctx)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := authorizationsResponse{
+		Authorizations: make([]authorizationResponse, len(auths)),
+	}
+	for i, a := range auths {
+		a.Token = ""
+		res.Authorizations[i] = newAuthorizationResponse(srcID, a)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// NewAuthorization creates an API token with the requested permissions in an
+// InfluxDB v2 source
+func (s *Service) NewAuthorization(w http.ResponseWriter, r *http.Request) {
+	var req influx.Authorization
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidAuthorizationRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	a, err := cli.CreateAuthorization(ctx, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := newAuthorizationResponse(srcID, *a)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateAuthorization activates, deactivates or describes an API token of an
+// InfluxDB v2 source
+func (s *Service) UpdateAuthorization(w http.ResponseWriter, r *http.Request) {
+	var req influx.AuthorizationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if req.Status != "" && !oneOf(req.Status, "active", "inactive") {
+		invalidData(w, fmt.Errorf("status must be active or inactive"), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	aid, ok := v2IDParam(ctx, w, "aid", s.Logger)
+	if !ok {
+		return
+	}
+	a, err := cli.UpdateAuthorization(ctx, aid, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	a.Token = ""
+	encodeJSON(w, http.StatusOK, newAuthorizationResponse(srcID, *a), s.Logger)
+}
+
+// RemoveAuthorization deletes an API token of an InfluxDB v2 source
+func (s *Service) RemoveAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	aid, ok := v2IDParam(ctx, w, "aid", s.Logger)
+	if !ok {
+		return
+	}
+	if err := cli.DeleteAuthorization(ctx, aid); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Tasks lists the tasks of an InfluxDB v2 source
+func (s *Service) Tasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	tasks, err := cli.Tasks(ctx)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := tasksResponse{
+		Tasks: make([]taskResponse, len(tasks)),
+	}
+	for i, t := range tasks {
+		res.Tasks[i] = newTaskResponse(srcID, t)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// TaskID returns a task of an InfluxDB v2 source
+func (s *Service) TaskID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	tid, ok := v2IDParam(ctx, w, "tid", s.Logger)
+	if !ok {
+		return
+	}
+	t, err := cli.Task(ctx, tid)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newTaskResponse(srcID, *t), s.Logger)
+}
+
+// NewTask creates a task from a Flux script in an InfluxDB v2 source
+func (s *Service) NewTask(w http.ResponseWriter, r *http.Request) {
+	var req influx.Task
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidTaskRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	t, err := cli.CreateTask(ctx, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := newTaskResponse(srcID, *t)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateTask changes the script, schedule, status or description of a task
+// of an InfluxDB v2 source
+func (s *Service) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	var req influx.TaskUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if req.Status != "" && !oneOf(req.Status, "active", "inactive") {
+		invalidData(w, fmt.Errorf("status must be active or inactive"), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	tid, ok := v2IDParam(ctx, w, "tid", s.Logger)
+	if !ok {
+		return
+	}
+	t, err := cli.UpdateTask(ctx, tid, &req)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newTaskResponse(srcID, *t), s.Logger)
+}
+
+// RemoveTask deletes a task of an InfluxDB v2 source
+func (s *Service) RemoveTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	tid, ok := v2IDParam(ctx, w, "tid", s.Logger)
+	if !ok {
+		return
+	}
+	if err := cli.DeleteTask(ctx, tid); err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TaskRuns lists the most recent runs of a task of an InfluxDB v2 source,
+// at most as many as the optional limit query parameter
+func (s *Service) TaskRuns(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 {
+			Error(w, http.StatusUnprocessableEntity, "limit must be a positive integer", s.Logger)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	srcID, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	taskID, ok := v2IDParam(ctx, w, "tid", s.Logger)
+	if !ok {
+		return
+	}
+	runs, err := cli.TaskRuns(ctx, taskID, limit)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	res := taskRunsResponse{
+		Runs: make([]taskRunResponse, len(runs)),
+	}
+	for i, run := range runs {
+		res.Runs[i] = taskRunResponse{
+			TaskRun: run,
+			Links: taskRunLinks{
+				Logs: fmt.Sprintf("/chronograf/v1/sources/%d/v2/tasks/%s/runs/%s/logs", srcID, taskID, run.ID),
+			},
+		}
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// TaskRunLogs returns the log events of a run of a task of an InfluxDB v2
+// source
+func (s *Service) TaskRunLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, cli, ok := s.sourceV2Client(ctx, w, r)
+	if !ok {
+		return
+	}
+	taskID, ok := v2IDParam(ctx, w, "tid", s.Logger)
+	if !ok {
+		return
+	}
+	runID, ok := v2IDParam(ctx, w, "rid", s.Logger)
+	if !ok {
+		return
+	}
+	events, err := cli.TaskRunLogs(ctx, taskID, runID)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, taskRunLogsResponse{Events: events}, s.Logger)
+}
+
+// ValidBucketRequest checks that a bucket has a name and valid retention rules
+func ValidBucketRequest(b *influx.Bucket) error {
+	if b.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return validRetentionRules(b.RetentionRules)
+}
+
+// validRetentionRules defaults the type of retention rules to expire and
+// checks that their durations are not negative
+func validRetentionRules(rules []influx.RetentionRule) error {
+	for i := range rules {
+		if rules[i].Type == "" {
+			rules[i].Type = "expire"
+		}
+		if rules[i].Type != "expire" {
+			return fmt.Errorf("retention rule type must be expire")
+		}
+		if rules[i].EverySeconds < 0 || rules[i].ShardGroupDurationSeconds < 0 {
+			return fmt.Errorf("retention rule durations must not be negative")
+		}
+	}
+	return nil
+}
+
+// ValidDBRPRequest checks that a DBRP mapping has a bucket, a database and a
+// retention policy
+func ValidDBRPRequest(m *influx.DBRPMapping) error {
+	switch {
+	case m.BucketID == "":
+		return fmt.Errorf("bucketID is required")
+	case m.Database == "":
+		return fmt.Errorf("database is required")
+	case m.RetentionPolicy == "":
+		return fmt.Errorf("retention_policy is required")
+	}
+	return nil
+}
+
+// ValidAuthorizationRequest checks that an API token grants at least one read
+// or write permission on a resource type
+func ValidAuthorizationRequest(a *influx.Authorization) error {
+	if a.Status != "" && !oneOf(a.Status, "active", "inactive") {
+		return fmt.Errorf("status must be active or inactive")
+	}
+	if len(a.Permissions) == 0 {
+		return fmt.Errorf("at least one permission is required")
+	}
+	for _, p := range a.Permissions {
+		if !oneOf(p.Action, "read", "write") {
+			return fmt.Errorf("permission action must be read or write")
+		}
+		if p.Resource.Type == "" {
+			return fmt.Errorf("permission resource type is required")
+		}
+	}
+	return nil
+}
+
+// ValidTaskRequest checks that a task has a Flux script and a valid status
+func ValidTaskRequest(t *influx.Task) error {
+	if t.Flux == "" {
+		return fmt.Errorf("flux is required")
+	}
+	if t.Status != "" && !oneOf(t.Status, "active", "inactive") {
+		return fmt.Errorf("status must be active or inactive")
+	}
+	return nil
+}
+
+// v2IDPattern matches the 16 hexadecimal characters of an InfluxDB v2 ID
+var v2IDPattern = regexp.MustCompile(`^[0-9a-fA-F]{16}$`)
+
+// v2IDParam returns the router param name if it is an InfluxDB v2 ID and
+// responds with an error otherwise, so that IDs are safe to use in the paths
+// of the API of the source
+func v2IDParam(ctx context.Context, w http.ResponseWriter, name string, logger chronograf.Logger) (string, bool) {
+	id := httprouter.GetParamFromContext(ctx, name)
+	if !v2IDPattern.MatchString(id) {
+		invalidData(w, fmt.Errorf("invalid ID %q: InfluxDB v2 IDs are 16 hexadecimal characters", id), logger)
+		return "", false
+	}
+	return id, true
+}
+
+// sourceV2Client connects a client to the InfluxDB v2 source of the request.
+// It responds with an error and returns false if the source is not an
+// InfluxDB v2 source.
+func (s *Service) sourceV2Client(ctx context.Context, w http.ResponseWriter, r *http.Request) (int, *influx.Client, bool) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return 0, nil, false
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return 0, nil, false
+	}
+	if src.Type != chronograf.InfluxDBv2 {
+		Error(w, http.StatusNotFound, fmt.Sprintf("Source %d is not an InfluxDB v2 source", srcID), s.Logger)
+		return 0, nil, false
+	}
+	cli := &influx.Client{
+		Logger:   s.Logger,
+		V3Config: s.V3Config,
+	}
+	if err := cli.Connect(ctx, &src); err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return 0, nil, false
+	}
+	return srcID, cli, true
+}
+
+// influxAPIError responds with the error of an InfluxDB v2 or v3 API
+// request. Missing objects and rejected requests are passed through, other
+// failures of the server are reported as a bad gateway.
+func influxAPIError(w http.ResponseWriter, err error, logger chronograf.Logger) {
+	if err == chronograf.ErrUpstreamTimeout {
+		Error(w, http.StatusGatewayTimeout, "Timeout waiting for InfluxDB response", logger)
+		return
+	}
+	if err == influx.ErrV3CoreOnly || err == influx.ErrV3ManagementAPI || err == influx.ErrV3DatabaseName {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), logger)
+		return
+	}
+	status := 0
+	switch apiErr := err.(type) {
+	case *influx.V2Error:
+		status = apiErr.StatusCode
+	case *influx.V3Error:
+		status = apiErr.StatusCode
+	}
+	code := http.StatusBadGateway
+	switch status {
+	case http.StatusNotFound, http.StatusConflict:
+		code = status
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = http.StatusUnprocessableEntity
+	}
+	Error(w, code, err.Error(), logger)
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_InfluxV2(t *testing.T) {
+	patched := "" // patched is the body of the last PATCH request
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/v2/orgs":
+			w.Write([]byte(`{"orgs":[{"id":"000000000000000a","name":"my-org"}]}`))
+		case "GET /api/v2/buckets":
+			w.Write([]byte(`{"buckets":[{"id":"000000000000001b","orgID":"000000000000000a","name":"telegraf","type":"user","retentionRules":[{"type":"expire","everySeconds":86400}]}]}`))
+		case "POST /api/v2/buckets":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"000000000000001b","orgID":"000000000000000a","name":"telegraf","retentionRules":[]}`))
+		case "DELETE /api/v2/buckets/000000000000001b":
+			w.WriteHeader(http.StatusNoContent)
+		case "PATCH /api/v2/buckets/000000000000001b":
+			body, _ := ioutil.ReadAll(r.Body)
+			patched = string(body)
+			w.Write([]byte(`{"id":"000000000000001b","orgID":"000000000000000a","name":"telegraf","retentionRules":[]}`))
+		case "PATCH /api/v2/tasks/000000000000005e":
+			body, _ := ioutil.ReadAll(r.Body)
+			patched = string(body)
+			w.Write([]byte(`{"id":"000000000000005e","name":"downsample","status":"inactive"}`))
+		case "GET /api/v2/authorizations":
+			w.Write([]byte(`{"authorizations":[{"id":"000000000000004d","token":"secret","status":"active","orgID":"000000000000000a","permissions":[{"action":"read","resource":{"type":"buckets"}}]}]}`))
+		case "GET /api/v2/tasks/000000000000005e/runs":
+			w.Write([]byte(`{"runs":[{"id":"000000000000006f","taskID":"000000000000005e","status":"success"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"not found","message":"not found"}`))
+		}
+	}))
+	defer v2.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, URL: v2.URL, Type: chronograf.InfluxDBv2, Username: "my-org", Password: "my-token"}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: v2.URL, Type: chronograf.InfluxDBv1}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+
+	tests := []struct {
+		name       string
+		handler    func(*Service) http.HandlerFunc
+		method     string
+		target     string
+		body       string
+		params     httprouter.Params
+		wantStatus int
+		wantBody   string
+		wantPatch  string // wantPatch is the body of the PATCH request sent to the source
+	}{
+		{
+			name:       "buckets",
+			handler:    func(s *Service) http.HandlerFunc { return s.Buckets },
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"buckets":[{"id":"000000000000001b","orgID":"000000000000000a","name":"telegraf","type":"user","retentionRules":[{"type":"expire","everySeconds":86400}],"links":{"self":"/chronograf/v1/sources/1/v2/buckets/000000000000001b","dbrps":"/chronograf/v1/sources/1/v2/dbrps?bucketID=000000000000001b"}}]}`,
+		},
+		{
+			name:       "buckets of a v1 source",
+			handler:    func(s *Service) http.HandlerFunc { return s.Buckets },
+			params:     httprouter.Params{{Key: "id", Value: "2"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "create bucket",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewBucket },
+			method:     "POST",
+			body:       `{"name":"telegraf"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"id":"000000000000001b","orgID":"000000000000000a","name":"telegraf","retentionRules":[],"links":{"self":"/chronograf/v1/sources/1/v2/buckets/000000000000001b","dbrps":"/chronograf/v1/sources/1/v2/dbrps?bucketID=000000000000001b"}}`,
+		},
+		{
+			name:       "create bucket with negative retention",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewBucket },
+			method:     "POST",
+			body:       `{"name":"telegraf","retentionRules":[{"everySeconds":-1}]}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "remove bucket",
+			handler:    func(s *Service) http.HandlerFunc { return s.RemoveBucket },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "bid", Value: "000000000000001b"}},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "remove unknown bucket",
+			handler:    func(s *Service) http.HandlerFunc { return s.RemoveBucket },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "bid", Value: "000000000000002c"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "remove bucket with an invalid ID",
+			handler:    func(s *Service) http.HandlerFunc { return s.RemoveBucket },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "bid", Value: "../orgs/000000000000000a"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "update bucket without description",
+			handler:    func(s *Service) http.HandlerFunc { return s.UpdateBucket },
+			method:     "PATCH",
+			body:       `{"name":"telegraf"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "bid", Value: "000000000000001b"}},
+			wantStatus: http.StatusOK,
+			wantPatch:  `{"name":"telegraf"}`,
+		},
+		{
+			name:       "update bucket clearing its description",
+			handler:    func(s *Service) http.HandlerFunc { return s.UpdateBucket },
+			method:     "PATCH",
+			body:       `{"description":""}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "bid", Value: "000000000000001b"}},
+			wantStatus: http.StatusOK,
+			wantPatch:  `{"description":""}`,
+		},
+		{
+			name:       "update task status only",
+			handler:    func(s *Service) http.HandlerFunc { return s.UpdateTask },
+			method:     "PATCH",
+			body:       `{"status":"inactive"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "tid", Value: "000000000000005e"}},
+			wantStatus: http.StatusOK,
+			wantPatch:  `{"status":"inactive"}`,
+		},
+		{
+			name:       "authorizations hide tokens",
+			handler:    func(s *Service) http.HandlerFunc { return s.Authorizations },
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"authorizations":[{"id":"000000000000004d","status":"active","orgID":"000000000000000a","permissions":[{"action":"read","resource":{"type":"buckets"}}],"links":{"self":"/chronograf/v1/sources/1/v2/authorizations/000000000000004d"}}]}`,
+		},
+		{
+			name:       "create authorization without permissions",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewAuthorization },
+			method:     "POST",
+			body:       `{"description":"telegraf"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "create task without flux",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewTask },
+			method:     "POST",
+			body:       `{"status":"active"}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "task runs",
+			handler:    func(s *Service) http.HandlerFunc { return s.TaskRuns },
+			target:     "?limit=5",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "tid", Value: "000000000000005e"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"runs":[{"id":"000000000000006f","taskID":"000000000000005e","status":"success","links":{"logs":"/chronograf/v1/sources/1/v2/tasks/000000000000005e/runs/000000000000006f/logs"}}]}`,
+		},
+		{
+			name:       "task runs with invalid limit",
+			handler:    func(s *Service) http.HandlerFunc { return s.TaskRuns },
+			target:     "?limit=none",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "tid", Value: "000000000000005e"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store:  &mocks.Store{SourcesStore: sources},
+				Logger: log.New(log.DebugLevel),
+			}
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url"+tt.target, strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if tt.wantPatch != "" {
+				if eq, _ := jsonEqual(patched, tt.wantPatch); !eq {
+					t.Errorf("PATCH body = %s, want %s", patched, tt.wantPatch)
+				}
+			}
+		})
+	}
+}
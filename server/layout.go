@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -117,3 +118,115 @@ func (s *Service) LayoutsID(w http.ResponseWriter, r *http.Request) {
 	res := newLayoutResponse(layout)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
+
+// ValidLayoutRequest checks that the layout names its application and
+// measurement and that its cells only use the x, y and y2 axes
+func ValidLayoutRequest(layout chronograf.Layout) error {
+	if layout.Application == "" {
+		return fmt.Errorf("app required")
+	}
+	if layout.Measurement == "" {
+		return fmt.Errorf("measurement required")
+	}
+	for _, cell := range layout.Cells {
+		for axis := range cell.Axes {
+			if !oneOf(axis, "x", "y", "y2") {
+				return fmt.Errorf("Invalid axis %q in cell %q: must be one of x, y or y2", axis, cell.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// NewLayout adds a layout to the layouts that can be written
+func (s *Service) NewLayout(w http.ResponseWriter, r *http.Request) {
+	var layout chronograf.Layout
+	if err := json.NewDecoder(r.Body).Decode(&layout); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidLayoutRequest(layout); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	layout, err := s.Store.Layouts(ctx).Add(ctx, layout)
+	if err == chronograf.ErrLayoutReadOnly {
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return
+	}
+	if err != nil {
+		msg := fmt.Errorf("Error storing layout %v: %v", layout, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := newLayoutResponse(layout)
+	location(w, res.Link.Href)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpdateLayout replaces the layout with ID. Layouts that are shipped as
+// files are read-only.
+func (s *Service) UpdateLayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	store := s.Store.Layouts(ctx)
+	if _, err := store.Get(ctx, id); err != nil {
+		Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", id), s.Logger)
+		return
+	}
+
+	var layout chronograf.Layout
+	if err := json.NewDecoder(r.Body).Decode(&layout); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	layout.ID = id
+	if err := ValidLayoutRequest(layout); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	if err := store.Update(ctx, layout); err == chronograf.ErrLayoutReadOnly {
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return
+	} else if err != nil {
+		msg := fmt.Sprintf("Error updating layout ID %s: %v", id, err)
+		Error(w, http.StatusInternalServerError, msg, s.Logger)
+		return
+	}
+
+	// The store generates the IDs of new cells
+	layout, err := store.Get(ctx, id)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newLayoutResponse(layout), s.Logger)
+}
+
+// RemoveLayout deletes the layout with ID. Layouts that are shipped as files
+// are read-only.
+func (s *Service) RemoveLayout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := httprouter.GetParamFromContext(ctx, "id")
+
+	store := s.Store.Layouts(ctx)
+	layout, err := store.Get(ctx, id)
+	if err != nil {
+		Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", id), s.Logger)
+		return
+	}
+
+	if err := store.Delete(ctx, layout); err == chronograf.ErrLayoutReadOnly {
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return
+	} else if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
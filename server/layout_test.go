@@ -3,11 +3,13 @@ package server_test
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
 
+	"github.com/bouk/httprouter"
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/mocks"
@@ -184,3 +186,133 @@ func Test_Layouts(t *testing.T) {
 		})
 	}
 }
+
+func Test_LayoutsWrite(t *testing.T) {
+	custom := chronograf.Layout{ID: "custom", Application: "myapp", Measurement: "myapp"}
+	canned := chronograf.Layout{ID: "canned", Application: "system", Measurement: "cpu"}
+	store := &mocks.LayoutsStore{
+		GetF: func(ctx context.Context, id string) (chronograf.Layout, error) {
+			switch id {
+			case custom.ID:
+				return custom, nil
+			case canned.ID:
+				return canned, nil
+			}
+			return chronograf.Layout{}, chronograf.ErrLayoutNotFound
+		},
+		AddF: func(ctx context.Context, layout chronograf.Layout) (chronograf.Layout, error) {
+			layout.ID = "new"
+			return layout, nil
+		},
+		UpdateF: func(ctx context.Context, layout chronograf.Layout) error {
+			if layout.ID == canned.ID {
+				return chronograf.ErrLayoutReadOnly
+			}
+			return nil
+		},
+		DeleteF: func(ctx context.Context, layout chronograf.Layout) error {
+			if layout.ID == canned.ID {
+				return chronograf.ErrLayoutReadOnly
+			}
+			return nil
+		},
+	}
+	svc := server.Service{
+		Store:  &mocks.Store{LayoutsStore: store},
+		Logger: &mocks.TestLogger{},
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		id           string
+		body         string
+		handler      func(http.ResponseWriter, *http.Request)
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "create",
+			method:       "POST",
+			body:         `{"app":"myapp","measurement":"myapp","cells":[{"name":"A Graph","axes":{"y":{"bounds":["0","100"]}}}]}`,
+			handler:      svc.NewLayout,
+			wantStatus:   http.StatusCreated,
+			wantLocation: "/chronograf/v1/layouts/new",
+		},
+		{
+			name:       "create without measurement",
+			method:     "POST",
+			body:       `{"app":"myapp"}`,
+			handler:    svc.NewLayout,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "create with an invalid axis",
+			method:     "POST",
+			body:       `{"app":"myapp","measurement":"myapp","cells":[{"axes":{"z":{}}}]}`,
+			handler:    svc.NewLayout,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "update",
+			method:     "PUT",
+			id:         custom.ID,
+			body:       `{"app":"myapp","measurement":"mem"}`,
+			handler:    svc.UpdateLayout,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "update a read-only layout",
+			method:     "PUT",
+			id:         canned.ID,
+			body:       `{"app":"system","measurement":"cpu"}`,
+			handler:    svc.UpdateLayout,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "update a missing layout",
+			method:     "PUT",
+			id:         "missing",
+			body:       `{"app":"myapp","measurement":"mem"}`,
+			handler:    svc.UpdateLayout,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "delete",
+			method:     "DELETE",
+			id:         custom.ID,
+			handler:    svc.RemoveLayout,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "delete a read-only layout",
+			method:     "DELETE",
+			id:         canned.ID,
+			handler:    svc.RemoveLayout,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "delete a missing layout",
+			method:     "DELETE",
+			id:         "missing",
+			handler:    svc.RemoveLayout,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://any.url/chronograf/v1/layouts/"+tt.id, strings.NewReader(tt.body))
+			req = req.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: tt.id}}))
+			rr := httptest.NewRecorder()
+			tt.handler(rr, req)
+
+			resp := rr.Result()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("Location"); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+		})
+	}
+}
@@ -0,0 +1,659 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/roles"
+	"github.com/influxdata/influxql"
+)
+
+const (
+	liveDefaultEvery    = 10 * time.Second
+	liveMinEvery        = time.Second
+	liveMaxDuration     = time.Hour // liveMaxDuration ends connections, clients reconnect with Last-Event-ID
+	liveKeepAlive       = 15 * time.Second
+	liveMaxEventBytes   = 10 << 20 // liveMaxEventBytes is the maximum size of the results of a poll
+	liveSubscriberQueue = 16       // liveSubscriberQueue events are buffered before a slow client is dropped
+)
+
+// liveEpochs are the epochs of InfluxQL live queries
+var liveEpochs = map[string]int64{
+	"ns": 1,
+	"u":  int64(time.Microsecond),
+	"ms": int64(time.Millisecond),
+	"s":  int64(time.Second),
+}
+
+// fluxRangeStart matches the start argument of the range calls of Flux queries
+var fluxRangeStart = regexp.MustCompile(`(\brange\s*\(\s*start\s*:\s*)([^,)]+)`)
+
+// errLiveClientLimit is returned if a client watches too many live queries
+var errLiveClientLimit = errors.New("too many live queries")
+
+// liveKey identifies the live queries that share a poller
+type liveKey struct {
+	Source int
+	Type   string
+	Query  string
+	DB     string
+	RP     string
+	Epoch  string
+	Every  time.Duration
+}
+
+// newLiveKey parses the live query of a source from the parameters of the
+// request
+func newLiveKey(src int, params url.Values) (liveKey, error) {
+	key := liveKey{
+		Source: src,
+		Type:   params.Get("type"),
+		Query:  params.Get("query"),
+		DB:     params.Get("db"),
+		RP:     params.Get("rp"),
+		Epoch:  params.Get("epoch"),
+		Every:  liveDefaultEvery,
+	}
+	if strings.TrimSpace(key.Query) == "" {
+		return key, fmt.Errorf("query parameter required")
+	}
+	if key.Type == "" {
+		key.Type = "influxql"
+	}
+	if !oneOf(key.Type, "influxql", "flux") {
+		return key, fmt.Errorf("invalid type %q: must be influxql or flux", key.Type)
+	}
+	if key.Epoch == "" {
+		key.Epoch = "ms"
+	}
+	if _, ok := liveEpochs[key.Epoch]; !ok {
+		return key, fmt.Errorf("invalid epoch %q: must be one of ns, u, ms or s", key.Epoch)
+	}
+	if every := params.Get("every"); every != "" {
+		d, err := time.ParseDuration(every)
+		if err != nil {
+			return key, fmt.Errorf("invalid every %q", every)
+		}
+		if d < liveMinEvery {
+			return key, fmt.Errorf("every must be at least %s", liveMinEvery)
+		}
+		key.Every = d
+	}
+	return key, nil
+}
+
+// liveEvent is a server-sent event of a live query
+type liveEvent struct {
+	ID   string // ID is the time of the newest point, from which reconnecting clients resume
+	Name string
+	Data []byte
+}
+
+func (e liveEvent) write(w http.ResponseWriter) {
+	if e.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", e.ID)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Name, e.Data)
+}
+
+// liveEventID is the ID of the event of the points up to the time in
+// nanoseconds
+func liveEventID(last int64) string {
+	if last <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(last, 10)
+}
+
+// liveResult are the results of a poll of a live query
+type liveResult struct {
+	Data   []byte // Data is the JSON of the event
+	Last   int64  // Last is the time in nanoseconds of the newest point
+	Points int    // Points is the number of points of the results
+}
+
+// liveQuerier executes a live query. The query of a zero time is executed
+// as is, otherwise it only selects the points since the time in nanoseconds.
+type liveQuerier func(ctx context.Context, since int64) (liveResult, error)
+
+// LiveQueries shares the polling of the live queries of dashboards: the
+// clients watching the same query of a source receive the points of a
+// single poller.
+type LiveQueries struct {
+	MaxPerClient int // MaxPerClient is the number of live queries of each client; zero means no limit
+
+	mu      sync.Mutex
+	pollers map[liveKey]*livePoller
+	clients map[string]int
+}
+
+// livePoller polls a live query until its last subscriber leaves
+type livePoller struct {
+	key    liveKey
+	query  liveQuerier
+	last   int64
+	subs   map[*liveSubscriber]struct{}
+	cancel context.CancelFunc
+}
+
+// liveSubscriber receives the events of a poller. Its events are closed once
+// it is unsubscribed or dropped for being too slow.
+type liveSubscriber struct {
+	poller *livePoller
+	events chan liveEvent
+}
+
+// acquire reserves a live query of the client, the release func frees it
+func (l *LiveQueries) acquire(client string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.MaxPerClient > 0 && l.clients[client] >= l.MaxPerClient {
+		return nil, errLiveClientLimit
+	}
+	if l.clients == nil {
+		l.clients = map[string]int{}
+	}
+	l.clients[client]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			if l.clients[client]--; l.clients[client] <= 0 {
+				delete(l.clients, client)
+			}
+		})
+	}, nil
+}
+
+// subscribe receives the events of the live query. The poller of a query
+// that is not watched yet starts polling after the time of the newest point
+// that the subscriber has already read.
+func (l *LiveQueries) subscribe(key liveKey, query liveQuerier, last int64) *liveSubscriber {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.pollers == nil {
+		l.pollers = map[liveKey]*livePoller{}
+	}
+	p, ok := l.pollers[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		p = &livePoller{
+			key:    key,
+			query:  query,
+			last:   last,
+			subs:   map[*liveSubscriber]struct{}{},
+			cancel: cancel,
+		}
+		l.pollers[key] = p
+		go l.poll(ctx, p)
+	}
+	sub := &liveSubscriber{
+		poller: p,
+		events: make(chan liveEvent, liveSubscriberQueue),
+	}
+	p.subs[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe stops the events of the subscriber and the poller of its
+// query once it has no subscribers
+func (l *LiveQueries) unsubscribe(sub *liveSubscriber) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remove(sub)
+}
+
+// remove must be called with the lock held
+func (l *LiveQueries) remove(sub *liveSubscriber) {
+	p := sub.poller
+	if _, ok := p.subs[sub]; !ok {
+		return
+	}
+	delete(p.subs, sub)
+	close(sub.events)
+	if len(p.subs) == 0 {
+		p.cancel()
+		if l.pollers[p.key] == p {
+			delete(l.pollers, p.key)
+		}
+	}
+}
+
+// poll queries the points of the poller since its newest point and sends
+// them to its subscribers. Errors are sent as well, polling continues as the
+// source may recover.
+func (l *LiveQueries) poll(ctx context.Context, p *livePoller) {
+	ticker := time.NewTicker(p.key.Every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		l.mu.Lock()
+		since := p.last
+		l.mu.Unlock()
+		res, err := p.query(ctx, since)
+		if ctx.Err() != nil {
+			return
+		}
+
+		var ev liveEvent
+		switch {
+		case err != nil:
+			data, _ := json.Marshal(map[string]string{"message": err.Error()})
+			ev = liveEvent{Name: "error", Data: data}
+		case res.Points == 0:
+			continue
+		default:
+			ev = liveEvent{ID: liveEventID(res.Last), Name: "points", Data: res.Data}
+		}
+
+		l.mu.Lock()
+		if err == nil && res.Last > p.last {
+			p.last = res.Last
+		}
+		for sub := range p.subs {
+			select {
+			case sub.events <- ev:
+			default:
+				// slow clients reconnect and resume from their last event
+				l.remove(sub)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
+
+// LiveQuery streams the points of an InfluxQL or Flux query of a dashboard as
+// server-sent events. The first event has the results of the query, the
+// following ones only the points added since the previous event. Clients
+// watching the same query share its polling. Points of GROUP BY time
+// queries are sent again while their interval is not over, so clients
+// replace the points with the same time.
+func (s *Service) LiveQuery(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	key, err := newLiveKey(id, r.URL.Query())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	var since int64
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+		if since, err = strconv.ParseInt(lastID, 10, 64); err != nil || since <= 0 {
+			invalidData(w, fmt.Errorf("invalid Last-Event-ID %q", lastID), s.Logger)
+			return
+		}
+	}
+
+	ctx := r.Context()
+	if key.Type == "flux" {
+		if role, ok := hasRoleContext(ctx); ok && role == roles.ReaderRoleName {
+			if err := readerFluxQueryReadOnly(key.Query); err != nil {
+				Error(w, readerFluxErrorStatus(err), readerFluxErrorMessage(err), s.Logger)
+				return
+			}
+		}
+	} else if err := enforceReaderInfluxQLReadOnly(ctx, key.Query); err != nil {
+		if errors.Is(err, errReaderInfluxQLParse) {
+			Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+			return
+		}
+		Error(w, http.StatusForbidden, err.Error(), s.Logger)
+		return
+	}
+
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if err := validLiveQuery(key); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	var query liveQuerier
+	if key.Type == "flux" {
+		query, err = s.fluxLiveQuerier(ctx, src, key)
+	} else {
+		query, err = s.influxQLLiveQuerier(ctx, src, key)
+	}
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), s.Logger)
+		return
+	}
+	if s.Live == nil {
+		Error(w, http.StatusServiceUnavailable, "Live queries are not enabled", s.Logger)
+		return
+	}
+	release, err := s.Live.acquire(liveClient(r))
+	if err != nil {
+		msg := fmt.Sprintf("Unable to watch more than %d queries at once", s.Live.MaxPerClient)
+		Error(w, http.StatusTooManyRequests, msg, s.Logger)
+		return
+	}
+	defer release()
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		Error(w, http.StatusInternalServerError, "Streaming is not supported", s.Logger)
+		return
+	}
+
+	res, err := query(ctx, since)
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+	if res.Last < since {
+		res.Last = since
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	liveEvent{ID: liveEventID(res.Last), Name: "points", Data: res.Data}.write(w)
+	flusher.Flush()
+
+	sub := s.Live.subscribe(key, query, res.Last)
+	defer s.Live.unsubscribe(sub)
+	deadline := time.NewTimer(liveMaxDuration)
+	defer deadline.Stop()
+	keepAlive := time.NewTicker(liveKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline.C:
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case ev, ok := <-sub.events:
+			if !ok {
+				return
+			}
+			ev.write(w)
+		}
+		flusher.Flush()
+	}
+}
+
+// validLiveQuery checks that the time range of the query can be rewritten
+func validLiveQuery(key liveKey) error {
+	if key.Type == "flux" {
+		if !fluxRangeStart.MatchString(key.Query) {
+			return fmt.Errorf("live flux queries must have a range start")
+		}
+		return nil
+	}
+	_, err := liveInfluxQL(key.Query, 1, time.Now())
+	return err
+}
+
+// liveClient identifies the client of a live query by its user, or by its
+// address without authentication
+func liveClient(r *http.Request) string {
+	if u, ok := hasUserContext(r.Context()); ok {
+		return fmt.Sprintf("user:%s/%s/%s", u.Scheme, u.Provider, u.Name)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
+
+// influxQLLiveQuerier polls the points of the SELECT statements of an
+// InfluxQL query
+func (s *Service) influxQLLiveQuerier(ctx context.Context, src chronograf.Source, key liveKey) (liveQuerier, error) {
+	ts, err := s.TimeSeries(src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to source %d: %v", src.ID, err)
+	}
+	return func(ctx context.Context, since int64) (liveResult, error) {
+		command := key.Query
+		if since > 0 {
+			var err error
+			if command, err = liveInfluxQL(key.Query, since, time.Now()); err != nil {
+				return liveResult{}, err
+			}
+		}
+		q := chronograf.Query{
+			Command: command,
+			DB:      key.DB,
+			RP:      key.RP,
+			Epoch:   key.Epoch,
+		}
+		setupQueryFromCommand(&q)
+		response, err := ts.Query(ctx, q)
+		if err != nil {
+			return liveResult{}, err
+		}
+		octets, err := response.MarshalJSON()
+		if err != nil {
+			return liveResult{}, err
+		}
+		if len(octets) > liveMaxEventBytes {
+			return liveResult{}, chronograf.ErrResponseTooLarge
+		}
+		res, err := influxQLLiveResult(octets, liveEpochs[key.Epoch])
+		if err != nil {
+			return res, err
+		}
+		res.Data, err = json.Marshal(struct {
+			Results json.RawMessage `json:"results"`
+		}{octets})
+		return res, err
+	}, nil
+}
+
+// influxQLLiveResult counts the points of InfluxQL results and finds the
+// time of the newest one
+func influxQLLiveResult(octets []byte, epoch int64) (liveResult, error) {
+	var results []struct {
+		Series []struct {
+			Values [][]interface{} `json:"values"`
+		} `json:"series"`
+		Error string `json:"error"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(octets))
+	dec.UseNumber()
+	if err := dec.Decode(&results); err != nil {
+		return liveResult{}, err
+	}
+	var res liveResult
+	for _, r := range results {
+		if r.Error != "" {
+			return liveResult{}, fmt.Errorf("%s", r.Error)
+		}
+		for _, series := range r.Series {
+			for _, row := range series.Values {
+				res.Points++
+				if len(row) == 0 {
+					continue
+				}
+				t, ok := influxQLTime(row[0])
+				if !ok {
+					continue
+				}
+				if _, rfc3339 := row[0].(string); !rfc3339 {
+					t *= epoch
+				}
+				if t > res.Last {
+					res.Last = t
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// liveInfluxQL rewrites the time condition of the SELECT statements of the
+// query to select the points since the time in nanoseconds. Raw points are
+// selected after it, GROUP BY time intervals from the start of the interval
+// of the time, so that the last interval is selected again until it is
+// over. The upper bound of the query is kept.
+func liveInfluxQL(query string, since int64, now time.Time) (string, error) {
+	q, err := influxql.ParseQuery(query)
+	if err != nil {
+		return "", err
+	}
+	for _, stmt := range q.Statements {
+		sel, ok := stmt.(*influxql.SelectStatement)
+		if !ok {
+			return "", fmt.Errorf("live queries must only have SELECT statements")
+		}
+		for _, source := range sel.Sources {
+			if _, ok := source.(*influxql.SubQuery); ok {
+				return "", fmt.Errorf("live queries must not have subqueries")
+			}
+		}
+		if err := liveSelect(sel, since, now); err != nil {
+			return "", err
+		}
+	}
+	return q.String(), nil
+}
+
+// liveSelect replaces the time condition of the statement
+func liveSelect(sel *influxql.SelectStatement, since int64, now time.Time) error {
+	lower := since + 1
+	interval, err := sel.GroupByInterval()
+	if err != nil {
+		return err
+	}
+	if interval > 0 {
+		offset, err := sel.GroupByOffset()
+		if err != nil {
+			return err
+		}
+		// start of the interval of since, which may precede the offset
+		rem := (since - int64(offset)) % int64(interval)
+		if rem < 0 {
+			rem += int64(interval)
+		}
+		lower = since - rem
+	}
+
+	min, max, err := influx.TimeRangeAsEpochNano(sel.Condition, now)
+	if err != nil {
+		return err
+	}
+	cond, tr, err := influxql.ConditionExpr(sel.Condition, &influxql.NowValuer{Now: now})
+	if err != nil {
+		return err
+	}
+	if lower < min {
+		lower = min
+	}
+	timeCond := influxql.Expr(&influxql.BinaryExpr{
+		Op:  influxql.GTE,
+		LHS: &influxql.VarRef{Val: "time"},
+		RHS: &influxql.TimeLiteral{Val: time.Unix(0, lower).UTC()},
+	})
+	if !tr.Max.IsZero() {
+		timeCond = &influxql.BinaryExpr{
+			Op:  influxql.AND,
+			LHS: timeCond,
+			RHS: &influxql.BinaryExpr{
+				Op:  influxql.LTE,
+				LHS: &influxql.VarRef{Val: "time"},
+				RHS: &influxql.TimeLiteral{Val: time.Unix(0, max).UTC()},
+			},
+		}
+	}
+	if cond == nil {
+		sel.Condition = timeCond
+		return nil
+	}
+	sel.Condition = &influxql.BinaryExpr{
+		Op:  influxql.AND,
+		LHS: &influxql.ParenExpr{Expr: cond},
+		RHS: timeCond,
+	}
+	return nil
+}
+
+// fluxLiveQuerier polls the points of a Flux query since its range start
+func (s *Service) fluxLiveQuerier(ctx context.Context, src chronograf.Source, key liveKey) (liveQuerier, error) {
+	fluxEnabled, err := hasFlux(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("flux service unavailable: %v", err)
+	}
+	if !fluxEnabled {
+		return nil, fmt.Errorf("flux is not enabled for source %d", src.ID)
+	}
+	u, err := url.ParseRequestURI(src.URL)
+	if err != nil {
+		return nil, err
+	}
+	max := s.QueryLimits.Of(&src).MaxResponseBytes
+	if max <= 0 || max > liveMaxEventBytes {
+		max = liveMaxEventBytes
+	}
+	client := &flux.Client{
+		URL:                u,
+		InsecureSkipVerify: src.InsecureSkipVerify,
+		Org:                src.Username, // v2 organization name is stored in username
+		Authorizer:         influx.DefaultAuthorization(&src),
+	}
+	return func(ctx context.Context, since int64) (liveResult, error) {
+		query := key.Query
+		if since > 0 {
+			query = liveFlux(query, since)
+		}
+		body, err := client.Query(ctx, query)
+		if err != nil {
+			return liveResult{}, err
+		}
+		defer body.Close()
+		csv, err := influx.ReadResponse(body, max)
+		if err != nil {
+			return liveResult{}, err
+		}
+		rows, err := flux.ReadRows(bytes.NewReader(csv))
+		if err != nil {
+			return liveResult{}, err
+		}
+		res := liveResult{Points: len(rows)}
+		for _, row := range rows {
+			if t, err := time.Parse(time.RFC3339Nano, row["_time"]); err == nil && t.UnixNano() > res.Last {
+				res.Last = t.UnixNano()
+			}
+		}
+		res.Data, err = json.Marshal(map[string]string{"csv": string(csv)})
+		return res, err
+	}, nil
+}
+
+// liveFlux moves the range start of the Flux query after the time in
+// nanoseconds
+func liveFlux(query string, since int64) string {
+	start := time.Unix(0, since+1).UTC().Format(time.RFC3339Nano)
+	return fluxRangeStart.ReplaceAllString(query, "${1}"+start)
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestLiveInfluxQL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+	since := time.Date(2026, 1, 1, 0, 59, 30, 500, time.UTC).UnixNano()
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "raw points after the newest point",
+			query: `SELECT "usage_user" FROM "cpu" WHERE time > now() - 1h AND "host" = 'a'`,
+			want:  `SELECT usage_user FROM cpu WHERE (host = 'a') AND time >= '2026-01-01T00:59:30.000000501Z'`,
+		},
+		{
+			name:  "group by time from the start of the interval",
+			query: `SELECT mean("usage_user") FROM "cpu" WHERE time > now() - 1h GROUP BY time(1m), "host"`,
+			want:  `SELECT mean(usage_user) FROM cpu WHERE time >= '2026-01-01T00:59:00Z' GROUP BY time(1m), host`,
+		},
+		{
+			name:  "group by time with an offset",
+			query: `SELECT count("v") FROM "m" WHERE time > now() - 1h GROUP BY time(1m, 45s)`,
+			want:  `SELECT count(v) FROM m WHERE time >= '2026-01-01T00:58:45Z' GROUP BY time(1m, 45s)`,
+		},
+		{
+			name:  "upper bound is kept",
+			query: `SELECT "v" FROM "m" WHERE time > '2026-01-01T00:00:00Z' AND time < '2026-01-01T02:00:00Z'`,
+			want:  `SELECT v FROM m WHERE time >= '2026-01-01T00:59:30.000000501Z' AND time <= '2026-01-01T01:59:59.999999999Z'`,
+		},
+		{
+			name:  "lower bound is kept if it follows the newest point",
+			query: `SELECT "v" FROM "m" WHERE time > now() - 10s`,
+			want:  `SELECT v FROM m WHERE time >= '2026-01-01T00:59:50.000000001Z'`,
+		},
+		{
+			name:    "only select statements",
+			query:   `SHOW DATABASES`,
+			wantErr: true,
+		},
+		{
+			name:    "no subqueries",
+			query:   `SELECT max("v") FROM (SELECT "v" FROM "m") WHERE time > now() - 1h`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := liveInfluxQL(tt.query, since, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("liveInfluxQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("liveInfluxQL() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiveFlux(t *testing.T) {
+	query := `from(bucket: "telegraf") |> range(start: -1h, stop: now()) |> filter(fn: (r) => r._measurement == "cpu")`
+	want := `from(bucket: "telegraf") |> range(start: 2026-01-01T00:59:30.000000501Z, stop: now()) |> filter(fn: (r) => r._measurement == "cpu")`
+	since := time.Date(2026, 1, 1, 0, 59, 30, 500, time.UTC).UnixNano()
+	if got := liveFlux(query, since); got != want {
+		t.Errorf("liveFlux() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestLiveQueries(t *testing.T) {
+	polls := make(chan int64, 10)
+	query := func(ctx context.Context, since int64) (liveResult, error) {
+		polls <- since
+		return liveResult{Data: []byte(`{}`), Last: since + 10, Points: 1}, nil
+	}
+	live := &LiveQueries{MaxPerClient: 1}
+	key := liveKey{Source: 1, Type: "influxql", Query: "SELECT v FROM m", Every: 10 * time.Millisecond}
+
+	release, err := live.acquire("user:a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := live.acquire("user:a"); err != errLiveClientLimit {
+		t.Errorf("acquire() of a second query error = %v, want %v", err, errLiveClientLimit)
+	}
+	release()
+	release()
+	if _, err := live.acquire("user:a"); err != nil {
+		t.Errorf("acquire() after release error = %v", err)
+	}
+
+	sub1 := live.subscribe(key, query, 100)
+	sub2 := live.subscribe(key, query, 5)
+	if sub1.poller != sub2.poller {
+		t.Fatal("subscribers of the same query do not share a poller")
+	}
+	for _, sub := range []*liveSubscriber{sub1, sub2} {
+		select {
+		case ev := <-sub.events:
+			if ev.Name != "points" || ev.ID != "110" {
+				t.Errorf("event = %+v, want points with ID 110", ev)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event was received")
+		}
+	}
+	if since := <-polls; since != 100 {
+		t.Errorf("first poll since %d, want 100", since)
+	}
+
+	live.unsubscribe(sub1)
+	live.unsubscribe(sub2)
+	live.unsubscribe(sub2)
+	if _, ok := <-sub2.events; ok {
+		for range sub2.events {
+		}
+	}
+	live.mu.Lock()
+	defer live.mu.Unlock()
+	if len(live.pollers) != 0 {
+		t.Errorf("pollers = %d, want 0 once the query is not watched", len(live.pollers))
+	}
+}
+
+func TestService_LiveQuery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	since := time.Now().Add(-time.Minute).UnixNano()
+	newest := since/int64(time.Millisecond) + 2000
+	queries := []chronograf.Query{}
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+			QueryF: func(_ context.Context, q chronograf.Query) (chronograf.Response, error) {
+				queries = append(queries, q)
+				// the stream ends after the results of the first query
+				cancel()
+				res := fmt.Sprintf(`[{"statement_id":0,"series":[{"name":"cpu","columns":["time","usage_user"],"values":[[%d,1.5],[%d,2]]}]}]`, newest-1000, newest)
+				return mocks.NewResponse(res, nil), nil
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+		Live:   &LiveQueries{},
+	}
+
+	query := `SELECT "usage_user" FROM "telegraf"."autogen"."cpu" WHERE time > now() - 1h`
+	r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/1/live", nil)
+	r.URL.RawQuery = "every=5s&query=" + strings.ReplaceAll(query, " ", "+")
+	r.Header.Set("Last-Event-ID", strconv.FormatInt(since, 10))
+	r = r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}}))
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		s.LiveQuery(w, r)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("LiveQuery() did not end when the request was canceled")
+	}
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %s", ct)
+	}
+	want := fmt.Sprintf("id: %d\nevent: points\ndata: {\"results\":[{\"statement_id\":0,", newest*int64(time.Millisecond))
+	if body := w.Body.String(); !strings.HasPrefix(body, want) {
+		t.Errorf("events =\n%s\nwant prefix\n%s", body, want)
+	}
+	if len(queries) != 1 {
+		t.Fatalf("queries = %v", queries)
+	}
+	lower := time.Unix(0, since+1).UTC().Format(time.RFC3339Nano)
+	if q := queries[0]; q.Epoch != "ms" || !strings.Contains(q.Command, "WHERE time >= '"+lower+"'") {
+		t.Errorf("query = %+v", q)
+	}
+	s.Live.mu.Lock()
+	defer s.Live.mu.Unlock()
+	if len(s.Live.pollers) != 0 || len(s.Live.clients) != 0 {
+		t.Errorf("live queries were not released: %d pollers, %d clients", len(s.Live.pollers), len(s.Live.clients))
+	}
+}
+
+func TestService_LiveQueryInvalid(t *testing.T) {
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+		Live:   &LiveQueries{},
+	}
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{name: "no query", params: ""},
+		{name: "every below the minimum", params: "every=100ms&query=SELECT+v+FROM+m"},
+		{name: "invalid epoch", params: "epoch=d&query=SELECT+v+FROM+m"},
+		{name: "flux without range start", params: "type=flux&query=from(bucket:%22a%22)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/1/live?"+tt.params, nil)
+			r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{{Key: "id", Value: "1"}}))
+			w := httptest.NewRecorder()
+			s.LiveQuery(w, r)
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("status = %d, want %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body.String())
+			}
+		})
+	}
+}
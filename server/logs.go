@@ -0,0 +1,853 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/influxql"
+)
+
+// logsMeasurement is the measurement written by the syslog input of telegraf
+const logsMeasurement = "syslog"
+
+const (
+	defaultLogsLimit     = 100
+	maxLogsLimit         = 1000
+	defaultLogsRange     = time.Hour // defaultLogsRange is the range of logs without a lower bound
+	logsHistogramBuckets = 60        // logsHistogramBuckets is the number of buckets of the default interval
+	maxLogsBuckets       = 10000
+	defaultLogsTailEvery = time.Second
+	logsKeepAlive        = 15 * time.Second // logsKeepAlive is the interval of comments sent while tailing no logs
+)
+
+// syslogSeverities are the severities of syslog, the most severe first
+var syslogSeverities = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// logsTags are the tags of syslog that logs are filtered by, with the query
+// parameter of each
+var logsTags = []struct{ param, tag string }{
+	{"severity", "severity"},
+	{"facility", "facility"},
+	{"host", "host"},
+	{"appname", "appname"},
+}
+
+// logEntry is a syslog message, with the columns of the log viewer
+type logEntry struct {
+	Time      time.Time `json:"time"`
+	Severity  string    `json:"severity"`
+	Facility  string    `json:"facility"`
+	Host      string    `json:"host"`
+	Hostname  string    `json:"hostname,omitempty"`
+	Appname   string    `json:"appname"`
+	ProcID    string    `json:"procid,omitempty"`
+	Timestamp int64     `json:"timestamp,omitempty"` // Timestamp is the time in nanoseconds given by the sender
+	Message   string    `json:"message"`
+}
+
+// set sets the column of the entry to the string value v
+func (e *logEntry) set(column, v string) {
+	switch column {
+	case "severity":
+		e.Severity = v
+	case "facility":
+		e.Facility = v
+	case "host":
+		e.Host = v
+	case "hostname":
+		e.Hostname = v
+	case "appname":
+		e.Appname = v
+	case "procid":
+		e.ProcID = v
+	case "timestamp":
+		e.Timestamp, _ = strconv.ParseInt(v, 10, 64)
+	case "message":
+		e.Message = v
+	}
+}
+
+// logsColumns are the columns of logEntry besides the time
+var logsColumns = []string{"appname", "facility", "host", "hostname", "message", "procid", "severity", "timestamp"}
+
+// logsCount is the number of logs of a severity in a histogram bucket
+type logsCount struct {
+	Time     time.Time
+	Severity string
+	Count    int64
+}
+
+// logsBucket counts the logs of an interval by severity
+type logsBucket struct {
+	Time   time.Time        `json:"time"`
+	Total  int64            `json:"total"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// logsQuery selects the logs from lower, inclusive, to upper, exclusive
+type logsQuery struct {
+	Lower   time.Time
+	Upper   time.Time
+	Tags    map[string][]string // Tags are the values of each tag that are selected
+	Message string              // Message is a regular expression logs must match
+	Desc    bool                // Desc orders the logs from the newest
+	Limit   int
+}
+
+// logsSource queries the syslog measurement of a source
+type logsSource interface {
+	// Logs returns the logs of the query ordered by time
+	Logs(ctx context.Context, q logsQuery) ([]logEntry, error)
+	// Histogram counts the logs of the query by severity within intervals
+	// aligned to the epoch
+	Histogram(ctx context.Context, q logsQuery, interval time.Duration) ([]logsCount, error)
+}
+
+// logsCursor is a position within the logs ordered by time. As logs are
+// ordered by time only, Skip counts the logs at Time that precede the
+// position.
+type logsCursor struct {
+	Time int64
+	Skip int
+}
+
+func (c logsCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.Time, c.Skip)))
+}
+
+func parseLogsCursor(s string) (logsCursor, error) {
+	octets, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		parts := strings.Split(string(octets), ":")
+		if len(parts) == 2 {
+			t, err1 := strconv.ParseInt(parts[0], 10, 64)
+			skip, err2 := strconv.Atoi(parts[1])
+			if err1 == nil && err2 == nil && skip >= 0 {
+				return logsCursor{Time: t, Skip: skip}, nil
+			}
+		}
+	}
+	return logsCursor{}, fmt.Errorf("invalid cursor %q", s)
+}
+
+// logsRequest is a request of logs, histograms or tails of logs
+type logsRequest struct {
+	logsQuery
+	DB        string
+	RP        string
+	Cursor    *logsCursor
+	Direction string        // Direction of the page from the cursor, older (default) or newer
+	Interval  time.Duration // Interval of the buckets of histograms
+	Every     time.Duration // Every is the interval at which tails query new logs
+}
+
+func newLogsRequest(params url.Values, now time.Time) (logsRequest, error) {
+	req := logsRequest{
+		logsQuery: logsQuery{
+			Upper:   now,
+			Tags:    map[string][]string{},
+			Message: params.Get("message"),
+			Limit:   defaultLogsLimit,
+		},
+		DB:        params.Get("db"),
+		RP:        params.Get("rp"),
+		Direction: "older",
+		Every:     defaultLogsTailEvery,
+	}
+
+	if upper := params.Get("upper"); upper != "" {
+		t, err := time.Parse(time.RFC3339Nano, upper)
+		if err != nil {
+			return req, fmt.Errorf("upper must be an RFC3339 time")
+		}
+		req.Upper = t
+	}
+	req.Lower = req.Upper.Add(-defaultLogsRange)
+	if lower := params.Get("lower"); lower != "" {
+		t, err := time.Parse(time.RFC3339Nano, lower)
+		if err != nil {
+			return req, fmt.Errorf("lower must be an RFC3339 time")
+		}
+		req.Lower = t
+	}
+	if !req.Lower.Before(req.Upper) {
+		return req, fmt.Errorf("lower must be before upper")
+	}
+
+	for _, t := range logsTags {
+		values := listParam(params, t.param)
+		if len(values) > 0 {
+			req.Tags[t.tag] = values
+		}
+	}
+	for _, severity := range req.Tags["severity"] {
+		if !containsString(syslogSeverities, severity) {
+			return req, fmt.Errorf("invalid severity %q: must be one of %s", severity, strings.Join(syslogSeverities, ", "))
+		}
+	}
+	if req.Message != "" {
+		if _, err := regexp.Compile(req.Message); err != nil {
+			return req, fmt.Errorf("invalid message regular expression: %v", err)
+		}
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxLogsLimit {
+			return req, fmt.Errorf("limit must be between 1 and %d", maxLogsLimit)
+		}
+		req.Limit = n
+	}
+	if cursor := params.Get("cursor"); cursor != "" {
+		c, err := parseLogsCursor(cursor)
+		if err != nil {
+			return req, err
+		}
+		req.Cursor = &c
+	}
+	if direction := params.Get("direction"); direction != "" {
+		if !oneOf(direction, "older", "newer") {
+			return req, fmt.Errorf("direction must be older or newer")
+		}
+		req.Direction = direction
+	}
+
+	span := req.Upper.Sub(req.Lower)
+	req.Interval = (span/logsHistogramBuckets + time.Second - 1).Truncate(time.Second)
+	if req.Interval < time.Second {
+		req.Interval = time.Second
+	}
+	if interval := params.Get("interval"); interval != "" {
+		d, err := influxql.ParseDuration(interval)
+		if err != nil || d < time.Second {
+			return req, fmt.Errorf("interval must be a duration of at least 1s")
+		}
+		req.Interval = d
+	}
+	if span/req.Interval > maxLogsBuckets {
+		return req, fmt.Errorf("interval must split the range into at most %d buckets", maxLogsBuckets)
+	}
+	if every := params.Get("every"); every != "" {
+		d, err := influxql.ParseDuration(every)
+		if err != nil || d < time.Second {
+			return req, fmt.Errorf("every must be a duration of at least 1s")
+		}
+		req.Every = d
+	}
+	return req, nil
+}
+
+// listParam returns the values of a repeated or comma separated parameter
+func listParam(params url.Values, key string) []string {
+	values := []string{}
+	for _, param := range params[key] {
+		for _, v := range strings.Split(param, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+type logsLinks struct {
+	Self      string `json:"self"`
+	Older     string `json:"older,omitempty"` // Older is the page of older logs, empty if there are none
+	Newer     string `json:"newer,omitempty"` // Newer is the page of newer logs
+	Histogram string `json:"histogram"`
+	Tail      string `json:"tail"`
+}
+
+type logsResponse struct {
+	Logs  []logEntry `json:"logs"` // Logs are ordered from the newest
+	Links logsLinks  `json:"links"`
+}
+
+type logsHistogramResponse struct {
+	Interval string       `json:"interval"`
+	Buckets  []logsBucket `json:"buckets"`
+}
+
+// logsPage is a page of logs, the newest first, with the cursors of the
+// pages of older and newer logs
+type logsPage struct {
+	Logs  []logEntry
+	Older *logsCursor
+	Newer *logsCursor
+}
+
+// Logs returns a page of the syslog messages of a source, filtered by
+// severity, facility, host, application and message
+func (s *Service) Logs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	src, req, ls, ok := s.logsRequest(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := readLogsPage(ctx, ls, req)
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+
+	base := fmt.Sprintf("/chronograf/v1/sources/%d/logs", src.ID)
+	params := r.URL.Query()
+	res := logsResponse{
+		Logs:  page.Logs,
+		Links: newLogsLinks(base, params),
+	}
+	if page.Older != nil {
+		res.Links.Older = logsCursorLink(base, params, *page.Older, "older")
+	}
+	if page.Newer != nil {
+		res.Links.Newer = logsCursorLink(base, params, *page.Newer, "newer")
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// LogsHistogram counts the syslog messages of a source by severity within
+// the intervals of the range of the request
+func (s *Service) LogsHistogram(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, req, ls, ok := s.logsRequest(w, r)
+	if !ok {
+		return
+	}
+
+	counts, err := ls.Histogram(ctx, req.logsQuery, req.Interval)
+	if err != nil {
+		s.influxQueryError(w, err)
+		return
+	}
+	res := logsHistogramResponse{
+		Interval: influxql.FormatDuration(req.Interval),
+		Buckets:  newLogsBuckets(counts),
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// TailLogs streams the syslog messages of a source as server-sent events.
+// Each logs event has the newly written logs and the cursor of the newest log
+// as ID, so that reconnecting clients resume from the last event.
+func (s *Service) TailLogs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, req, ls, ok := s.logsRequest(w, r)
+	if !ok {
+		return
+	}
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		c, err := parseLogsCursor(id)
+		if err != nil {
+			invalidData(w, err, s.Logger)
+			return
+		}
+		req.Cursor = &c
+	}
+	if req.Cursor == nil {
+		req.Cursor = &logsCursor{Time: time.Now().UnixNano()}
+	}
+	// tails resume from the cursor, even if it precedes the range
+	req.Lower = time.Unix(0, req.Cursor.Time)
+	req.Direction = "newer"
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		Error(w, http.StatusInternalServerError, "Streaming is not supported", s.Logger)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(req.Every)
+	defer ticker.Stop()
+	lastWrite := time.Now()
+	for {
+		req.Upper = time.Now()
+		page, err := readLogsPage(ctx, ls, req)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			writeLogsEvent(w, "error", "", map[string]string{"message": err.Error()})
+			flusher.Flush()
+			return
+		case len(page.Logs) > 0:
+			req.Cursor = page.Newer
+			writeLogsEvent(w, "logs", page.Newer.String(), map[string][]logEntry{"logs": page.Logs})
+			lastWrite = time.Now()
+		case time.Since(lastWrite) >= logsKeepAlive:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			lastWrite = time.Now()
+		}
+		flusher.Flush()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeLogsEvent writes a server-sent event with JSON data
+func writeLogsEvent(w http.ResponseWriter, event, id string, data interface{}) {
+	octets, _ := json.Marshal(data)
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, octets)
+}
+
+// logsRequest parses the request of logs of a source and connects to the
+// source, writing the error if it fails
+func (s *Service) logsRequest(w http.ResponseWriter, r *http.Request) (chronograf.Source, logsRequest, logsSource, bool) {
+	ctx := r.Context()
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return chronograf.Source{}, logsRequest{}, nil, false
+	}
+	req, err := newLogsRequest(r.URL.Query(), time.Now())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return chronograf.Source{}, req, nil, false
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, id)
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return src, req, nil, false
+	}
+	if req.DB == "" {
+		req.DB = src.Telegraf
+	}
+	if req.DB == "" {
+		req.DB = "telegraf"
+	}
+	ls, err := s.logsSource(ctx, src, req.DB, req.RP)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", id, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return src, req, nil, false
+	}
+	return src, req, ls, true
+}
+
+// logsSource connects to the source to query logs with Flux on 2.x and
+// InfluxQL otherwise
+func (s *Service) logsSource(ctx context.Context, src chronograf.Source, db, rp string) (logsSource, error) {
+	if src.Type == chronograf.InfluxDBv2 {
+		u, err := url.ParseRequestURI(src.URL)
+		if err != nil {
+			return nil, err
+		}
+		bucket := db
+		if rp != "" {
+			bucket += "/" + rp
+		}
+		return &fluxLogs{
+			client: &flux.Client{
+				URL:                u,
+				InsecureSkipVerify: src.InsecureSkipVerify,
+				Org:                src.Username, // v2 organization name is stored in username
+				Authorizer:         influx.DefaultAuthorization(&src),
+			},
+			bucket: bucket,
+		}, nil
+	}
+	ts, err := s.TimeSeries(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.Connect(ctx, &src); err != nil {
+		return nil, err
+	}
+	return &influxQLLogs{ts: ts, db: db, rp: rp}, nil
+}
+
+// readLogsPage reads the page of logs of the request. The page from a cursor
+// skips the logs at the time of the cursor that were already read.
+func readLogsPage(ctx context.Context, ls logsSource, req logsRequest) (logsPage, error) {
+	q := req.logsQuery
+	newer := req.Direction == "newer"
+	skip := 0
+	if c := req.Cursor; c != nil {
+		skip = c.Skip
+		t := time.Unix(0, c.Time)
+		if newer && t.After(q.Lower) {
+			q.Lower = t
+		}
+		if t = t.Add(time.Nanosecond); !newer && t.Before(q.Upper) {
+			q.Upper = t
+		}
+	}
+	q.Desc = !newer
+	q.Limit = req.Limit + skip + 1
+
+	logs := []logEntry{}
+	if q.Lower.Before(q.Upper) {
+		var err error
+		if logs, err = ls.Logs(ctx, q); err != nil {
+			return logsPage{}, err
+		}
+	}
+	if skip > len(logs) {
+		skip = len(logs)
+	}
+	logs = logs[skip:]
+	more := len(logs) > req.Limit
+	if more {
+		logs = logs[:req.Limit]
+	}
+	if newer {
+		for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+			logs[i], logs[j] = logs[j], logs[i]
+		}
+	}
+
+	page := logsPage{Logs: logs}
+	if len(logs) == 0 {
+		switch {
+		case req.Cursor == nil:
+			page.Newer = &logsCursor{Time: q.Upper.UnixNano()}
+		case newer:
+			page.Newer = req.Cursor
+		}
+		return page, nil
+	}
+	page.Newer = logsEndCursor(logs, 0, 1, req.Cursor, newer)
+	if more || newer {
+		page.Older = logsEndCursor(logs, len(logs)-1, -1, req.Cursor, !newer)
+	}
+	return page, nil
+}
+
+// logsEndCursor is the cursor of the log at the end i of a page, counting the
+// logs of the page with its time in direction step. The logs skipped by the
+// cursor of the page are counted if the page continues in the same direction.
+func logsEndCursor(logs []logEntry, i, step int, from *logsCursor, same bool) *logsCursor {
+	c := &logsCursor{Time: logs[i].Time.UnixNano()}
+	for ; i >= 0 && i < len(logs) && logs[i].Time.UnixNano() == c.Time; i += step {
+		c.Skip++
+	}
+	if same && from != nil && from.Time == c.Time {
+		c.Skip += from.Skip
+	}
+	return c
+}
+
+func newLogsLinks(base string, params url.Values) logsLinks {
+	filters := url.Values{}
+	for k, v := range params {
+		if !oneOf(k, "cursor", "direction") {
+			filters[k] = v
+		}
+	}
+	query := ""
+	if len(filters) > 0 {
+		query = "?" + filters.Encode()
+	}
+	self := base
+	if len(params) > 0 {
+		self += "?" + params.Encode()
+	}
+	return logsLinks{
+		Self:      self,
+		Histogram: base + "/histogram" + query,
+		Tail:      base + "/tail" + query,
+	}
+}
+
+func logsCursorLink(base string, params url.Values, c logsCursor, direction string) string {
+	link := url.Values{}
+	for k, v := range params {
+		link[k] = v
+	}
+	link.Set("cursor", c.String())
+	link.Set("direction", direction)
+	return base + "?" + link.Encode()
+}
+
+// newLogsBuckets merges the counts of severities into buckets ordered by time
+func newLogsBuckets(counts []logsCount) []logsBucket {
+	buckets := []logsBucket{}
+	index := map[int64]int{}
+	for _, c := range counts {
+		if c.Count == 0 {
+			continue
+		}
+		i, ok := index[c.Time.UnixNano()]
+		if !ok {
+			i = len(buckets)
+			index[c.Time.UnixNano()] = i
+			buckets = append(buckets, logsBucket{Time: c.Time, Counts: map[string]int64{}})
+		}
+		buckets[i].Total += c.Count
+		buckets[i].Counts[c.Severity] += c.Count
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Time.Before(buckets[j].Time)
+	})
+	return buckets
+}
+
+// regexLiteral returns the regular expression as an InfluxQL or Flux regular
+// expression literal, escaping the slashes that delimit it. A trailing
+// backslash is escaped so that it cannot escape the closing slash.
+func regexLiteral(re string) string {
+	var b strings.Builder
+	b.WriteByte('/')
+	for i := 0; i < len(re); i++ {
+		switch re[i] {
+		case '\\':
+			b.WriteByte('\\')
+			if i+1 < len(re) {
+				i++
+				b.WriteByte(re[i])
+			} else {
+				b.WriteByte('\\')
+			}
+		case '/':
+			b.WriteString(`\/`)
+		default:
+			b.WriteByte(re[i])
+		}
+	}
+	b.WriteByte('/')
+	return b.String()
+}
+
+// influxQLLogs queries logs with InfluxQL
+type influxQLLogs struct {
+	ts chronograf.TimeSeries
+	db string
+	rp string
+}
+
+// influxQLSeries is a series of an InfluxQL result whose numbers are decoded
+// as json.Number to keep the precision of times
+type influxQLSeries struct {
+	Tags    map[string]string `json:"tags"`
+	Columns []string          `json:"columns"`
+	Values  [][]interface{}   `json:"values"`
+}
+
+func (l *influxQLLogs) Logs(ctx context.Context, q logsQuery) ([]logEntry, error) {
+	columns := make([]string, len(logsColumns))
+	for i, c := range logsColumns {
+		columns[i] = influxql.QuoteIdent(c)
+	}
+	order := "ASC"
+	if q.Desc {
+		order = "DESC"
+	}
+	command := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY time %s LIMIT %d",
+		strings.Join(columns, ", "), l.from(), l.where(q), order, q.Limit)
+	series, err := queryInfluxQL(ctx, l.ts, l.db, l.rp, command)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []logEntry{}
+	for _, s := range series {
+		for _, row := range s.Values {
+			var e logEntry
+			for i, column := range s.Columns {
+				if i >= len(row) || row[i] == nil {
+					continue
+				}
+				v := fmt.Sprint(row[i])
+				if column == "time" {
+					ns, _ := strconv.ParseInt(v, 10, 64)
+					e.Time = time.Unix(0, ns).UTC()
+					continue
+				}
+				e.set(column, v)
+			}
+			logs = append(logs, e)
+		}
+	}
+	return logs, nil
+}
+
+func (l *influxQLLogs) Histogram(ctx context.Context, q logsQuery, interval time.Duration) ([]logsCount, error) {
+	command := fmt.Sprintf(`SELECT count("message") FROM %s%s GROUP BY time(%s), "severity" fill(none)`,
+		l.from(), l.where(q), influxql.FormatDuration(interval))
+	series, err := queryInfluxQL(ctx, l.ts, l.db, l.rp, command)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []logsCount{}
+	for _, s := range series {
+		for _, row := range s.Values {
+			if len(row) < 2 {
+				continue
+			}
+			ns, _ := strconv.ParseInt(fmt.Sprint(row[0]), 10, 64)
+			n, _ := strconv.ParseInt(fmt.Sprint(row[1]), 10, 64)
+			counts = append(counts, logsCount{
+				Time:     time.Unix(0, ns).UTC(),
+				Severity: s.Tags["severity"],
+				Count:    n,
+			})
+		}
+	}
+	return counts, nil
+}
+
+func (l *influxQLLogs) from() string {
+	return influxql.QuoteIdent(l.db, l.rp, logsMeasurement)
+}
+
+func (l *influxQLLogs) where(q logsQuery) string {
+	conds := []string{fmt.Sprintf("time >= %d AND time < %d", q.Lower.UnixNano(), q.Upper.UnixNano())}
+	for _, t := range logsTags {
+		values := q.Tags[t.tag]
+		if len(values) == 0 {
+			continue
+		}
+		ors := make([]string, len(values))
+		for i, v := range values {
+			ors[i] = fmt.Sprintf("%s = %s", influxql.QuoteIdent(t.tag), influxql.QuoteString(v))
+		}
+		conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+	}
+	if q.Message != "" {
+		conds = append(conds, `"message" =~ `+regexLiteral(q.Message))
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// queryInfluxQL runs the InfluxQL command on ts in the database db and the
+// retention policy rp and returns the series of all of its results, with times
+// in nanoseconds
+func queryInfluxQL(ctx context.Context, ts chronograf.TimeSeries, db, rp, command string) ([]influxQLSeries, error) {
+	response, err := ts.Query(ctx, chronograf.Query{
+		Command: command,
+		DB:      db,
+		RP:      rp,
+		Epoch:   "ns",
+	})
+	if err != nil {
+		return nil, err
+	}
+	octets, err := response.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var results []struct {
+		Series []influxQLSeries `json:"series"`
+		Error  string           `json:"error"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(octets))
+	dec.UseNumber()
+	if err := dec.Decode(&results); err != nil {
+		return nil, err
+	}
+	series := []influxQLSeries{}
+	for _, res := range results {
+		if res.Error != "" {
+			return nil, fmt.Errorf("%s", res.Error)
+		}
+		series = append(series, res.Series...)
+	}
+	return series, nil
+}
+
+// fluxLogs queries logs with Flux
+type fluxLogs struct {
+	client *flux.Client
+	bucket string
+}
+
+func (l *fluxLogs) Logs(ctx context.Context, q logsQuery) ([]logEntry, error) {
+	query := l.from(q) + `
+  |> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`
+	if q.Message != "" {
+		query += `
+  |> filter(fn: (r) => r.message =~ ` + regexLiteral(q.Message) + `)`
+	}
+	query += fmt.Sprintf(`
+  |> group()
+  |> sort(columns: ["_time"], desc: %t)
+  |> limit(n: %d)`, q.Desc, q.Limit)
+	rows, err := l.client.Rows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]logEntry, 0, len(rows))
+	for _, row := range rows {
+		var e logEntry
+		e.Time, _ = time.Parse(time.RFC3339Nano, row["_time"])
+		for _, column := range logsColumns {
+			e.set(column, row[column])
+		}
+		logs = append(logs, e)
+	}
+	return logs, nil
+}
+
+func (l *fluxLogs) Histogram(ctx context.Context, q logsQuery, interval time.Duration) ([]logsCount, error) {
+	query := l.from(q) + `
+  |> filter(fn: (r) => r._field == "message")`
+	if q.Message != "" {
+		query += `
+  |> filter(fn: (r) => r._value =~ ` + regexLiteral(q.Message) + `)`
+	}
+	query += fmt.Sprintf(`
+  |> group(columns: ["severity"])
+  |> aggregateWindow(every: %s, fn: count, createEmpty: false, timeSrc: "_start")`, influxql.FormatDuration(interval))
+	rows, err := l.client.Rows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]logsCount, 0, len(rows))
+	for _, row := range rows {
+		t, _ := time.Parse(time.RFC3339Nano, row["_time"])
+		n, _ := strconv.ParseInt(row["_value"], 10, 64)
+		counts = append(counts, logsCount{Time: t.UTC(), Severity: row["severity"], Count: n})
+	}
+	return counts, nil
+}
+
+func (l *fluxLogs) from(q logsQuery) string {
+	query := fmt.Sprintf(`from(bucket: %s)
+  |> range(start: %s, stop: %s)
+  |> filter(fn: (r) => r._measurement == "%s")`,
+		influx.FluxString(l.bucket),
+		q.Lower.UTC().Format(time.RFC3339Nano),
+		q.Upper.UTC().Format(time.RFC3339Nano),
+		logsMeasurement)
+	for _, t := range logsTags {
+		values := q.Tags[t.tag]
+		if len(values) == 0 {
+			continue
+		}
+		ors := make([]string, len(values))
+		for i, v := range values {
+			ors[i] = fmt.Sprintf(`r.%s == %s`, t.tag, influx.FluxString(v))
+		}
+		query += `
+  |> filter(fn: (r) => ` + strings.Join(ors, " or ") + `)`
+	}
+	return query
+}
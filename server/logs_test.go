@@ -0,0 +1,353 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+const (
+	logsSelect = `SELECT appname, facility, host, hostname, message, procid, severity, timestamp FROM "telegraf"..syslog `
+	logsRange  = `WHERE time >= 1767225600000000000 AND time < 1767229200000000000`
+	logsRows   = `[{"statement_id":0,"series":[{"name":"syslog",
+		"columns":["time","appname","facility","host","hostname","message","procid","severity","timestamp"],
+		"values":[%s]}]}]`
+	logsRow1 = `[1767229140000000000,"sshd","auth","a","a.local","Accepted publickey","42","info",1767229140000000001]`
+	logsRow2 = `[1767229080000000000,"cron","cron","a","a.local","(root) CMD (run-parts)","7","err",null]`
+	logsRow3 = `[1767229080000000000,"cron","cron","b","b.local","(root) CMD (sync)","8","err",null]`
+	logsRow4 = `[1767229020000000000,"kernel","kern","b","b.local","Out of memory","0","crit",null]`
+)
+
+func newLogsTestService(t *testing.T, influxQL map[string]string, fluxURL string) *Service {
+	return &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					switch ID {
+					case 1:
+						return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+					case 2:
+						return chronograf.Source{ID: 2, URL: fluxURL, Type: chronograf.InfluxDBv2, Username: "org"}, nil
+					}
+					return chronograf.Source{}, chronograf.ErrSourceNotFound
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+			QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+				res, ok := influxQL[q.Command]
+				if !ok {
+					t.Errorf("unexpected query %s", q.Command)
+					res = `[{"statement_id":0,"error":"unexpected query"}]`
+				}
+				return mocks.NewResponse(res, nil), nil
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+}
+
+func logsRequestTo(handler http.HandlerFunc, id, query string) (*http.Response, []byte) {
+	r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/"+id+"/logs?"+query, nil)
+	r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{{Key: "id", Value: id}}))
+	w := httptest.NewRecorder()
+	handler(w, r)
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	return resp, body
+}
+
+func TestService_Logs(t *testing.T) {
+	rows := func(values ...string) string {
+		return strings.Replace(logsRows, "%s", strings.Join(values, ","), 1)
+	}
+	older := logsCursor{Time: 1767229080000000000, Skip: 1}
+	influxQL := map[string]string{
+		logsSelect + logsRange + ` ORDER BY time DESC LIMIT 3`: rows(logsRow1, logsRow2, logsRow3),
+		// the older page skips the log of the previous page at the time of the cursor
+		logsSelect + `WHERE time >= 1767225600000000000 AND time < 1767229080000000001 ORDER BY time DESC LIMIT 4`:                                               rows(logsRow2, logsRow3, logsRow4),
+		logsSelect + logsRange + ` AND (severity = 'err' OR severity = 'crit') AND (host = 'b') AND "message" =~ /^\/usr\/bin|CMD/ ORDER BY time DESC LIMIT 101`: rows(logsRow3, logsRow4),
+	}
+	s := newLogsTestService(t, influxQL, "")
+	bounds := "lower=2026-01-01T00:00:00Z&upper=2026-01-01T01:00:00Z"
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		wantStatus int
+		wantLogs   []string // wantLogs are the messages of the logs
+		wantOlder  *logsCursor
+		wantNewer  *logsCursor
+	}{
+		{
+			name:       "first page",
+			id:         "1",
+			query:      bounds + "&limit=2",
+			wantStatus: http.StatusOK,
+			wantLogs:   []string{"Accepted publickey", "(root) CMD (run-parts)"},
+			wantOlder:  &older,
+			wantNewer:  &logsCursor{Time: 1767229140000000000, Skip: 1},
+		},
+		{
+			name:       "older page",
+			id:         "1",
+			query:      bounds + "&limit=2&cursor=" + older.String(),
+			wantStatus: http.StatusOK,
+			wantLogs:   []string{"(root) CMD (sync)", "Out of memory"},
+			wantNewer:  &logsCursor{Time: 1767229080000000000, Skip: 1},
+		},
+		{
+			name:       "filtered",
+			id:         "1",
+			query:      bounds + "&severity=err,crit&host=b&message=" + url.QueryEscape("^/usr/bin|CMD"),
+			wantStatus: http.StatusOK,
+			wantLogs:   []string{"(root) CMD (sync)", "Out of memory"},
+			wantNewer:  &logsCursor{Time: 1767229080000000000, Skip: 1},
+		},
+		{
+			name:       "invalid severity",
+			id:         "1",
+			query:      "severity=fatal",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid message",
+			id:         "1",
+			query:      "message=" + url.QueryEscape("(cron"),
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "message with a trailing backslash",
+			id:         "1",
+			query:      "message=" + url.QueryEscape(`cron\`),
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid range",
+			id:         "1",
+			query:      "lower=2026-01-01T01:00:00Z&upper=2026-01-01T00:00:00Z",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "invalid cursor",
+			id:         "1",
+			query:      "cursor=abc",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown source",
+			id:         "3",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := logsRequestTo(s.Logs, tt.id, tt.query)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var res logsResponse
+			if err := json.Unmarshal(body, &res); err != nil {
+				t.Fatal(err)
+			}
+			messages := []string{}
+			for _, l := range res.Logs {
+				messages = append(messages, l.Message)
+			}
+			if !reflect.DeepEqual(messages, tt.wantLogs) {
+				t.Errorf("logs = %v, want %v", messages, tt.wantLogs)
+			}
+			if got, want := cursorOfLink(t, res.Links.Older), tt.wantOlder; !reflect.DeepEqual(got, want) {
+				t.Errorf("older cursor = %+v, want %+v", got, want)
+			}
+			if got, want := cursorOfLink(t, res.Links.Newer), tt.wantNewer; !reflect.DeepEqual(got, want) {
+				t.Errorf("newer cursor = %+v, want %+v", got, want)
+			}
+			if !strings.HasPrefix(res.Links.Histogram, "/chronograf/v1/sources/1/logs/histogram?") || strings.Contains(res.Links.Histogram, "cursor") {
+				t.Errorf("histogram link = %s", res.Links.Histogram)
+			}
+		})
+	}
+
+	resp, body := logsRequestTo(s.Logs, "1", bounds+"&limit=2")
+	var res struct {
+		Logs []map[string]interface{} `json:"logs"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Logs() = %d, %s", resp.StatusCode, body)
+	}
+	want := map[string]interface{}{
+		"time": "2026-01-01T00:59:00Z", "appname": "sshd", "facility": "auth", "host": "a", "hostname": "a.local",
+		"message": "Accepted publickey", "procid": "42", "severity": "info", "timestamp": float64(1767229140000000001),
+	}
+	if !reflect.DeepEqual(res.Logs[0], want) {
+		t.Errorf("log = %v, want %v", res.Logs[0], want)
+	}
+}
+
+func cursorOfLink(t *testing.T, link string) *logsCursor {
+	if link == "" {
+		return nil
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := parseLogsCursor(u.Query().Get("cursor"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &c
+}
+
+func TestService_LogsHistogram(t *testing.T) {
+	influxQL := map[string]string{
+		`SELECT count("message") FROM "telegraf"..syslog ` + logsRange + ` GROUP BY time(1m), "severity" fill(none)`: `[{"statement_id":0,"series":[
+			{"name":"syslog","tags":{"severity":"err"},"columns":["time","count"],"values":[[1767229020000000000,2],[1767229080000000000,3]]},
+			{"name":"syslog","tags":{"severity":"info"},"columns":["time","count"],"values":[[1767229080000000000,1]]}
+		]}]`,
+	}
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		want := `from(bucket: "telegraf")
+  |> range(start: 2026-01-01T00:00:00Z, stop: 2026-01-01T01:00:00Z)
+  |> filter(fn: (r) => r._measurement == "syslog")
+  |> filter(fn: (r) => r.appname == "cron")
+  |> filter(fn: (r) => r._field == "message")
+  |> group(columns: ["severity"])
+  |> aggregateWindow(every: 1m, fn: count, createEmpty: false, timeSrc: "_start")`
+		if req.Query != want {
+			t.Errorf("flux query =\n%s\nwant\n%s", req.Query, want)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("#datatype,string,long,string,dateTime:RFC3339,long\r\n" +
+			"#group,false,false,true,false,false\r\n" +
+			"#default,_result,,,,\r\n" +
+			",result,table,severity,_time,_value\r\n" +
+			",,0,err,2026-01-01T00:57:00Z,2\r\n" +
+			",,0,err,2026-01-01T00:58:00Z,3\r\n" +
+			",,1,info,2026-01-01T00:58:00Z,1\r\n\r\n"))
+	}))
+	defer v2.Close()
+	s := newLogsTestService(t, influxQL, v2.URL)
+
+	wantBody := `{"interval":"1m","buckets":[
+		{"time":"2026-01-01T00:57:00Z","total":2,"counts":{"err":2}},
+		{"time":"2026-01-01T00:58:00Z","total":4,"counts":{"err":3,"info":1}}
+	]}`
+	for _, tt := range []struct{ id, query string }{
+		{"1", ""},
+		{"2", "&appname=cron"},
+	} {
+		resp, body := logsRequestTo(s.LogsHistogram, tt.id, "lower=2026-01-01T00:00:00Z&upper=2026-01-01T01:00:00Z"+tt.query)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("source %s: status = %d: %s", tt.id, resp.StatusCode, body)
+		}
+		if eq, _ := jsonEqual(string(body), wantBody); !eq {
+			t.Errorf("source %s: body =\n%s\nwant\n%s", tt.id, body, wantBody)
+		}
+	}
+
+	if resp, _ := logsRequestTo(s.LogsHistogram, "1", "lower=2026-01-01T00:00:00Z&upper=2026-01-02T00:00:00Z&interval=1s"); resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status of too many buckets = %d, want %d", resp.StatusCode, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestService_TailLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	queries := []string{}
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+			QueryF: func(_ context.Context, q chronograf.Query) (chronograf.Response, error) {
+				queries = append(queries, q.Command)
+				if len(queries) > 1 {
+					cancel()
+					return mocks.NewResponse(`[{"statement_id":0}]`, nil), nil
+				}
+				return mocks.NewResponse(strings.Replace(logsRows, "%s", logsRow2+","+logsRow1, 1), nil), nil
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+
+	cursor := logsCursor{Time: 1767229020000000000}
+	r := httptest.NewRequest("GET", "http://any.url/chronograf/v1/sources/1/logs/tail?every=1s", nil)
+	r.Header.Set("Last-Event-ID", cursor.String())
+	r = r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}}))
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		s.TailLogs(w, r)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("TailLogs() did not end when the request was canceled")
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %s", ct)
+	}
+	newest := logsCursor{Time: 1767229140000000000, Skip: 1}
+	want := "id: " + newest.String() + "\nevent: logs\ndata: "
+	if body := w.Body.String(); !strings.HasPrefix(body, want) || !strings.Contains(body, `"message":"Accepted publickey"`) {
+		t.Errorf("events =\n%s\nwant prefix\n%s", body, want)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("queries = %v", queries)
+	}
+	if !strings.Contains(queries[0], "time >= 1767229020000000000 AND") || !strings.Contains(queries[0], "ORDER BY time ASC LIMIT 101") {
+		t.Errorf("first query = %s", queries[0])
+	}
+	if !strings.Contains(queries[1], "time >= 1767229140000000000 AND") || !strings.Contains(queries[1], "ORDER BY time ASC LIMIT 102") {
+		t.Errorf("second query = %s", queries[1])
+	}
+}
+
+func Test_regexLiteral(t *testing.T) {
+	tests := []struct {
+		re   string
+		want string
+	}{
+		{re: `^/usr/bin`, want: `/^\/usr\/bin/`},
+		{re: `a\/b`, want: `/a\/b/`},
+		{re: `\d+`, want: `/\d+/`},
+		{re: `cron\`, want: `/cron\\/`},
+	}
+	for _, tt := range tests {
+		if got := regexLiteral(tt.re); got != tt.want {
+			t.Errorf("regexLiteral(%q) = %s, want %s", tt.re, got, tt.want)
+		}
+	}
+}
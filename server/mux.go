@@ -191,6 +191,13 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.DELETE("/chronograf/v1/sources/:id", EnsureEditor(service.RemoveSource))
 	router.GET("/chronograf/v1/sources/:id/health", EnsureReader(service.SourceHealth))
 
+	// InfluxDB Enterprise cluster management
+	router.GET("/chronograf/v1/sources/:id/cluster", EnsureAdmin(service.Cluster))
+	router.GET("/chronograf/v1/sources/:id/cluster/shards", EnsureAdmin(service.ClusterShards))
+	router.POST("/chronograf/v1/sources/:id/cluster/shards/:sid/copy", EnsureAdmin(service.CopyClusterShard))
+	router.DELETE("/chronograf/v1/sources/:id/cluster/shards/:sid/owners/:nid", EnsureAdmin(service.RemoveClusterShard))
+	router.POST("/chronograf/v1/sources/:id/cluster/truncate", EnsureAdmin(service.TruncateClusterShards))
+
 	// Flux
 	router.GET("/chronograf/v1/flux", EnsureReader(service.Flux))
 	router.POST("/chronograf/v1/flux/ast", EnsureReader(service.FluxAST))
@@ -223,8 +230,21 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 		),
 	)
 
-	// Write proxies line protocol write requests to InfluxDB
+	// Write proxies line protocol write requests to InfluxDB. Writes and imports
+	// require the viewer role or higher: readers are read-only, whatever the
+	// credentials of the source allow.
 	router.POST("/chronograf/v1/sources/:id/write", EnsureViewer(service.Write))
+	router.POST("/chronograf/v1/sources/:id/import", EnsureViewer(service.Import))
+
+	// Export downloads the results of a query as CSV, JSON Lines or line protocol
+	router.POST("/chronograf/v1/sources/:id/export", EnsureReader(service.Export))
+
+	// Logs searches, counts and tails the syslog messages written to a source
+	router.GET("/chronograf/v1/sources/:id/logs", EnsureReader(service.Logs))
+	router.GET("/chronograf/v1/sources/:id/logs/histogram", EnsureReader(service.LogsHistogram))
+	router.GET("/chronograf/v1/sources/:id/logs/tail", EnsureReader(service.TailLogs))
+	router.GET("/chronograf/v1/sources/:id/live", EnsureReader(service.LiveQuery))
+	router.GET("/chronograf/v1/sources/:id/alerts", EnsureReader(service.AlertHistory))
 
 	// Queries is used to analyze a specific queries and does not create any
 	// resources. It's a POST because Queries are POSTed to InfluxDB, but this
@@ -241,6 +261,13 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 		),
 	)
 
+	// Running queries of InfluxDB 1.x sources, which admins may kill
+	router.GET("/chronograf/v1/sources/:id/queries/running", EnsureAdmin(service.RunningQueries))
+	router.DELETE("/chronograf/v1/sources/:id/queries/running/:qid", EnsureAdmin(service.KillQuery))
+
+	// Inspect executes a query and reports its cost, query plan or profile
+	router.POST("/chronograf/v1/sources/:id/inspect", EnsureViewer(service.InspectQuery))
+
 	// Annotations are user-defined events associated with this source
 	router.GET("/chronograf/v1/sources/:id/annotations", EnsureReader(service.Annotations))
 	router.POST("/chronograf/v1/sources/:id/annotations", EnsureEditor(service.NewAnnotation))
@@ -297,6 +324,11 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.PATCH("/chronograf/v1/sources/:id/kapacitors/:kid/rules/:tid", EnsureEditor(service.KapacitorRulesStatus))
 	router.DELETE("/chronograf/v1/sources/:id/kapacitors/:kid/rules/:tid", EnsureEditor(service.KapacitorRulesDelete))
 
+	// Kapacitor alert topics
+	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/alerts/topics", EnsureViewer(service.KapacitorTopics))
+	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/alerts/topics/:topic/events", EnsureViewer(service.KapacitorTopicEvents))
+	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/alerts/topics/:topic/handlers", EnsureViewer(service.KapacitorTopicHandlers))
+
 	// Kapacitor Proxy
 	router.GET("/chronograf/v1/sources/:id/kapacitors/:kid/proxy", EnsureViewer(service.ProxyGet))
 	router.POST("/chronograf/v1/sources/:id/kapacitors/:kid/proxy", EnsureEditor(service.ProxyPost))
@@ -306,10 +338,21 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// Layouts
 	router.GET("/chronograf/v1/layouts", EnsureViewer(service.Layouts))
 	router.GET("/chronograf/v1/layouts/:id", EnsureViewer(service.LayoutsID))
+	router.POST("/chronograf/v1/layouts", EnsureSuperAdmin(service.NewLayout))
+	router.PUT("/chronograf/v1/layouts/:id", EnsureSuperAdmin(service.UpdateLayout))
+	router.DELETE("/chronograf/v1/layouts/:id", EnsureSuperAdmin(service.RemoveLayout))
 
 	// Protoboards
 	router.GET("/chronograf/v1/protoboards", EnsureViewer(service.Protoboards))
 	router.GET("/chronograf/v1/protoboards/:id", EnsureViewer(service.ProtoboardsID))
+	router.POST("/chronograf/v1/protoboards/:id/instantiate", EnsureEditor(service.InstantiateProtoboard))
+
+	// Packages of protoboards and layouts installed from the catalog
+	router.GET("/chronograf/v1/packages", EnsureViewer(service.Packages))
+	router.POST("/chronograf/v1/packages/:name", EnsureAdmin(service.InstallPackage))
+	router.PUT("/chronograf/v1/packages/:name", EnsureAdmin(service.UpgradePackage))
+	router.DELETE("/chronograf/v1/packages/:name", EnsureAdmin(service.UninstallPackage))
+	router.GET("/chronograf/v1/packages/:name/diff", EnsureViewer(service.PackageDiff))
 
 	// Users associated with Chronograf
 	router.GET("/chronograf/v1/me", service.Me)
@@ -340,6 +383,13 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.DELETE("/chronograf/v1/dashboards/:id", EnsureEditor(service.RemoveDashboard))
 	router.PUT("/chronograf/v1/dashboards/:id", EnsureEditor(service.ReplaceDashboard))
 	router.PATCH("/chronograf/v1/dashboards/:id", EnsureEditor(service.UpdateDashboard))
+	router.GET("/chronograf/v1/dashboards/:id/lint", EnsureViewer(service.DashboardLint))
+	router.POST("/chronograf/v1/dashboards/:id/inspect", EnsureViewer(service.InspectDashboard))
+
+	// Dashboards synced from definition files
+	router.GET("/chronograf/v1/sync/dashboards", EnsureViewer(service.DashboardsSyncStatus))
+	router.POST("/chronograf/v1/sync/dashboards", EnsureSuperAdmin(service.SyncDashboards))
+
 	// Dashboard Cells
 	router.GET("/chronograf/v1/dashboards/:id/cells", EnsureReader(service.DashboardCells))
 	router.POST("/chronograf/v1/dashboards/:id/cells", EnsureEditor(service.NewDashboardCell))
@@ -348,6 +398,24 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.DELETE("/chronograf/v1/dashboards/:id/cells/:cid", EnsureEditor(service.RemoveDashboardCell))
 	router.PUT("/chronograf/v1/dashboards/:id/cells/:cid", EnsureEditor(service.ReplaceDashboardCell))
 
+	// Dashboard Permissions
+	router.GET("/chronograf/v1/dashboards/:id/permissions", EnsureReader(service.DashboardPermissions))
+	router.PUT("/chronograf/v1/dashboards/:id/permissions", EnsureEditor(service.ReplaceDashboardPermissions))
+	router.DELETE("/chronograf/v1/dashboards/:id/permissions", EnsureEditor(service.RemoveDashboardPermissions))
+
+	// Dashboard Shares
+	router.GET("/chronograf/v1/dashboards/:id/shares", EnsureEditor(service.DashboardShares))
+	router.POST("/chronograf/v1/dashboards/:id/shares", EnsureEditor(service.NewDashboardShare))
+	router.DELETE("/chronograf/v1/dashboards/:id/shares/:sid", EnsureEditor(service.RemoveDashboardShare))
+
+	// Shared dashboards are authorized by the share token in the path instead of a session
+	router.GET("/chronograf/v1/shared/:token", service.SharedDashboard)
+	router.Handler(
+		"GET",
+		"/chronograf/v1/shared/:token/cells/:cid/queries/:qid",
+		gziphandler.GzipHandler(http.HandlerFunc(service.SharedDashboardQuery)),
+	)
+
 	// Dashboard Templates
 	router.GET("/chronograf/v1/dashboards/:id/templates", EnsureViewer(service.Templates))
 	router.POST("/chronograf/v1/dashboards/:id/templates", EnsureEditor(service.NewTemplate))
@@ -356,6 +424,8 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	router.DELETE("/chronograf/v1/dashboards/:id/templates/:tid", EnsureEditor(service.RemoveTemplate))
 	router.PUT("/chronograf/v1/dashboards/:id/templates/:tid", EnsureEditor(service.ReplaceTemplate))
 
+	router.POST("/chronograf/v1/dashboards/:id/templates/resolve", EnsureViewer(service.ResolveTemplates))
+
 	// Databases
 	router.GET("/chronograf/v1/sources/:id/dbs", EnsureViewer(service.GetDatabases))
 	router.POST("/chronograf/v1/sources/:id/dbs", EnsureEditor(service.NewDatabase))
@@ -372,6 +442,55 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 	// Measurements
 	router.GET("/chronograf/v1/sources/:id/dbs/:db/measurements", EnsureViewer(service.Measurements))
 
+	// Continuous Queries
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/cqs", EnsureViewer(service.ContinuousQueries))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/cqs", EnsureEditor(service.NewContinuousQuery))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/cqs/:cq", EnsureEditor(service.DropContinuousQuery))
+
+	// Subscriptions
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/subscriptions", EnsureViewer(service.Subscriptions))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/subscriptions", EnsureEditor(service.NewSubscription))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/subscriptions/:rp/:name", EnsureEditor(service.DropSubscription))
+
+	// Series cardinality of a database
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/cardinality", EnsureViewer(service.Cardinality))
+
+	// Protoboards and layouts of the measurements written to a source
+	router.GET("/chronograf/v1/sources/:id/protoboards/suggest", EnsureViewer(service.SuggestProtoboards))
+
+	// InfluxDB 3 tables and caches
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/tables", EnsureEditor(service.NewTable))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/tables/:table", EnsureEditor(service.DropTable))
+	router.GET("/chronograf/v1/sources/:id/dbs/:db/caches", EnsureViewer(service.DatabaseCaches))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/tables/:table/last_caches", EnsureEditor(service.NewLastValueCache))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/tables/:table/last_caches/:name", EnsureEditor(service.DropLastValueCache))
+	router.POST("/chronograf/v1/sources/:id/dbs/:db/tables/:table/distinct_caches", EnsureEditor(service.NewDistinctValueCache))
+	router.DELETE("/chronograf/v1/sources/:id/dbs/:db/tables/:table/distinct_caches/:name", EnsureEditor(service.DropDistinctValueCache))
+
+	// InfluxDB v2 buckets, DBRP mappings, API tokens and tasks
+	router.GET("/chronograf/v1/sources/:id/v2/buckets", EnsureViewer(service.Buckets))
+	router.POST("/chronograf/v1/sources/:id/v2/buckets", EnsureEditor(service.NewBucket))
+	router.PATCH("/chronograf/v1/sources/:id/v2/buckets/:bid", EnsureEditor(service.UpdateBucket))
+	router.DELETE("/chronograf/v1/sources/:id/v2/buckets/:bid", EnsureEditor(service.RemoveBucket))
+
+	router.GET("/chronograf/v1/sources/:id/v2/dbrps", EnsureViewer(service.DBRPs))
+	router.POST("/chronograf/v1/sources/:id/v2/dbrps", EnsureEditor(service.NewDBRP))
+	router.PATCH("/chronograf/v1/sources/:id/v2/dbrps/:did", EnsureEditor(service.UpdateDBRP))
+	router.DELETE("/chronograf/v1/sources/:id/v2/dbrps/:did", EnsureEditor(service.RemoveDBRP))
+
+	router.GET("/chronograf/v1/sources/:id/v2/authorizations", EnsureAdmin(service.Authorizations))
+	router.POST("/chronograf/v1/sources/:id/v2/authorizations", EnsureAdmin(service.NewAuthorization))
+	router.PATCH("/chronograf/v1/sources/:id/v2/authorizations/:aid", EnsureAdmin(service.UpdateAuthorization))
+	router.DELETE("/chronograf/v1/sources/:id/v2/authorizations/:aid", EnsureAdmin(service.RemoveAuthorization))
+
+	router.GET("/chronograf/v1/sources/:id/v2/tasks", EnsureViewer(service.Tasks))
+	router.POST("/chronograf/v1/sources/:id/v2/tasks", EnsureEditor(service.NewTask))
+	router.GET("/chronograf/v1/sources/:id/v2/tasks/:tid", EnsureViewer(service.TaskID))
+	router.PATCH("/chronograf/v1/sources/:id/v2/tasks/:tid", EnsureEditor(service.UpdateTask))
+	router.DELETE("/chronograf/v1/sources/:id/v2/tasks/:tid", EnsureEditor(service.RemoveTask))
+	router.GET("/chronograf/v1/sources/:id/v2/tasks/:tid/runs", EnsureViewer(service.TaskRuns))
+	router.GET("/chronograf/v1/sources/:id/v2/tasks/:tid/runs/:rid/logs", EnsureViewer(service.TaskRunLogs))
+
 	// Global application config for Chronograf
 	router.GET("/chronograf/v1/config", EnsureSuperAdmin(service.Config))
 	router.GET("/chronograf/v1/config/auth", EnsureSuperAdmin(service.AuthConfig))
@@ -413,7 +532,7 @@ func NewMux(opts MuxOpts, service Service) http.Handler {
 		router.GET("/oauth/logout", logout("/", opts.Basepath, allRoutes.AuthRoutes))
 		out = auth
 	} else if opts.BasicAuth != nil {
-		out = BasicAuthWrapper(router, opts.BasicAuth)
+		out = BasicAuthWrapper(router, opts.BasicAuth, opts.Basepath)
 	} else {
 		out = router
 	}
@@ -461,6 +580,10 @@ func AuthAPI(opts MuxOpts, router chronograf.Router) (http.Handler, AuthRoutes)
 	// Wrap the API with token validation middleware.
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		cleanPath := path.Clean(r.URL.Path) // compare ignoring path garbage, trailing slashes, etc.
+		if isSharedPath(cleanPath, opts.Basepath) {
+			router.ServeHTTP(w, r)
+			return
+		}
 		if (strings.HasPrefix(cleanPath, rootPath) && len(cleanPath) > len(rootPath)) || cleanPath == logoutPath {
 			tokenMiddleware.ServeHTTP(w, r)
 			return
@@ -469,11 +592,19 @@ func AuthAPI(opts MuxOpts, router chronograf.Router) (http.Handler, AuthRoutes)
 	}), routes
 }
 
-// BasicAuthWrapper returns http handlers that wraps the supplied handler with HTTP Basic authentication
-func BasicAuthWrapper(router chronograf.Router, auth *basicAuth.BasicAuth) http.Handler {
-	return auth.Wrap(func(response http.ResponseWriter, authRequest *basicAuth.AuthenticatedRequest) {
+// BasicAuthWrapper returns http handlers that wraps the supplied handler with HTTP Basic authentication.
+// Shared dashboards are authorized by their share token and are served without authentication.
+func BasicAuthWrapper(router chronograf.Router, auth *basicAuth.BasicAuth, basepath string) http.Handler {
+	wrapped := auth.Wrap(func(response http.ResponseWriter, authRequest *basicAuth.AuthenticatedRequest) {
 		router.ServeHTTP(response, &authRequest.Request)
 	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isSharedPath(path.Clean(r.URL.Path), basepath) {
+			router.ServeHTTP(w, r)
+			return
+		}
+		wrapped.ServeHTTP(w, r)
+	})
 
 }
 
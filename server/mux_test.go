@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/oauth2"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestNewMux_WritePolicy(t *testing.T) {
+	paths := []string{
+		"/chronograf/v1/sources/1/write",
+		"/chronograf/v1/sources/1/import",
+	}
+	tests := []struct {
+		role      string
+		forbidden bool
+	}{
+		{role: roles.MemberRoleName, forbidden: true},
+		{role: roles.ReaderRoleName, forbidden: true},
+		{role: roles.ViewerRoleName},
+		{role: roles.EditorRoleName},
+		{role: roles.AdminRoleName},
+	}
+	for _, tt := range tests {
+		store := &mocks.Store{
+			UsersStore: &mocks.UsersStore{
+				GetF: func(ctx context.Context, q chronograf.UserQuery) (*chronograf.User, error) {
+					return &chronograf.User{
+						ID:       1337,
+						Name:     "billysteve",
+						Provider: "google",
+						Scheme:   "oauth2",
+						Roles: []chronograf.Role{
+							{
+								Name:         tt.role,
+								Organization: "1337",
+							},
+						},
+					}, nil
+				},
+			},
+			OrganizationsStore: &mocks.OrganizationsStore{
+				DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+					return &chronograf.Organization{
+						ID: "0",
+					}, nil
+				},
+				GetF: func(ctx context.Context, q chronograf.OrganizationQuery) (*chronograf.Organization, error) {
+					return &chronograf.Organization{
+						ID:   "1337",
+						Name: "The ShillBillThrilliettas",
+					}, nil
+				},
+			},
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+					return chronograf.Source{}, chronograf.ErrSourceNotFound
+				},
+			},
+		}
+		logger := log.New(log.DebugLevel)
+		mux := NewMux(MuxOpts{
+			Logger:  logger,
+			UseAuth: true,
+			Auth: &mocks.Authenticator{
+				Principal: oauth2.Principal{
+					Subject:      "billysteve",
+					Issuer:       "google",
+					Organization: "1337",
+				},
+			},
+		}, Service{
+			Store:  store,
+			Logger: logger,
+		})
+
+		for _, path := range paths {
+			t.Run(tt.role+" "+path, func(t *testing.T) {
+				r := httptest.NewRequest("POST", path+"?db=telegraf", strings.NewReader("cpu value=1"))
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, r)
+
+				if forbidden := w.Code == http.StatusForbidden; forbidden != tt.forbidden {
+					t.Errorf("status = %d, want forbidden %v: %s", w.Code, tt.forbidden, w.Body.String())
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
+)
+
+type packageLinks struct {
+	Self string `json:"self"`
+	Diff string `json:"diff"`
+}
+
+// packageResponse is a package of the catalog or installed in the
+// organization
+type packageResponse struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Versions    []string          `json:"versions"`            // Versions are the versions of the catalog, the latest first
+	Latest      string            `json:"latest,omitempty"`    // Latest is the latest version of the catalog
+	Installed   *installedVersion `json:"installed,omitempty"` // Installed is set if the package is installed in the organization
+	Upgradable  bool              `json:"upgradable"`          // Upgradable is true if the latest version is newer than the installed one
+	Links       packageLinks      `json:"links"`
+}
+
+type installedVersion struct {
+	Version     string    `json:"version"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+type packagesResponse struct {
+	Packages []packageResponse `json:"packages"`
+}
+
+type installedPackageResponse struct {
+	chronograf.InstalledPackage
+	Links packageLinks `json:"links"`
+}
+
+func newPackageLinks(name string) packageLinks {
+	self := fmt.Sprintf("/chronograf/v1/packages/%s", url.PathEscape(name))
+	return packageLinks{
+		Self: self,
+		Diff: self + "/diff",
+	}
+}
+
+func newInstalledPackageResponse(pkg chronograf.InstalledPackage) installedPackageResponse {
+	return installedPackageResponse{
+		InstalledPackage: pkg,
+		Links:            newPackageLinks(pkg.Manifest.Name),
+	}
+}
+
+// PackageRequest selects the version of a package to install or to upgrade to.
+// An empty version is the latest version of the catalog.
+type PackageRequest struct {
+	Version string `json:"version"`
+}
+
+// Packages lists the packages of the catalog and the packages installed in
+// the organization
+func (s *Service) Packages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, ok := hasOrganizationContext(ctx)
+	if !ok {
+		Error(w, http.StatusBadRequest, "Organization not found on context", s.Logger)
+		return
+	}
+
+	installed, err := s.Store.Packages(ctx).All(ctx, orgID)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	byName := map[string]chronograf.InstalledPackage{}
+	for _, pkg := range installed {
+		byName[pkg.Manifest.Name] = pkg
+	}
+
+	res := packagesResponse{Packages: []packageResponse{}}
+	if s.Catalog != nil {
+		idx, err := s.Catalog.Index(ctx)
+		if err != nil {
+			msg := fmt.Sprintf("Unable to read the package catalog: %v", err)
+			Error(w, http.StatusBadGateway, msg, s.Logger)
+			return
+		}
+		for _, p := range idx.Packages {
+			pkg := packageResponse{
+				Name:        p.Name,
+				Description: p.Description,
+				Versions:    []string{},
+				Links:       newPackageLinks(p.Name),
+			}
+			for _, v := range p.Sorted() {
+				pkg.Versions = append(pkg.Versions, v.Version)
+			}
+			if latest, ok := p.Latest(); ok {
+				pkg.Latest = latest.Version
+			}
+			if inst, ok := byName[p.Name]; ok {
+				delete(byName, p.Name)
+				pkg.Installed = &installedVersion{
+					Version:     inst.Manifest.Version,
+					InstalledAt: inst.InstalledAt,
+				}
+				pkg.Upgradable = newerVersion(pkg.Latest, inst.Manifest.Version)
+			}
+			res.Packages = append(res.Packages, pkg)
+		}
+	}
+
+	// packages installed from a catalog that no longer lists them
+	for _, inst := range byName {
+		res.Packages = append(res.Packages, packageResponse{
+			Name:        inst.Manifest.Name,
+			Description: inst.Manifest.Description,
+			Versions:    []string{},
+			Installed: &installedVersion{
+				Version:     inst.Manifest.Version,
+				InstalledAt: inst.InstalledAt,
+			},
+			Links: newPackageLinks(inst.Manifest.Name),
+		})
+	}
+	sort.SliceStable(res.Packages, func(i, j int) bool {
+		return res.Packages[i].Name < res.Packages[j].Name
+	})
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// InstallPackage installs a version of a package of the catalog in the
+// organization
+func (s *Service) InstallPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, ok := hasOrganizationContext(ctx)
+	if !ok {
+		Error(w, http.StatusBadRequest, "Organization not found on context", s.Logger)
+		return
+	}
+	name := httprouter.GetParamFromContext(ctx, "name")
+	var req PackageRequest
+	if err := decodePackageRequest(r, &req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	store := s.Store.Packages(ctx)
+	if _, err := store.Get(ctx, orgID, name); err == nil {
+		msg := fmt.Sprintf("package %s is already installed", name)
+		Error(w, http.StatusConflict, msg, s.Logger)
+		return
+	} else if err != chronograf.ErrPackageNotFound {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+
+	pkg, ok := s.catalogPackage(ctx, w, name, req.Version)
+	if !ok {
+		return
+	}
+	inst := chronograf.InstalledPackage{
+		Package:      pkg,
+		Organization: orgID,
+		InstalledAt:  time.Now().UTC(),
+	}
+	if err := store.Put(ctx, inst); err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+
+	res := newInstalledPackageResponse(inst)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// UpgradePackage replaces a package installed in the organization with a
+// newer version of the catalog
+func (s *Service) UpgradePackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, ok := hasOrganizationContext(ctx)
+	if !ok {
+		Error(w, http.StatusBadRequest, "Organization not found on context", s.Logger)
+		return
+	}
+	name := httprouter.GetParamFromContext(ctx, "name")
+	var req PackageRequest
+	if err := decodePackageRequest(r, &req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	store := s.Store.Packages(ctx)
+	installed, ok := s.installedPackage(ctx, w, orgID, name)
+	if !ok {
+		return
+	}
+	pkg, ok := s.catalogPackage(ctx, w, name, req.Version)
+	if !ok {
+		return
+	}
+	if !newerVersion(pkg.Manifest.Version, installed.Manifest.Version) {
+		msg := fmt.Errorf("version %s of package %s is not newer than the installed version %s", pkg.Manifest.Version, name, installed.Manifest.Version)
+		invalidData(w, msg, s.Logger)
+		return
+	}
+
+	inst := chronograf.InstalledPackage{
+		Package:      pkg,
+		Organization: orgID,
+		InstalledAt:  time.Now().UTC(),
+	}
+	if err := store.Put(ctx, inst); err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, newInstalledPackageResponse(inst), s.Logger)
+}
+
+// UninstallPackage removes a package and its protoboards and layouts from the
+// organization
+func (s *Service) UninstallPackage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, ok := hasOrganizationContext(ctx)
+	if !ok {
+		Error(w, http.StatusBadRequest, "Organization not found on context", s.Logger)
+		return
+	}
+	name := httprouter.GetParamFromContext(ctx, "name")
+
+	installed, ok := s.installedPackage(ctx, w, orgID, name)
+	if !ok {
+		return
+	}
+	if err := s.Store.Packages(ctx).Delete(ctx, installed); err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PackageDiff compares the protoboards and layouts of the installed version
+// of a package with a version of the catalog, by default the latest one. The
+// installed version is empty if the package is not installed.
+func (s *Service) PackageDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, ok := hasOrganizationContext(ctx)
+	if !ok {
+		Error(w, http.StatusBadRequest, "Organization not found on context", s.Logger)
+		return
+	}
+	name := httprouter.GetParamFromContext(ctx, "name")
+
+	installed, err := s.Store.Packages(ctx).Get(ctx, orgID, name)
+	if err != nil && err != chronograf.ErrPackageNotFound {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	pkg, ok := s.catalogPackage(ctx, w, name, r.URL.Query().Get("version"))
+	if !ok {
+		return
+	}
+	encodeJSON(w, http.StatusOK, catalog.NewDiff(installed.Package, pkg), s.Logger)
+}
+
+// installedPackage writes a not found error if the package is not installed
+// in the organization
+func (s *Service) installedPackage(ctx context.Context, w http.ResponseWriter, orgID, name string) (chronograf.InstalledPackage, bool) {
+	pkg, err := s.Store.Packages(ctx).Get(ctx, orgID, name)
+	if err == chronograf.ErrPackageNotFound {
+		Error(w, http.StatusNotFound, fmt.Sprintf("package %s is not installed", name), s.Logger)
+		return pkg, false
+	}
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return pkg, false
+	}
+	return pkg, true
+}
+
+// catalogPackage reads a version of a package from the catalog and writes
+// the error if it is unavailable
+func (s *Service) catalogPackage(ctx context.Context, w http.ResponseWriter, name, version string) (chronograf.Package, bool) {
+	if s.Catalog == nil {
+		invalidData(w, fmt.Errorf("no package catalog is configured"), s.Logger)
+		return chronograf.Package{}, false
+	}
+	if version != "" {
+		if _, err := catalog.ParseVersion(version); err != nil {
+			invalidData(w, err, s.Logger)
+			return chronograf.Package{}, false
+		}
+	}
+	pkg, err := s.Catalog.Package(ctx, name, version)
+	if err == chronograf.ErrPackageNotFound {
+		msg := fmt.Errorf("package %s %s is not in the catalog", name, version)
+		if version == "" {
+			msg = fmt.Errorf("package %s is not in the catalog", name)
+		}
+		invalidData(w, msg, s.Logger)
+		return pkg, false
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Unable to read package %s from the catalog: %v", name, err)
+		Error(w, http.StatusBadGateway, msg, s.Logger)
+		return pkg, false
+	}
+	return pkg, true
+}
+
+// decodePackageRequest decodes an optional package request
+func decodePackageRequest(r *http.Request, req *PackageRequest) error {
+	if r.Body == nil {
+		return nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// newerVersion is true if the semantic version a follows b
+func newerVersion(a, b string) bool {
+	va, err := catalog.ParseVersion(a)
+	if err != nil {
+		return false
+	}
+	vb, err := catalog.ParseVersion(b)
+	if err != nil {
+		return true
+	}
+	return va.Compare(vb) > 0
+}
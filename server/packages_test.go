@@ -0,0 +1,348 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/organizations"
+)
+
+func newPackagesCatalog() *httptest.Server {
+	files := map[string]string{
+		"/index.json": `{"packages": [
+			{"name": "system", "description": "Hosts", "versions": [
+				{"version": "1.0.0", "path": "system-1.0.0.json"},
+				{"version": "1.1.0", "path": "system-1.1.0.json"}
+			]},
+			{"name": "docker", "versions": [{"version": "0.1.0", "path": "docker-0.1.0.json"}]}
+		]}`,
+		"/system-1.0.0.json": `{
+			"manifest": {"name": "system", "version": "1.0.0"},
+			"protoboards": [{"id": "system", "meta": {"name": "System"}}],
+			"layouts": [{"id": "cpu", "app": "system", "measurement": "cpu"}]
+		}`,
+		"/system-1.1.0.json": `{
+			"manifest": {"name": "system", "version": "1.1.0"},
+			"protoboards": [{"id": "system", "meta": {"name": "System", "measurements": ["cpu"]}}]
+		}`,
+		"/docker-0.1.0.json": `{"manifest": {"name": "docker", "version": "0.1.0"}}`,
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(b))
+	}))
+}
+
+// memPackages is a PackagesStore of the packages of an organization
+func memPackages(pkgs map[string]chronograf.InstalledPackage) *mocks.PackagesStore {
+	return &mocks.PackagesStore{
+		AllF: func(ctx context.Context, orgID string) ([]chronograf.InstalledPackage, error) {
+			all := []chronograf.InstalledPackage{}
+			for _, p := range pkgs {
+				if p.Organization == orgID {
+					all = append(all, p)
+				}
+			}
+			return all, nil
+		},
+		GetF: func(ctx context.Context, orgID, name string) (chronograf.InstalledPackage, error) {
+			p, ok := pkgs[name]
+			if !ok || p.Organization != orgID {
+				return chronograf.InstalledPackage{}, chronograf.ErrPackageNotFound
+			}
+			return p, nil
+		},
+		PutF: func(ctx context.Context, p chronograf.InstalledPackage) error {
+			pkgs[p.Manifest.Name] = p
+			return nil
+		},
+		DeleteF: func(ctx context.Context, p chronograf.InstalledPackage) error {
+			delete(pkgs, p.Manifest.Name)
+			return nil
+		},
+	}
+}
+
+func TestService_Packages(t *testing.T) {
+	ts := newPackagesCatalog()
+	defer ts.Close()
+
+	installedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	installed := func() map[string]chronograf.InstalledPackage {
+		return map[string]chronograf.InstalledPackage{
+			"system": {
+				Package: chronograf.Package{
+					Manifest: chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+					Protoboards: []chronograf.Protoboard{
+						{ID: "system", Meta: chronograf.ProtoboardMeta{Name: "System"}},
+					},
+				},
+				Organization: "default",
+				InstalledAt:  installedAt,
+			},
+			"nginx": {
+				Package:      chronograf.Package{Manifest: chronograf.PackageManifest{Name: "nginx", Version: "2.0.0"}},
+				Organization: "default",
+				InstalledAt:  installedAt,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		pkg        string
+		path       string
+		body       string
+		catalog    string
+		wantStatus int
+		wantBody   string
+		want       map[string]string // want are the installed versions after the request
+	}{
+		{
+			name:       "list",
+			method:     "GET",
+			catalog:    ts.URL,
+			wantStatus: http.StatusOK,
+			wantBody: `{"packages":[
+				{"name":"docker","versions":["0.1.0"],"latest":"0.1.0","upgradable":false,
+					"links":{"self":"/chronograf/v1/packages/docker","diff":"/chronograf/v1/packages/docker/diff"}},
+				{"name":"nginx","versions":[],"installed":{"version":"2.0.0","installedAt":"2026-01-02T03:04:05Z"},"upgradable":false,
+					"links":{"self":"/chronograf/v1/packages/nginx","diff":"/chronograf/v1/packages/nginx/diff"}},
+				{"name":"system","description":"Hosts","versions":["1.1.0","1.0.0"],"latest":"1.1.0",
+					"installed":{"version":"1.0.0","installedAt":"2026-01-02T03:04:05Z"},"upgradable":true,
+					"links":{"self":"/chronograf/v1/packages/system","diff":"/chronograf/v1/packages/system/diff"}}
+			]}`,
+		},
+		{
+			name:       "list without catalog",
+			method:     "GET",
+			wantStatus: http.StatusOK,
+			wantBody: `{"packages":[
+				{"name":"nginx","versions":[],"installed":{"version":"2.0.0","installedAt":"2026-01-02T03:04:05Z"},"upgradable":false,
+					"links":{"self":"/chronograf/v1/packages/nginx","diff":"/chronograf/v1/packages/nginx/diff"}},
+				{"name":"system","versions":[],"installed":{"version":"1.0.0","installedAt":"2026-01-02T03:04:05Z"},"upgradable":false,
+					"links":{"self":"/chronograf/v1/packages/system","diff":"/chronograf/v1/packages/system/diff"}}
+			]}`,
+		},
+		{
+			name:       "list with unavailable catalog",
+			method:     "GET",
+			catalog:    ts.URL + "/missing/",
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:       "install latest",
+			method:     "POST",
+			pkg:        "docker",
+			catalog:    ts.URL,
+			wantStatus: http.StatusCreated,
+			want:       map[string]string{"system": "1.0.0", "nginx": "2.0.0", "docker": "0.1.0"},
+		},
+		{
+			name:       "install installed package",
+			method:     "POST",
+			pkg:        "system",
+			body:       `{"version":"1.1.0"}`,
+			catalog:    ts.URL,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "install unknown package",
+			method:     "POST",
+			pkg:        "apache",
+			catalog:    ts.URL,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "install without catalog",
+			method:     "POST",
+			pkg:        "docker",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "upgrade",
+			method:     "PUT",
+			pkg:        "system",
+			body:       `{"version":"1.1.0"}`,
+			catalog:    ts.URL,
+			wantStatus: http.StatusOK,
+			want:       map[string]string{"system": "1.1.0", "nginx": "2.0.0"},
+		},
+		{
+			name:       "upgrade to the installed version",
+			method:     "PUT",
+			pkg:        "system",
+			body:       `{"version":"1.0.0"}`,
+			catalog:    ts.URL,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "upgrade package that is not installed",
+			method:     "PUT",
+			pkg:        "docker",
+			catalog:    ts.URL,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "uninstall",
+			method:     "DELETE",
+			pkg:        "system",
+			wantStatus: http.StatusNoContent,
+			want:       map[string]string{"nginx": "2.0.0"},
+		},
+		{
+			name:       "uninstall package that is not installed",
+			method:     "DELETE",
+			pkg:        "docker",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "diff with latest",
+			method:     "GET",
+			pkg:        "system",
+			path:       "/diff",
+			catalog:    ts.URL,
+			wantStatus: http.StatusOK,
+			wantBody: `{"name":"system","from":"1.0.0","to":"1.1.0",
+				"protoboards":[{"id":"system","name":"System","type":"modified","fields":["meta"]}],
+				"layouts":[]}`,
+		},
+		{
+			name:       "diff of package that is not installed",
+			method:     "GET",
+			pkg:        "docker",
+			path:       "/diff?version=0.1.0",
+			catalog:    ts.URL,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"name":"docker","from":"","to":"0.1.0","protoboards":[],"layouts":[]}`,
+		},
+		{
+			name:       "diff with invalid version",
+			method:     "GET",
+			pkg:        "system",
+			path:       "/diff?version=latest",
+			catalog:    ts.URL,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs := installed()
+			s := &Service{
+				Store:  &mocks.Store{PackagesStore: memPackages(pkgs)},
+				Logger: log.New(log.DebugLevel),
+			}
+			if tt.catalog != "" {
+				s.Catalog = &catalog.Client{Location: tt.catalog}
+			}
+
+			ctx := context.WithValue(context.Background(), organizations.ContextKey, "default")
+			r := httptest.NewRequest(tt.method, "http://any.url/chronograf/v1/packages"+tt.path, bytes.NewBufferString(tt.body))
+			var handler http.HandlerFunc
+			switch {
+			case tt.method == "GET" && tt.pkg == "":
+				handler = s.Packages
+			case tt.method == "GET":
+				handler = s.PackageDiff
+			case tt.method == "POST":
+				handler = s.InstallPackage
+			case tt.method == "PUT":
+				handler = s.UpgradePackage
+			case tt.method == "DELETE":
+				handler = s.UninstallPackage
+			}
+			r = r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "name", Value: tt.pkg}}))
+			w := httptest.NewRecorder()
+			handler(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if tt.want != nil {
+				got := map[string]string{}
+				for name, p := range pkgs {
+					got[name] = p.Manifest.Version
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("installed = %v, want %v", got, tt.want)
+				}
+			}
+			if tt.wantStatus == http.StatusCreated && !strings.HasSuffix(resp.Header.Get("Location"), "/packages/"+tt.pkg) {
+				t.Errorf("Location = %q", resp.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func TestStore_PackagedProtoboardsAndLayouts(t *testing.T) {
+	pkgs := map[string]chronograf.InstalledPackage{
+		"system": {
+			Package: chronograf.Package{
+				Manifest:    chronograf.PackageManifest{Name: "system", Version: "1.0.0"},
+				Protoboards: []chronograf.Protoboard{{ID: "system"}},
+				Layouts:     []chronograf.Layout{{ID: "cpu", Application: "system", Measurement: "cpu"}},
+			},
+			Organization: "1",
+		},
+	}
+	s := &Store{
+		PackagesStore: memPackages(pkgs),
+		ProtoboardsStore: &mocks.ProtoboardsStore{
+			AllF: func(context.Context) ([]chronograf.Protoboard, error) {
+				return []chronograf.Protoboard{{ID: "docker"}}, nil
+			},
+		},
+		LayoutsStore: &mocks.LayoutsStore{
+			AllF: func(context.Context) ([]chronograf.Layout, error) {
+				return []chronograf.Layout{{ID: "mem", Application: "system", Measurement: "mem"}}, nil
+			},
+			GetF: func(context.Context, string) (chronograf.Layout, error) {
+				return chronograf.Layout{}, chronograf.ErrLayoutNotFound
+			},
+			DeleteF: func(context.Context, chronograf.Layout) error {
+				return chronograf.ErrLayoutNotFound
+			},
+		},
+	}
+
+	for org, want := range map[string]int{"1": 2, "2": 1} {
+		ctx := context.WithValue(context.Background(), organizations.ContextKey, org)
+		protoboards, err := s.Protoboards(ctx).All(ctx)
+		if err != nil || len(protoboards) != want {
+			t.Errorf("organization %s: Protoboards().All() = %v, %v, want %d protoboards", org, protoboards, err, want)
+		}
+		layouts, err := s.Layouts(ctx).All(ctx)
+		if err != nil || len(layouts) != want {
+			t.Errorf("organization %s: Layouts().All() = %v, %v, want %d layouts", org, layouts, err, want)
+		}
+	}
+
+	ctx := context.WithValue(context.Background(), organizations.ContextKey, "1")
+	if err := s.Layouts(ctx).Delete(ctx, chronograf.Layout{ID: "cpu"}); err != chronograf.ErrLayoutReadOnly {
+		t.Errorf("Layouts().Delete() error = %v, want %v", err, chronograf.ErrLayoutReadOnly)
+	}
+}
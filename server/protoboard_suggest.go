@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxql"
+)
+
+// defaultSuggestSince bounds the measurements of suggestions to those
+// written recently, as the onboarding of the UI does
+const defaultSuggestSince = 10 * time.Minute
+
+type protoboardSuggestionLinks struct {
+	Self        string `json:"self"`
+	Instantiate string `json:"instantiate,omitempty"`
+}
+
+// protoboardSuggestion is a protoboard or the layouts of an application
+// whose measurements are written to a source
+type protoboardSuggestion struct {
+	Type         string                    `json:"type"` // Type is protoboard or layout
+	ID           string                    `json:"id"`   // ID is the ID of the protoboard or the application of the layouts
+	Name         string                    `json:"name"`
+	Score        float64                   `json:"score"`        // Score is the fraction of the measurements that are written
+	Measurements []string                  `json:"measurements"` // Measurements are the measurements visualized
+	Matched      []string                  `json:"matched"`      // Matched are the measurements that are written
+	Hosts        []string                  `json:"hosts"`        // Hosts are the hosts writing the matched measurements
+	Links        protoboardSuggestionLinks `json:"links"`
+}
+
+type protoboardSuggestionsResponse struct {
+	Database    string                 `json:"database"`
+	Since       string                 `json:"since"`
+	Suggestions []protoboardSuggestion `json:"suggestions"`
+}
+
+// SuggestProtoboards ranks the protoboards and layouts by the fraction of
+// their measurements that are written to the telegraf database of the source
+func (s *Service) SuggestProtoboards(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	since, err := suggestSince(r.URL.Query())
+	if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+
+	candidates, err := s.suggestionCandidates(ctx)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+
+	db := src.Telegraf
+	if db == "" {
+		db = "telegraf"
+	}
+	card, err := s.cardinalitySource(ctx, src, db, cardinalityRequest{Since: since})
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+	written, err := card.Series(ctx)
+	if err != nil {
+		influxAPIError(w, err, s.Logger)
+		return
+	}
+
+	// hosts maps the written measurements to the hosts writing them
+	hosts := map[string][]string{}
+	res := protoboardSuggestionsResponse{
+		Database:    db,
+		Since:       influxql.FormatDuration(since),
+		Suggestions: []protoboardSuggestion{},
+	}
+	for _, c := range candidates {
+		seen := map[string]bool{}
+		for _, m := range c.Measurements {
+			if written[m] == 0 {
+				continue
+			}
+			c.Matched = append(c.Matched, m)
+			if _, ok := hosts[m]; !ok {
+				tagSets, _, err := card.SampleSeries(ctx, m, defaultCardinalitySample)
+				if err != nil {
+					influxAPIError(w, err, s.Logger)
+					return
+				}
+				hosts[m] = []string{}
+				for _, tags := range tagSets {
+					if host := tags["host"]; host != "" {
+						hosts[m] = append(hosts[m], host)
+					}
+				}
+			}
+			for _, host := range hosts[m] {
+				if !seen[host] {
+					seen[host] = true
+					c.Hosts = append(c.Hosts, host)
+				}
+			}
+		}
+		if len(c.Matched) == 0 {
+			continue
+		}
+		c.Score = float64(len(c.Matched)) / float64(len(c.Measurements))
+		sort.Strings(c.Hosts)
+		res.Suggestions = append(res.Suggestions, c)
+	}
+
+	sort.SliceStable(res.Suggestions, func(i, j int) bool {
+		a, b := res.Suggestions[i], res.Suggestions[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if len(a.Matched) != len(b.Matched) {
+			return len(a.Matched) > len(b.Matched)
+		}
+		return a.Name < b.Name
+	})
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// suggestSince parses the since query parameter of suggestions
+func suggestSince(params url.Values) (time.Duration, error) {
+	since := params.Get("since")
+	if since == "" {
+		return defaultSuggestSince, nil
+	}
+	d, err := influxql.ParseDuration(since)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("since must be a positive duration")
+	}
+	return d, nil
+}
+
+// suggestionCandidates returns the protoboards and the layouts grouped by
+// application, with their measurements
+func (s *Service) suggestionCandidates(ctx context.Context) ([]protoboardSuggestion, error) {
+	protoboards, err := s.Store.Protoboards(ctx).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading protoboards: %v", err)
+	}
+	layouts, err := s.Store.Layouts(ctx).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading layouts: %v", err)
+	}
+
+	candidates := []protoboardSuggestion{}
+	seen := map[string]bool{}
+	for _, pb := range protoboards {
+		if seen[pb.ID] || len(pb.Meta.Measurements) == 0 {
+			continue
+		}
+		seen[pb.ID] = true
+		self := fmt.Sprintf("/chronograf/v1/protoboards/%s", pb.ID)
+		candidates = append(candidates, protoboardSuggestion{
+			Type:         "protoboard",
+			ID:           pb.ID,
+			Name:         pb.Meta.Name,
+			Measurements: pb.Meta.Measurements,
+			Matched:      []string{},
+			Hosts:        []string{},
+			Links: protoboardSuggestionLinks{
+				Self:        self,
+				Instantiate: self + "/instantiate",
+			},
+		})
+	}
+
+	apps := map[string]int{}
+	for _, layout := range layouts {
+		if layout.Measurement == "" {
+			continue
+		}
+		i, ok := apps[layout.Application]
+		if !ok {
+			i = len(candidates)
+			apps[layout.Application] = i
+			candidates = append(candidates, protoboardSuggestion{
+				Type:         "layout",
+				ID:           layout.Application,
+				Name:         layout.Application,
+				Measurements: []string{},
+				Matched:      []string{},
+				Hosts:        []string{},
+				Links: protoboardSuggestionLinks{
+					Self: "/chronograf/v1/layouts?app=" + url.QueryEscape(layout.Application),
+				},
+			})
+		}
+		c := &candidates[i]
+		if !containsString(c.Measurements, layout.Measurement) {
+			c.Measurements = append(c.Measurements, layout.Measurement)
+		}
+	}
+	return candidates, nil
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
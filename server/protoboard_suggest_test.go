@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestService_SuggestProtoboards(t *testing.T) {
+	influxQL := map[string]string{
+		`SHOW SERIES EXACT CARDINALITY ON metrics WHERE time > now() - 10m`: `[{"statement_id":0,"series":[
+			{"name":"cpu","columns":["count"],"values":[[3]]},
+			{"name":"mem","columns":["count"],"values":[[1]]},
+			{"name":"docker","columns":["count"],"values":[[1]]}
+		]}]`,
+		`SHOW SERIES ON metrics FROM cpu WHERE time > now() - 10m LIMIT 10000`: `[{"statement_id":0,"series":[
+			{"columns":["key"],"values":[["cpu,cpu=cpu0,host=b"],["cpu,cpu=cpu1,host=a"],["cpu,cpu=cpu0,host=a"]]}
+		]}]`,
+		`SHOW SERIES ON metrics FROM mem WHERE time > now() - 10m LIMIT 10000`: `[{"statement_id":0,"series":[
+			{"columns":["key"],"values":[["mem,host=a"]]}
+		]}]`,
+		`SHOW SERIES ON metrics FROM docker WHERE time > now() - 10m LIMIT 10000`: `[{"statement_id":0,"series":[
+			{"columns":["key"],"values":[["docker,engine_host=c,host=c"]]}
+		]}]`,
+	}
+	ts := &mocks.TimeSeries{
+		ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+		QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+			res, ok := influxQL[q.Command]
+			if !ok {
+				t.Errorf("unexpected query %s", q.Command)
+				res = `[{"statement_id":0,"error":"unexpected query"}]`
+			}
+			return mocks.NewResponse(res, nil), nil
+		},
+	}
+
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/csv")
+		switch {
+		case strings.Contains(string(body), `count(column: \"_time\")`):
+			w.Write([]byte("#datatype,string,long,string,long\r\n" +
+				"#group,false,false,false,false\r\n" +
+				"#default,_result,,,\r\n" +
+				",result,table,_measurement,_time\r\n" +
+				",,0,docker,2\r\n\r\n"))
+		case strings.Contains(string(body), `limit(n: 10000)`):
+			w.Write([]byte("#datatype,string,long,string\r\n" +
+				"#group,false,false,false\r\n" +
+				"#default,_result,,\r\n" +
+				",result,table,host\r\n" +
+				",,0,d\r\n" +
+				",,0,c\r\n\r\n"))
+		default:
+			t.Errorf("unexpected flux query %s", body)
+		}
+	}))
+	defer v2.Close()
+
+	v3 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		switch {
+		case strings.HasPrefix(q, "SELECT table_name"):
+			w.Write([]byte(`[{"table_name":"mem","column_name":"host","data_type":"Dictionary(Int32, Utf8)"},{"table_name":"mem","column_name":"used","data_type":"Int64"}]`))
+		case q == `SELECT 'mem' AS name, COUNT(*) AS series FROM (SELECT DISTINCT "host" FROM "mem" WHERE time > now() - INTERVAL '3600 seconds')`:
+			w.Write([]byte(`[{"name":"mem","series":1}]`))
+		case q == `SELECT DISTINCT "host" FROM "mem" WHERE time > now() - INTERVAL '3600 seconds' LIMIT 10000`:
+			w.Write([]byte(`[{"host":"e"}]`))
+		default:
+			t.Errorf("unexpected SQL query %s", q)
+		}
+	}))
+	defer v3.Close()
+
+	store := &mocks.Store{
+		SourcesStore: &mocks.SourcesStore{
+			GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+				switch ID {
+				case 1:
+					return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1, Telegraf: "metrics"}, nil
+				case 2:
+					return chronograf.Source{ID: 2, URL: v2.URL, Type: chronograf.InfluxDBv2, Username: "org"}, nil
+				case 3:
+					return chronograf.Source{ID: 3, URL: v3.URL, Type: chronograf.InfluxDBv3Core}, nil
+				}
+				return chronograf.Source{}, chronograf.ErrSourceNotFound
+			},
+		},
+		ProtoboardsStore: &mocks.ProtoboardsStore{
+			AllF: func(ctx context.Context) ([]chronograf.Protoboard, error) {
+				return []chronograf.Protoboard{
+					{ID: "system", Meta: chronograf.ProtoboardMeta{Name: "System", Measurements: []string{"cpu", "mem", "disk"}}},
+					{ID: "docker", Meta: chronograf.ProtoboardMeta{Name: "Docker", Measurements: []string{"docker", "docker_container_cpu"}}},
+					{ID: "apache", Meta: chronograf.ProtoboardMeta{Name: "Apache", Measurements: []string{"apache"}}},
+				}, nil
+			},
+		},
+		LayoutsStore: &mocks.LayoutsStore{
+			AllF: func(ctx context.Context) ([]chronograf.Layout, error) {
+				return []chronograf.Layout{
+					{ID: "1", Application: "system", Measurement: "cpu"},
+					{ID: "2", Application: "system", Measurement: "mem"},
+					{ID: "3", Application: "system", Measurement: "cpu"},
+					{ID: "4", Application: "docker", Measurement: "docker"},
+				}, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "1.x",
+			id:         "1",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"metrics","since":"10m","suggestions":[
+				{"type":"layout","id":"system","name":"system","score":1,"measurements":["cpu","mem"],"matched":["cpu","mem"],"hosts":["a","b"],"links":{"self":"/chronograf/v1/layouts?app=system"}},
+				{"type":"layout","id":"docker","name":"docker","score":1,"measurements":["docker"],"matched":["docker"],"hosts":["c"],"links":{"self":"/chronograf/v1/layouts?app=docker"}},
+				{"type":"protoboard","id":"system","name":"System","score":0.6666666666666666,"measurements":["cpu","mem","disk"],"matched":["cpu","mem"],"hosts":["a","b"],
+					"links":{"self":"/chronograf/v1/protoboards/system","instantiate":"/chronograf/v1/protoboards/system/instantiate"}},
+				{"type":"protoboard","id":"docker","name":"Docker","score":0.5,"measurements":["docker","docker_container_cpu"],"matched":["docker"],"hosts":["c"],
+					"links":{"self":"/chronograf/v1/protoboards/docker","instantiate":"/chronograf/v1/protoboards/docker/instantiate"}}
+			]}`,
+		},
+		{
+			name:       "2.x",
+			id:         "2",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"telegraf","since":"10m","suggestions":[
+				{"type":"layout","id":"docker","name":"docker","score":1,"measurements":["docker"],"matched":["docker"],"hosts":["c","d"],"links":{"self":"/chronograf/v1/layouts?app=docker"}},
+				{"type":"protoboard","id":"docker","name":"Docker","score":0.5,"measurements":["docker","docker_container_cpu"],"matched":["docker"],"hosts":["c","d"],
+					"links":{"self":"/chronograf/v1/protoboards/docker","instantiate":"/chronograf/v1/protoboards/docker/instantiate"}}
+			]}`,
+		},
+		{
+			name:       "InfluxDB 3 Core",
+			id:         "3",
+			query:      "?since=1h",
+			wantStatus: http.StatusOK,
+			wantBody: `{"database":"telegraf","since":"1h","suggestions":[
+				{"type":"layout","id":"system","name":"system","score":0.5,"measurements":["cpu","mem"],"matched":["mem"],"hosts":["e"],"links":{"self":"/chronograf/v1/layouts?app=system"}},
+				{"type":"protoboard","id":"system","name":"System","score":0.3333333333333333,"measurements":["cpu","mem","disk"],"matched":["mem"],"hosts":["e"],
+					"links":{"self":"/chronograf/v1/protoboards/system","instantiate":"/chronograf/v1/protoboards/system/instantiate"}}
+			]}`,
+		},
+		{
+			name:       "invalid since",
+			id:         "1",
+			query:      "?since=0s",
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown source",
+			id:         "4",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store:            store,
+				TimeSeriesClient: ts,
+				Logger:           log.New(log.DebugLevel),
+			}
+			r := httptest.NewRequest("GET", "http://any.url"+tt.query, nil)
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: tt.id}}))
+			w := httptest.NewRecorder()
+			s.SuggestProtoboards(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+		})
+	}
+}
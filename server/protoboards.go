@@ -1,11 +1,15 @@
 package server
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/bouk/httprouter"
 	"github.com/influxdata/chronograf"
+	idgen "github.com/influxdata/chronograf/id"
 )
 
 type protoboardLinks struct {
@@ -74,3 +78,240 @@ func (s *Service) ProtoboardsID(w http.ResponseWriter, r *http.Request) {
 	res := newProtoboardResponse(protoboard)
 	encodeJSON(w, http.StatusOK, res, s.Logger)
 }
+
+// InstantiateProtoboardRequest is the request to create a dashboard from
+// protoboards
+type InstantiateProtoboardRequest struct {
+	SourceID    string            `json:"sourceID"`    // SourceID is the ID of the source the queries and templates of the dashboard use
+	Name        string            `json:"name"`        // Name is the name of the dashboard, by default the name of the protoboard
+	Protoboards []string          `json:"protoboards"` // Protoboards are the IDs of protoboards merged below the cells of the instantiated protoboard
+	Templates   map[string]string `json:"templates"`   // Templates maps template variables to the value selected in the dashboard
+}
+
+// InstantiateProtoboard creates a dashboard from the protoboard with ID and
+// the protoboards of the request. The queries and templates of the dashboard
+// use the source of the request.
+func (s *Service) InstantiateProtoboard(w http.ResponseWriter, r *http.Request) {
+	var req InstantiateProtoboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, err := strconv.Atoi(req.SourceID)
+	if err != nil {
+		invalidData(w, fmt.Errorf("invalid sourceID %q", req.SourceID), s.Logger)
+		return
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		invalidData(w, fmt.Errorf("source %d not found", srcID), s.Logger)
+		return
+	}
+
+	id := httprouter.GetParamFromContext(ctx, "id")
+	ids := append([]string{id}, req.Protoboards...)
+	protoboards := make([]chronograf.Protoboard, 0, len(ids))
+	seen := map[string]bool{}
+	for i, pbID := range ids {
+		if seen[pbID] {
+			continue
+		}
+		seen[pbID] = true
+		pb, err := s.Store.Protoboards(ctx).Get(ctx, pbID)
+		if err != nil && i == 0 {
+			Error(w, http.StatusNotFound, fmt.Sprintf("ID %s not found", pbID), s.Logger)
+			return
+		}
+		if err != nil {
+			invalidData(w, fmt.Errorf("protoboard %s not found", pbID), s.Logger)
+			return
+		}
+		protoboards = append(protoboards, pb)
+	}
+
+	dashboard, err := instantiateProtoboards(protoboards, src, &idgen.UUID{})
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	if req.Name != "" {
+		dashboard.Name = req.Name
+	}
+	if err := selectTemplateValues(dashboard.Templates, req.Templates); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
+	if err != nil {
+		unknownErrorWithMessage(w, err, s.Logger)
+		return
+	}
+	if err := ValidDashboardRequest(&dashboard, defaultOrg.ID); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	if dashboard, err = s.Store.Dashboards(ctx).Add(ctx, dashboard); err != nil {
+		msg := fmt.Errorf("Error storing dashboard %v: %v", dashboard, err)
+		unknownErrorWithMessage(w, msg, s.Logger)
+		return
+	}
+
+	res := newDashboardResponse(dashboard)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, s.Logger)
+}
+
+// instantiateProtoboards converts the protoboards into a dashboard with the
+// cells of each protoboard placed below the cells of the previous one. The
+// :db: and :rp: of the queries are replaced by the telegraf database and the
+// default retention policy of the source.
+func instantiateProtoboards(protoboards []chronograf.Protoboard, src chronograf.Source, ids chronograf.ID) (chronograf.Dashboard, error) {
+	db, rp := src.Telegraf, src.DefaultRP
+	if db == "" {
+		db = "telegraf"
+	}
+	if rp == "" {
+		rp = "autogen"
+	}
+	replacer := strings.NewReplacer(":db:", db, ":rp:", rp)
+	sourceLink := fmt.Sprintf("/chronograf/v1/sources/%d", src.ID)
+
+	dashboard := chronograf.Dashboard{
+		Cells:     []chronograf.DashboardCell{},
+		Templates: []chronograf.Template{},
+	}
+	if len(protoboards) > 0 {
+		dashboard.Name = protoboards[0].Meta.Name
+	}
+
+	vars := map[string]bool{}
+	var offset int32
+	for _, pb := range protoboards {
+		cells := placeProtoboardCells(pb.Data.Cells)
+		var bottom int32
+		for _, c := range cells {
+			cid, err := ids.Generate()
+			if err != nil {
+				return chronograf.Dashboard{}, err
+			}
+			queries := make([]chronograf.DashboardQuery, len(c.Queries))
+			for i, q := range c.Queries {
+				q.Command = replacer.Replace(q.Command)
+				q.Source = sourceLink
+				queries[i] = q
+			}
+			dashboard.Cells = append(dashboard.Cells, chronograf.DashboardCell{
+				ID:             cid,
+				X:              c.X,
+				Y:              c.Y + offset,
+				W:              c.W,
+				H:              c.H,
+				Name:           c.Name,
+				Queries:        queries,
+				Axes:           c.Axes,
+				Type:           c.Type,
+				CellColors:     c.CellColors,
+				Legend:         c.Legend,
+				TableOptions:   c.TableOptions,
+				FieldOptions:   c.FieldOptions,
+				TimeFormat:     c.TimeFormat,
+				DecimalPlaces:  c.DecimalPlaces,
+				Note:           c.Note,
+				NoteVisibility: c.NoteVisibility,
+			})
+			if c.Y+c.H > bottom {
+				bottom = c.Y + c.H
+			}
+		}
+		offset += bottom
+
+		// protoboards of the same dashboard share their template variables
+		for _, t := range pb.Data.Templates {
+			if vars[t.Var] {
+				continue
+			}
+			vars[t.Var] = true
+			tid, err := ids.Generate()
+			if err != nil {
+				return chronograf.Dashboard{}, err
+			}
+			t.ID = chronograf.TemplateID(tid)
+			t.SourceID = strconv.Itoa(src.ID)
+			if t.Query != nil {
+				q := *t.Query
+				q.DB = db
+				t.Query = &q
+			}
+			t.Values = append([]chronograf.TemplateValue(nil), t.Values...)
+			dashboard.Templates = append(dashboard.Templates, t)
+		}
+	}
+	return dashboard, nil
+}
+
+// placeProtoboardCells places the cells of a protoboard without positions
+// next to each other, starting a new row when a cell does not fit into the
+// grid, as the UI does.
+func placeProtoboardCells(cells []chronograf.ProtoboardCell) []chronograf.ProtoboardCell {
+	placed := make([]chronograf.ProtoboardCell, len(cells))
+	copy(placed, cells)
+	for _, c := range cells {
+		if c.X != 0 || c.Y != 0 {
+			return placed
+		}
+	}
+
+	var x, y, rowHeight int32
+	for i, c := range placed {
+		if x > 0 && x+c.W > DashboardGridColumns {
+			x, y, rowHeight = 0, y+rowHeight, 0
+		}
+		placed[i].X, placed[i].Y = x, y
+		x += c.W
+		if c.H > rowHeight {
+			rowHeight = c.H
+		}
+	}
+	return placed
+}
+
+// selectTemplateValues selects the values of the template variables. The
+// variables may be given with or without their colons. Values that are not
+// among the values of a template are added to it.
+func selectTemplateValues(templates []chronograf.Template, values map[string]string) error {
+	for name, value := range values {
+		name = ":" + strings.Trim(name, ":") + ":"
+		found := false
+		for i := range templates {
+			t := &templates[i]
+			if t.Var != name {
+				continue
+			}
+			found = true
+			selected := false
+			for j := range t.Values {
+				t.Values[j].Selected = t.Values[j].Value == value
+				selected = selected || t.Values[j].Selected
+			}
+			if !selected {
+				typ, ok := templateValueTypes[t.Type]
+				if !ok {
+					typ = t.Type
+				}
+				t.Values = append(t.Values, chronograf.TemplateValue{
+					Value:    value,
+					Type:     typ,
+					Selected: true,
+				})
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown template variable %s", name)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/bouk/httprouter"
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/chronograf"
 	"github.com/influxdata/chronograf/mocks"
 )
@@ -325,3 +328,241 @@ func Test_ProtoboardsID(t *testing.T) {
 		})
 	}
 }
+
+// sequentialIDs generates the IDs 1, 2, 3...
+type sequentialIDs struct{ n int }
+
+func (s *sequentialIDs) Generate() (string, error) {
+	s.n++
+	return fmt.Sprint(s.n), nil
+}
+
+func Test_instantiateProtoboards(t *testing.T) {
+	system := chronograf.Protoboard{
+		ID:   "system",
+		Meta: chronograf.ProtoboardMeta{Name: "System"},
+		Data: chronograf.ProtoboardData{
+			Cells: []chronograf.ProtoboardCell{
+				{W: 6, H: 4, Name: "CPU", Queries: []chronograf.DashboardQuery{{Command: `SELECT "usage_user" FROM ":db:".":rp:"."cpu" WHERE "host" = :host:`}}},
+				{W: 6, H: 2, Name: "Load"},
+				{W: 4, H: 4, Name: "Memory"},
+			},
+			Templates: []chronograf.Template{
+				{TemplateVar: chronograf.TemplateVar{Var: ":host:"}, Type: "tagValues", Query: &chronograf.TemplateQuery{DB: ":db:", Measurement: "cpu", TagKey: "host"}},
+			},
+		},
+	}
+	docker := chronograf.Protoboard{
+		ID:   "docker",
+		Meta: chronograf.ProtoboardMeta{Name: "Docker"},
+		Data: chronograf.ProtoboardData{
+			Cells: []chronograf.ProtoboardCell{
+				{X: 0, Y: 1, W: 12, H: 3, Name: "Containers"},
+			},
+			Templates: []chronograf.Template{
+				{TemplateVar: chronograf.TemplateVar{Var: ":host:"}, Type: "tagValues"},
+			},
+		},
+	}
+	src := chronograf.Source{ID: 2, Telegraf: "metrics"}
+
+	got, err := instantiateProtoboards([]chronograf.Protoboard{system, docker}, src, &sequentialIDs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := chronograf.Dashboard{
+		Name: "System",
+		Cells: []chronograf.DashboardCell{
+			{ID: "1", X: 0, Y: 0, W: 6, H: 4, Name: "CPU", Queries: []chronograf.DashboardQuery{{
+				Command: `SELECT "usage_user" FROM "metrics"."autogen"."cpu" WHERE "host" = :host:`,
+				Source:  "/chronograf/v1/sources/2",
+			}}},
+			{ID: "2", X: 6, Y: 0, W: 6, H: 2, Name: "Load", Queries: []chronograf.DashboardQuery{}},
+			{ID: "3", X: 0, Y: 4, W: 4, H: 4, Name: "Memory", Queries: []chronograf.DashboardQuery{}},
+			{ID: "5", X: 0, Y: 9, W: 12, H: 3, Name: "Containers", Queries: []chronograf.DashboardQuery{}},
+		},
+		Templates: []chronograf.Template{
+			{TemplateVar: chronograf.TemplateVar{Var: ":host:"}, ID: "4", Type: "tagValues", SourceID: "2", Query: &chronograf.TemplateQuery{DB: "metrics", Measurement: "cpu", TagKey: "host"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("instantiateProtoboards() -want/+got\n%s", diff)
+	}
+	if system.Data.Templates[0].Query.DB != ":db:" {
+		t.Errorf("instantiateProtoboards() modified the templates of the protoboard")
+	}
+}
+
+func Test_selectTemplateValues(t *testing.T) {
+	templates := []chronograf.Template{
+		{
+			TemplateVar: chronograf.TemplateVar{Var: ":host:", Values: []chronograf.TemplateValue{
+				{Value: "a", Type: "tagValue", Selected: true},
+				{Value: "b", Type: "tagValue"},
+			}},
+			Type: "tagValues",
+		},
+		{TemplateVar: chronograf.TemplateVar{Var: ":region:"}, Type: "text"},
+	}
+	if err := selectTemplateValues(templates, map[string]string{"host": "b", ":region:": "eu"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []chronograf.Template{
+		{
+			TemplateVar: chronograf.TemplateVar{Var: ":host:", Values: []chronograf.TemplateValue{
+				{Value: "a", Type: "tagValue"},
+				{Value: "b", Type: "tagValue", Selected: true},
+			}},
+			Type: "tagValues",
+		},
+		{TemplateVar: chronograf.TemplateVar{Var: ":region:", Values: []chronograf.TemplateValue{
+			{Value: "eu", Type: "constant", Selected: true},
+		}}, Type: "text"},
+	}
+	if diff := cmp.Diff(want, templates); diff != "" {
+		t.Errorf("selectTemplateValues() -want/+got\n%s", diff)
+	}
+	if err := selectTemplateValues(templates, map[string]string{"dc": "x"}); err == nil {
+		t.Errorf("selectTemplateValues() expected an error for an unknown variable")
+	}
+}
+
+func Test_InstantiateProtoboard(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantStatus int
+		wantName   string
+		wantCells  int
+	}{
+		{
+			name:       "single protoboard",
+			id:         "system",
+			body:       `{"sourceID":"1"}`,
+			wantStatus: http.StatusCreated,
+			wantName:   "System",
+			wantCells:  1,
+		},
+		{
+			name:       "merged protoboards with a name",
+			id:         "system",
+			body:       `{"sourceID":"1","name":"Hosts","protoboards":["docker","system"],"templates":{"host":"a"}}`,
+			wantStatus: http.StatusCreated,
+			wantName:   "Hosts",
+			wantCells:  2,
+		},
+		{
+			name:       "unknown protoboard",
+			id:         "nope",
+			body:       `{"sourceID":"1"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "unknown merged protoboard",
+			id:         "system",
+			body:       `{"sourceID":"1","protoboards":["nope"]}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown source",
+			id:         "system",
+			body:       `{"sourceID":"2"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown template variable",
+			id:         "system",
+			body:       `{"sourceID":"1","templates":{"dc":"x"}}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	protoboards := map[string]chronograf.Protoboard{
+		"system": {
+			ID:   "system",
+			Meta: chronograf.ProtoboardMeta{Name: "System"},
+			Data: chronograf.ProtoboardData{
+				Cells:     []chronograf.ProtoboardCell{{W: 4, H: 4, Name: "CPU"}},
+				Templates: []chronograf.Template{{TemplateVar: chronograf.TemplateVar{Var: ":host:"}, Type: "text"}},
+			},
+		},
+		"docker": {
+			ID:   "docker",
+			Meta: chronograf.ProtoboardMeta{Name: "Docker"},
+			Data: chronograf.ProtoboardData{Cells: []chronograf.ProtoboardCell{{W: 4, H: 4, Name: "Containers"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var added *chronograf.Dashboard
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							if ID != 1 {
+								return chronograf.Source{}, chronograf.ErrSourceNotFound
+							}
+							return chronograf.Source{ID: 1}, nil
+						},
+					},
+					ProtoboardsStore: &mocks.ProtoboardsStore{
+						GetF: func(ctx context.Context, id string) (chronograf.Protoboard, error) {
+							pb, ok := protoboards[id]
+							if !ok {
+								return chronograf.Protoboard{}, chronograf.ErrProtoboardNotFound
+							}
+							return pb, nil
+						},
+					},
+					OrganizationsStore: &mocks.OrganizationsStore{
+						DefaultOrganizationF: func(ctx context.Context) (*chronograf.Organization, error) {
+							return &chronograf.Organization{ID: "0"}, nil
+						},
+					},
+					DashboardsStore: &mocks.DashboardsStore{
+						AddF: func(ctx context.Context, d chronograf.Dashboard) (chronograf.Dashboard, error) {
+							d.ID = 7
+							added = &d
+							return d, nil
+						},
+					},
+				},
+				Logger: &mocks.TestLogger{},
+			}
+			r := httptest.NewRequest("POST", "http://any.url/chronograf/v1/protoboards/"+tt.id+"/instantiate", bytes.NewBufferString(tt.body))
+			r = r.WithContext(httprouter.WithParams(r.Context(), httprouter.Params{{Key: "id", Value: tt.id}}))
+			w := httptest.NewRecorder()
+			s.InstantiateProtoboard(w, r)
+
+			resp := w.Result()
+			if resp.StatusCode != tt.wantStatus {
+				body, _ := ioutil.ReadAll(resp.Body)
+				t.Fatalf("InstantiateProtoboard() status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				if added != nil {
+					t.Errorf("InstantiateProtoboard() stored a dashboard")
+				}
+				return
+			}
+			if got := resp.Header.Get("Location"); got != "/chronograf/v1/dashboards/7" {
+				t.Errorf("InstantiateProtoboard() Location = %q", got)
+			}
+			var res dashboardResponse
+			if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+				t.Fatal(err)
+			}
+			if res.Name != tt.wantName || len(res.Cells) != tt.wantCells || added.Organization != "0" {
+				t.Errorf("InstantiateProtoboard() = %+v", res)
+			}
+			for _, c := range added.Cells {
+				if c.ID == "" {
+					t.Errorf("InstantiateProtoboard() did not generate the ID of cell %s", c.Name)
+				}
+			}
+			if added.Templates[0].SourceID != "1" {
+				t.Errorf("InstantiateProtoboard() template source = %q, want 1", added.Templates[0].SourceID)
+			}
+		})
+	}
+}
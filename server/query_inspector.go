@@ -0,0 +1,451 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/influxql"
+)
+
+const (
+	// fluxProfilerImport imports the package of the Flux profilers
+	fluxProfilerImport = `import "profiler"`
+	// fluxProfilerOption enables the profilers of a Flux query, whose results
+	// are returned as an additional _profiler result
+	fluxProfilerOption = `option profiler.enabledProfilers = ["query", "operator"]`
+)
+
+// InspectQueryRequest is a query to execute and inspect
+type InspectQueryRequest struct {
+	Query   string `json:"query"`
+	Type    string `json:"type,omitempty"`    // Type is the language of the query, influxql (default) or flux
+	DB      string `json:"db,omitempty"`      // DB is the database of InfluxQL queries that do not specify one
+	RP      string `json:"rp,omitempty"`      // RP is the retention policy of InfluxQL queries that do not specify one
+	Analyze bool   `json:"analyze,omitempty"` // Analyze runs EXPLAIN ANALYZE, which executes InfluxQL queries a second time
+}
+
+// InspectDashboardRequest specifies the time range and the template
+// selections used to render the queries of a dashboard to inspect
+type InspectDashboardRequest struct {
+	ResolveTemplatesRequest
+	Analyze bool `json:"analyze,omitempty"` // Analyze runs EXPLAIN ANALYZE on InfluxQL queries
+}
+
+type queryInspection struct {
+	Query        string              `json:"query"`
+	Type         string              `json:"type"`
+	Duration     float64             `json:"durationMs"`             // Duration is the upstream latency of the query
+	Bytes        int64               `json:"bytes"`                  // Bytes is the size of the response of the query
+	Series       int                 `json:"series"`                 // Series is the number of series or tables of the response
+	Points       int                 `json:"points"`                 // Points is the number of points or rows of the response
+	Explain      []string            `json:"explain,omitempty"`      // Explain is the EXPLAIN output of InfluxQL queries
+	Analyze      []string            `json:"analyze,omitempty"`      // Analyze is the EXPLAIN ANALYZE output of InfluxQL queries
+	Profile      []map[string]string `json:"profile,omitempty"`      // Profile is the profiler output of Flux queries
+	Error        string              `json:"error,omitempty"`        // Error is why the query failed
+	ExplainError string              `json:"explainError,omitempty"` // ExplainError is why the query could not be explained
+}
+
+type inspectedCellQuery struct {
+	CellID   string `json:"cellID"`
+	CellName string `json:"cellName"`
+	Source   string `json:"source"`
+	queryInspection
+}
+
+type dashboardInspectionLinks struct {
+	Self      string `json:"self"`      // Self link mapping to this resource
+	Dashboard string `json:"dashboard"` // Dashboard link to the inspected dashboard
+}
+
+type dashboardInspectionResponse struct {
+	Duration float64                  `json:"durationMs"` // Duration is the total upstream latency of all queries
+	Bytes    int64                    `json:"bytes"`      // Bytes is the total size of the responses of all queries
+	Queries  []inspectedCellQuery     `json:"queries"`    // Queries of the cells, the most costly first
+	Links    dashboardInspectionLinks `json:"links"`
+}
+
+// InspectQuery executes a query and returns its upstream latency, the size
+// of its response and its number of series and points, together with the
+// EXPLAIN output of InfluxQL queries or the profiler output of Flux queries.
+// A query that fails is reported with its error.
+func (s *Service) InspectQuery(w http.ResponseWriter, r *http.Request) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	var req InspectQueryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if err := ValidInspectQueryRequest(&req); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return
+	}
+	res, err := s.inspectQuery(ctx, src, req)
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// InspectDashboard inspects the queries of all cells of a dashboard,
+// rendered like ResolveTemplates does, and ranks them by their upstream
+// latency.
+func (s *Service) InspectDashboard(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	var req InspectDashboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if req.Lower == "" {
+		req.Lower = "now() - 1h"
+	}
+	if err := validTimeRange(req.Lower, req.Upper, time.Now()); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+	_, cells, err := s.resolveDashboard(ctx, d, req.ResolveTemplatesRequest)
+	if isReaderFluxError(err) {
+		Error(w, readerFluxErrorStatus(err), readerFluxErrorMessage(err), s.Logger)
+		return
+	} else if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	res := dashboardInspectionResponse{
+		Queries: []inspectedCellQuery{},
+		Links: dashboardInspectionLinks{
+			Self:      fmt.Sprintf("/chronograf/v1/dashboards/%d/inspect", d.ID),
+			Dashboard: fmt.Sprintf("/chronograf/v1/dashboards/%d", d.ID),
+		},
+	}
+	for _, cell := range cells {
+		for _, q := range cell.Queries {
+			iq := inspectedCellQuery{
+				CellID:   cell.ID,
+				CellName: cell.Name,
+				Source:   q.Source,
+				queryInspection: queryInspection{
+					Query: q.QueryTemplated,
+					Type:  inspectedQueryType(q.Type),
+					Error: q.Error,
+				},
+			}
+			if iq.Error == "" {
+				iq.queryInspection = s.inspectCellQuery(ctx, q, req.Analyze)
+			}
+			res.Duration += iq.Duration
+			res.Bytes += iq.Bytes
+			res.Queries = append(res.Queries, iq)
+		}
+	}
+	sort.SliceStable(res.Queries, func(i, j int) bool {
+		if res.Queries[i].Duration != res.Queries[j].Duration {
+			return res.Queries[i].Duration > res.Queries[j].Duration
+		}
+		return res.Queries[i].Bytes > res.Queries[j].Bytes
+	})
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// inspectCellQuery inspects a rendered query of a cell against its source
+func (s *Service) inspectCellQuery(ctx context.Context, q resolvedQuery, analyze bool) queryInspection {
+	req := InspectQueryRequest{
+		Query:   q.QueryTemplated,
+		Type:    q.Type,
+		Analyze: analyze,
+	}
+	failed := func(err error) queryInspection {
+		return queryInspection{Query: req.Query, Type: inspectedQueryType(req.Type), Error: err.Error()}
+	}
+	if err := ValidInspectQueryRequest(&req); err != nil {
+		return failed(err)
+	}
+	if q.Source == "" {
+		return failed(fmt.Errorf("the query has no source"))
+	}
+	srcID, err := strconv.Atoi(path.Base(q.Source))
+	if err != nil {
+		return failed(fmt.Errorf("invalid source %s", q.Source))
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		return failed(fmt.Errorf("source %d not found", srcID))
+	}
+	res, err := s.inspectQuery(ctx, src, req)
+	if err != nil {
+		return failed(fmt.Errorf("unable to connect to source %d: %v", srcID, err))
+	}
+	return *res
+}
+
+// ValidInspectQueryRequest checks that the query is InfluxQL or Flux and
+// that InfluxQL queries are read-only, as inspecting them executes them.
+// Flux queries are not checked.
+func ValidInspectQueryRequest(req *InspectQueryRequest) error {
+	if req.Query == "" {
+		return fmt.Errorf("query field required")
+	}
+	req.Type = inspectedQueryType(req.Type)
+	switch req.Type {
+	case "flux":
+		return nil
+	case "influxql":
+		q, err := influxql.ParseQuery(req.Query)
+		if err != nil {
+			return err
+		}
+		for _, stmt := range q.Statements {
+			switch stmt := stmt.(type) {
+			case *influxql.SelectStatement:
+				if stmt.Target != nil {
+					return fmt.Errorf("SELECT INTO queries cannot be inspected")
+				}
+			case *influxql.ShowMeasurementsStatement, *influxql.ShowTagKeysStatement,
+				*influxql.ShowTagValuesStatement, *influxql.ShowFieldKeysStatement,
+				*influxql.ShowSeriesStatement, *influxql.ShowDatabasesStatement,
+				*influxql.ShowRetentionPoliciesStatement:
+			default:
+				return fmt.Errorf("only SELECT and SHOW queries can be inspected")
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid query type %q", req.Type)
+}
+
+func inspectedQueryType(t string) string {
+	if t == "" {
+		return "influxql"
+	}
+	return t
+}
+
+// inspectQuery executes the query against the source. It only fails if the
+// source cannot be connected to, errors of the query are reported within
+// the inspection.
+func (s *Service) inspectQuery(ctx context.Context, src chronograf.Source, req InspectQueryRequest) (*queryInspection, error) {
+	if req.Type == "flux" {
+		return inspectFluxQuery(ctx, src, req.Query)
+	}
+
+	ts, err := s.TimeSeries(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := ts.Connect(ctx, &src); err != nil {
+		return nil, err
+	}
+
+	res := &queryInspection{Query: req.Query, Type: req.Type}
+	q := chronograf.Query{Command: req.Query, DB: req.DB, RP: req.RP}
+	setupQueryFromCommand(&q)
+	start := time.Now()
+	results, size, err := influxQLResults(ctx, ts, q)
+	res.Duration = milliseconds(time.Since(start))
+	res.Bytes = size
+	if err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	for _, r := range results {
+		if r.Error != "" && res.Error == "" {
+			res.Error = r.Error
+		}
+		res.Series += len(r.Series)
+		for _, series := range r.Series {
+			res.Points += len(series.Values)
+		}
+	}
+
+	// SHOW statements have no query plan
+	parsed, err := influxql.ParseQuery(q.Command)
+	if err != nil {
+		return res, nil
+	}
+	for _, stmt := range parsed.Statements {
+		if _, ok := stmt.(*influxql.SelectStatement); !ok {
+			continue
+		}
+		explain := func(prefix string) ([]string, error) {
+			results, _, err := influxQLResults(ctx, ts, chronograf.Query{Command: prefix + stmt.String(), DB: q.DB, RP: q.RP})
+			if err != nil {
+				return nil, err
+			}
+			lines := []string{}
+			for _, r := range results {
+				if r.Error != "" {
+					return nil, fmt.Errorf("%s", r.Error)
+				}
+				for _, series := range r.Series {
+					for _, v := range series.Values {
+						if len(v) > 0 {
+							lines = append(lines, fmt.Sprint(v[0]))
+						}
+					}
+				}
+			}
+			return lines, nil
+		}
+		lines, err := explain("EXPLAIN ")
+		if err != nil {
+			res.ExplainError = err.Error()
+			return res, nil
+		}
+		res.Explain = append(res.Explain, lines...)
+		if req.Analyze {
+			if lines, err = explain("EXPLAIN ANALYZE "); err != nil {
+				res.ExplainError = err.Error()
+				return res, nil
+			}
+			res.Analyze = append(res.Analyze, lines...)
+		}
+	}
+	return res, nil
+}
+
+// influxQLResults executes the InfluxQL query and returns its results along
+// with the size of its response
+func influxQLResults(ctx context.Context, ts chronograf.TimeSeries, q chronograf.Query) ([]influxQLResult, int64, error) {
+	response, err := ts.Query(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	return influxQLResultsOf(response)
+}
+
+// influxQLResultsOf parses the results of an InfluxQL response and returns
+// them along with the size of the response
+func influxQLResultsOf(response chronograf.Response) ([]influxQLResult, int64, error) {
+	octets, err := response.MarshalJSON()
+	if err != nil {
+		return nil, 0, err
+	}
+	var results []influxQLResult
+	if err := json.Unmarshal(octets, &results); err != nil {
+		return nil, int64(len(octets)), err
+	}
+	return results, int64(len(octets)), nil
+}
+
+// inspectFluxQuery executes the Flux query with its profilers enabled
+func inspectFluxQuery(ctx context.Context, src chronograf.Source, query string) (*queryInspection, error) {
+	u, err := url.ParseRequestURI(src.URL)
+	if err != nil {
+		return nil, err
+	}
+	client := &flux.Client{
+		URL:                u,
+		InsecureSkipVerify: src.InsecureSkipVerify,
+		Org:                src.Username, // v2 organization name is stored in username
+		Authorizer:         influx.DefaultAuthorization(&src),
+	}
+
+	res := &queryInspection{Query: query, Type: "flux"}
+	start := time.Now()
+	body, err := client.Query(ctx, profiledFluxQuery(query))
+	if err != nil {
+		res.Duration = milliseconds(time.Since(start))
+		res.Error = err.Error()
+		return res, nil
+	}
+	defer body.Close()
+	counter := &countingReader{r: body}
+	rows, err := flux.ReadRows(counter)
+	res.Duration = milliseconds(time.Since(start))
+	res.Bytes = counter.n
+	if err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+
+	tables := map[string]bool{}
+	for _, row := range rows {
+		result := row["result"]
+		delete(row, "result")
+		if result == "_profiler" {
+			delete(row, "table")
+			res.Profile = append(res.Profile, row)
+			continue
+		}
+		tables[result+"/"+row["table"]] = true
+		res.Points++
+	}
+	res.Series = len(tables)
+	return res, nil
+}
+
+// profiledFluxQuery enables the profilers of a Flux query. Imports must come
+// first, so the option follows the imports of the query, to which the
+// profiler package is added unless the query imports it already.
+func profiledFluxQuery(query string) string {
+	imports, body := influx.SplitFluxImports(query)
+	profiler := false
+	for _, line := range strings.Split(imports, "\n") {
+		if strings.TrimSpace(line) == fluxProfilerImport {
+			profiler = true
+		}
+	}
+	if !profiler {
+		imports = strings.TrimSpace(imports + "\n" + fluxProfilerImport)
+	}
+	return imports + "\n\n" + fluxProfilerOption + "\n\n" + body
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,328 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestValidInspectQueryRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     InspectQueryRequest
+		wantErr bool
+	}{
+		{
+			name: "select",
+			req:  InspectQueryRequest{Query: `SELECT mean("usage_idle") FROM "cpu" WHERE time > now() - 1h GROUP BY time(1m)`},
+		},
+		{
+			name: "show",
+			req:  InspectQueryRequest{Query: `SHOW TAG VALUES FROM "cpu" WITH KEY = "host"`},
+		},
+		{
+			name: "flux",
+			req:  InspectQueryRequest{Query: `from(bucket: "b") |> range(start: -1h)`, Type: "flux"},
+		},
+		{
+			name:    "missing query",
+			req:     InspectQueryRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "drop",
+			req:     InspectQueryRequest{Query: `DROP MEASUREMENT "cpu"`},
+			wantErr: true,
+		},
+		{
+			name:    "select into",
+			req:     InspectQueryRequest{Query: `SELECT * INTO "cpu_copy" FROM "cpu"`},
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			req:     InspectQueryRequest{Query: "SELECT 1", Type: "sql"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidInspectQueryRequest(&tt.req); (err != nil) != tt.wantErr {
+				t.Errorf("ValidInspectQueryRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_profiledFluxQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "without imports",
+			query: `from(bucket: "b") |> range(start: -1h)`,
+			want: `import "profiler"
+
+option profiler.enabledProfilers = ["query", "operator"]
+
+from(bucket: "b") |> range(start: -1h)`,
+		},
+		{
+			name: "with imports",
+			query: `import "strings"
+
+from(bucket: "b") |> range(start: -1h) |> filter(fn: (r) => strings.hasPrefix(v: r.host, prefix: "a"))`,
+			want: `import "strings"
+import "profiler"
+
+option profiler.enabledProfilers = ["query", "operator"]
+
+from(bucket: "b") |> range(start: -1h) |> filter(fn: (r) => strings.hasPrefix(v: r.host, prefix: "a"))`,
+		},
+		{
+			name: "importing the profiler",
+			query: `import "profiler"
+from(bucket: "b") |> range(start: -1h)`,
+			want: `import "profiler"
+
+option profiler.enabledProfilers = ["query", "operator"]
+
+from(bucket: "b") |> range(start: -1h)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := profiledFluxQuery(tt.query); got != tt.want {
+				t.Errorf("profiledFluxQuery() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+const inspectedSelect = `SELECT mean(usage_idle) FROM telegraf.autogen.cpu WHERE time > now() - 1h GROUP BY host`
+
+// inspectorTimeSeries answers the inspected query, its EXPLAIN and EXPLAIN
+// ANALYZE, taking delay to answer slow queries
+func inspectorTimeSeries(t *testing.T, slow string, delay time.Duration) *mocks.TimeSeries {
+	return &mocks.TimeSeries{
+		ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+		QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+			if q.Command == slow {
+				time.Sleep(delay)
+			}
+			switch {
+			case strings.HasPrefix(q.Command, "EXPLAIN ANALYZE "):
+				return mocks.NewResponse(`[{"statement_id":0,"series":[{"columns":["EXPLAIN ANALYZE"],"values":[["."],["└── select"]]}]}]`, nil), nil
+			case strings.HasPrefix(q.Command, "EXPLAIN "):
+				return mocks.NewResponse(`[{"statement_id":0,"series":[{"columns":["QUERY PLAN"],"values":[["EXPRESSION: mean(usage_idle::float)"],["NUMBER OF SERIES: 2"]]}]}]`, nil), nil
+			case strings.HasPrefix(q.Command, "SELECT"):
+				return mocks.NewResponse(`[{"statement_id":0,"series":[
+					{"name":"cpu","tags":{"host":"a"},"columns":["time","mean"],"values":[[0,1],[60,2]]},
+					{"name":"cpu","tags":{"host":"b"},"columns":["time","mean"],"values":[[0,3]]}
+				]}]`, nil), nil
+			case strings.HasPrefix(q.Command, "SHOW"):
+				return mocks.NewResponse(`[{"statement_id":0,"error":"database not found: nope"}]`, nil), nil
+			}
+			t.Errorf("unexpected query %s", q.Command)
+			return nil, chronograf.ErrUpstreamTimeout
+		},
+	}
+}
+
+func TestService_InspectQuery(t *testing.T) {
+	v2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !strings.Contains(string(body), `profiler.enabledProfilers`) {
+			t.Errorf("flux query without profiler: %s", body)
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write([]byte("#datatype,string,long,string,double\r\n" +
+			"#group,false,false,true,false\r\n" +
+			"#default,_result,,,\r\n" +
+			",result,table,host,_value\r\n" +
+			",,0,a,1\r\n" +
+			",,0,a,2\r\n" +
+			",,1,b,3\r\n" +
+			"\r\n" +
+			"#datatype,string,long,string,long\r\n" +
+			"#group,false,false,true,false\r\n" +
+			"#default,_profiler,,,\r\n" +
+			",result,table,_measurement,TotalDuration\r\n" +
+			",,0,profiler/query,1200\r\n" +
+			"\r\n"))
+	}))
+	defer v2.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, Type: chronograf.InfluxDBv1}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: v2.URL, Type: chronograf.InfluxDBv2, Username: "org"}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		body       string
+		wantStatus int
+		check      func(t *testing.T, body string)
+	}{
+		{
+			name:       "InfluxQL",
+			id:         "1",
+			body:       `{"query":"` + inspectedSelect + `","analyze":true}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body string) {
+				for _, want := range []string{
+					`"series":2`, `"points":3`,
+					`"explain":["EXPRESSION: mean(usage_idle::float)","NUMBER OF SERIES: 2"]`,
+					`"analyze":[".","└── select"]`,
+				} {
+					if !strings.Contains(body, want) {
+						t.Errorf("body %s does not contain %s", body, want)
+					}
+				}
+			},
+		},
+		{
+			name:       "failing InfluxQL",
+			id:         "1",
+			body:       `{"query":"SHOW MEASUREMENTS ON nope"}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body string) {
+				if !strings.Contains(body, `"error":"database not found: nope"`) || strings.Contains(body, `"explain"`) {
+					t.Errorf("unexpected body %s", body)
+				}
+			},
+		},
+		{
+			name:       "Flux",
+			id:         "2",
+			body:       `{"query":"from(bucket: \"b\") |> range(start: -1h)","type":"flux"}`,
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body string) {
+				for _, want := range []string{
+					`"series":2`, `"points":3`,
+					`"profile":[{"TotalDuration":"1200","_measurement":"profiler/query"}]`,
+				} {
+					if !strings.Contains(body, want) {
+						t.Errorf("body %s does not contain %s", body, want)
+					}
+				}
+			},
+		},
+		{
+			name:       "write",
+			id:         "1",
+			body:       `{"query":"DROP DATABASE telegraf"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "unknown source",
+			id:         "3",
+			body:       `{"query":"` + inspectedSelect + `"}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store:            &mocks.Store{SourcesStore: sources},
+				TimeSeriesClient: inspectorTimeSeries(t, "", 0),
+				Logger:           log.New(log.DebugLevel),
+			}
+			r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: tt.id}}))
+			w := httptest.NewRecorder()
+			s.InspectQuery(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.check != nil {
+				tt.check(t, string(body))
+			}
+		})
+	}
+}
+
+func TestService_InspectDashboard(t *testing.T) {
+	slow := `SELECT max(usage_user) FROM telegraf.autogen.cpu WHERE time > now() - 1h`
+	dashboard := chronograf.Dashboard{
+		ID: 1,
+		Cells: []chronograf.DashboardCell{
+			{
+				ID:   "fast",
+				Name: "Fast",
+				Queries: []chronograf.DashboardQuery{
+					{Command: inspectedSelect, Source: "/chronograf/v1/sources/1"},
+				},
+			},
+			{
+				ID:   "slow",
+				Name: "Slow",
+				Queries: []chronograf.DashboardQuery{
+					{Command: `SELECT max(usage_user) FROM telegraf.autogen.cpu WHERE time > :dashboardTime:`, Source: "/chronograf/v1/sources/1"},
+					{Command: inspectedSelect},
+				},
+			},
+		},
+	}
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+					return dashboard, nil
+				},
+			},
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+					return chronograf.Source{ID: id}, nil
+				},
+			},
+		},
+		TimeSeriesClient: inspectorTimeSeries(t, slow, 20*time.Millisecond),
+		Logger:           log.New(log.DebugLevel),
+	}
+
+	r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(`{}`))
+	r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+	w := httptest.NewRecorder()
+	s.InspectDashboard(w, r)
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d: %s", resp.StatusCode, body)
+	}
+	got := string(body)
+	slowAt := strings.Index(got, `"cellID":"slow"`)
+	fastAt := strings.Index(got, `"cellID":"fast"`)
+	if slowAt < 0 || fastAt < 0 || slowAt > fastAt {
+		t.Errorf("the slow query is not ranked first: %s", got)
+	}
+	if !strings.Contains(got, `"error":"the query has no source"`) {
+		t.Errorf("the query without source is not reported: %s", got)
+	}
+	if !strings.Contains(got, `"self":"/chronograf/v1/dashboards/1/inspect"`) {
+		t.Errorf("unexpected links: %s", got)
+	}
+}
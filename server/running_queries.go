@@ -0,0 +1,232 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/enterprise"
+)
+
+type runningQueryLinks struct {
+	Self string `json:"self"`
+}
+
+type runningQuery struct {
+	ID       string            `json:"id"`             // ID identifies the query within the source; it is prefixed with the data node ID on Enterprise
+	QID      uint64            `json:"qid"`            // QID is the ID of the query within its data node
+	Node     uint64            `json:"node,omitempty"` // Node is the ID of the Enterprise data node running the query
+	User     string            `json:"user,omitempty"`
+	Database string            `json:"database"`
+	Duration string            `json:"duration"`
+	Status   string            `json:"status,omitempty"`
+	Query    string            `json:"query"`
+	Links    runningQueryLinks `json:"links"`
+}
+
+type dataNodeError struct {
+	Node  uint64 `json:"node"`
+	Error string `json:"error"`
+}
+
+type runningQueriesResponse struct {
+	Queries []runningQuery  `json:"queries"`
+	Errors  []dataNodeError `json:"errors,omitempty"` // Errors of the Enterprise data nodes that could not list their queries
+}
+
+// RunningQueries lists the queries running on an InfluxDB 1.x source, or on
+// all data nodes of an Enterprise source
+func (s *Service) RunningQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, ts, ok := s.sourceV1TimeSeries(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	q := chronograf.Query{Command: "SHOW QUERIES"}
+	res := runningQueriesResponse{Queries: []runningQuery{}}
+	if cl, ok := ts.(*enterprise.Client); ok && len(cl.DataNodes()) > 0 {
+		seen := map[string]bool{}
+		for _, dn := range cl.QueryDataNodes(ctx, q) {
+			queries, err := runningQueries(dn.Response, dn.Err, dn.ID)
+			if err != nil {
+				res.Errors = append(res.Errors, dataNodeError{Node: dn.ID, Error: err.Error()})
+				continue
+			}
+			// data nodes may report the queries of the whole cluster
+			for _, rq := range queries {
+				if !seen[rq.ID] {
+					seen[rq.ID] = true
+					res.Queries = append(res.Queries, rq)
+				}
+			}
+		}
+	} else {
+		response, err := ts.Query(ctx, q)
+		queries, err := runningQueries(response, err, 0)
+		if err != nil {
+			Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+			return
+		}
+		res.Queries = queries
+	}
+
+	for i := range res.Queries {
+		res.Queries[i].Links.Self = fmt.Sprintf("/chronograf/v1/sources/%d/queries/running/%s", srcID, res.Queries[i].ID)
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// KillQuery kills a running query of an InfluxDB 1.x source. The queries of
+// Enterprise sources are killed on the data node running them.
+func (s *Service) KillQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	node, qid, err := parseRunningQueryID(httprouter.GetParamFromContext(ctx, "qid"))
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+	_, ts, ok := s.sourceV1TimeSeries(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	q := chronograf.Query{Command: fmt.Sprintf("KILL QUERY %d", qid)}
+	var response chronograf.Response
+	if node != 0 {
+		cl, ok := ts.(*enterprise.Client)
+		if !ok {
+			Error(w, http.StatusNotFound, fmt.Sprintf("No running query %d on data node %d", qid, node), s.Logger)
+			return
+		}
+		response, err = cl.QueryDataNode(ctx, node, q)
+	} else {
+		response, err = ts.Query(ctx, q)
+	}
+	if err == nil {
+		var results []influxQLResult
+		if results, _, err = influxQLResultsOf(response); err == nil {
+			err = influxQLError(results)
+		}
+	}
+	switch {
+	case err == enterprise.ErrDataNodeNotFound:
+		Error(w, http.StatusNotFound, fmt.Sprintf("Data node %d not found", node), s.Logger)
+	case err != nil && strings.Contains(err.Error(), "no such query"):
+		Error(w, http.StatusNotFound, fmt.Sprintf("No running query %s", httprouter.GetParamFromContext(ctx, "qid")), s.Logger)
+	case err != nil:
+		Error(w, http.StatusBadGateway, err.Error(), s.Logger)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// runningQueries parses the response of SHOW QUERIES. Queries of Enterprise
+// data nodes are identified by the data node they run on, which is node
+// unless the response reports it.
+func runningQueries(response chronograf.Response, err error, node uint64) ([]runningQuery, error) {
+	if err != nil {
+		return nil, err
+	}
+	results, _, err := influxQLResultsOf(response)
+	if err == nil {
+		err = influxQLError(results)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []runningQuery{}
+	for _, res := range results {
+		for _, series := range res.Series {
+			for _, values := range series.Values {
+				rq := runningQuery{Node: node}
+				for i, column := range series.Columns {
+					if i >= len(values) || values[i] == nil {
+						continue
+					}
+					v := fmt.Sprint(values[i])
+					switch column {
+					case "qid":
+						rq.QID = uint64(cardinalityCount(values[i]))
+					case "node_id":
+						if id, err := strconv.ParseUint(v, 10, 64); err == nil {
+							rq.Node = id
+						}
+					case "user", "username":
+						rq.User = v
+					case "database":
+						rq.Database = v
+					case "duration":
+						rq.Duration = v
+					case "status":
+						rq.Status = v
+					case "query":
+						rq.Query = v
+					}
+				}
+				rq.ID = strconv.FormatUint(rq.QID, 10)
+				if rq.Node != 0 {
+					rq.ID = fmt.Sprintf("%d-%d", rq.Node, rq.QID)
+				}
+				queries = append(queries, rq)
+			}
+		}
+	}
+	return queries, nil
+}
+
+// parseRunningQueryID parses the ID of a running query, which is prefixed
+// with the ID of its data node on Enterprise sources
+func parseRunningQueryID(id string) (uint64, uint64, error) {
+	nodeID, qid := "", id
+	if i := strings.Index(id, "-"); i >= 0 {
+		nodeID, qid = id[:i], id[i+1:]
+	}
+	q, err := strconv.ParseUint(qid, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid query ID %q", id)
+	}
+	if nodeID == "" {
+		return 0, q, nil
+	}
+	node, err := strconv.ParseUint(nodeID, 10, 64)
+	if err != nil || node == 0 {
+		return 0, 0, fmt.Errorf("invalid query ID %q", id)
+	}
+	return node, q, nil
+}
+
+// sourceV1TimeSeries connects to the InfluxDB 1.x source of the request. It
+// responds with an error and returns false if the source is not an InfluxDB
+// 1.x source.
+func (s *Service) sourceV1TimeSeries(ctx context.Context, w http.ResponseWriter, r *http.Request) (int, chronograf.TimeSeries, bool) {
+	srcID, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return 0, nil, false
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		notFound(w, srcID, s.Logger)
+		return 0, nil, false
+	}
+	if src.Type == chronograf.InfluxDBv2 || chronograf.IsV3SrcType(src.Type) {
+		Error(w, http.StatusUnprocessableEntity, fmt.Sprintf("Source %d is not an InfluxDB 1.x source", srcID), s.Logger)
+		return 0, nil, false
+	}
+	ts, err := s.TimeSeries(src)
+	if err == nil {
+		err = ts.Connect(ctx, &src)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("Unable to connect to source %d: %v", srcID, err)
+		Error(w, http.StatusBadRequest, msg, s.Logger)
+		return 0, nil, false
+	}
+	return srcID, ts, true
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestParseRunningQueryID(t *testing.T) {
+	tests := []struct {
+		id       string
+		wantNode uint64
+		wantQID  uint64
+		wantErr  bool
+	}{
+		{id: "42", wantQID: 42},
+		{id: "5-42", wantNode: 5, wantQID: 42},
+		{id: "0-42", wantErr: true},
+		{id: "a-42", wantErr: true},
+		{id: "5-", wantErr: true},
+		{id: "query", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			node, qid, err := parseRunningQueryID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRunningQueryID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if node != tt.wantNode || qid != tt.wantQID {
+				t.Errorf("parseRunningQueryID() = %d, %d, want %d, %d", node, qid, tt.wantNode, tt.wantQID)
+			}
+		})
+	}
+}
+
+// dataNodeStandIn answers SHOW QUERIES with a query of its own and KILL
+// QUERY of that query, recording the commands it receives
+func dataNodeStandIn(qid int, query string, mu *sync.Mutex, commands *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		*commands = append(*commands, r.Host+" "+q)
+		mu.Unlock()
+		switch q {
+		case "SHOW QUERIES":
+			fmt.Fprintf(w, `{"results":[{"statement_id":0,"series":[{"columns":["qid","query","database","duration","status"],"values":[
+				[%d,%q,"telegraf","12s","running"]
+			]}]}]}`, qid, query)
+		case fmt.Sprintf("KILL QUERY %d", qid):
+			w.Write([]byte(`{"results":[{"statement_id":0}]}`))
+		default:
+			w.Write([]byte(`{"results":[{"statement_id":0,"error":"no such query id"}]}`))
+		}
+	}))
+}
+
+func TestService_RunningQueries(t *testing.T) {
+	var mu sync.Mutex
+	commands := []string{}
+	dn1 := dataNodeStandIn(7, `SELECT * FROM cpu`, &mu, &commands)
+	defer dn1.Close()
+	dn2 := dataNodeStandIn(7, `SELECT * FROM mem`, &mu, &commands)
+	defer dn2.Close()
+	meta := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[
+			{"id":4,"httpAddr":%q,"httpScheme":"http","status":"joined"},
+			{"id":5,"httpAddr":%q,"httpScheme":"http","status":"joined"}
+		],"meta":[]}`, strings.TrimPrefix(dn1.URL, "http://"), strings.TrimPrefix(dn2.URL, "http://"))
+	}))
+	defer meta.Close()
+	oss := dataNodeStandIn(3, `SELECT mean(usage_idle) FROM cpu`, &mu, &commands)
+	defer oss.Close()
+
+	sources := &mocks.SourcesStore{
+		GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+			switch ID {
+			case 1:
+				return chronograf.Source{ID: 1, URL: oss.URL, Type: chronograf.InfluxDBv1}, nil
+			case 2:
+				return chronograf.Source{ID: 2, URL: dn1.URL, MetaURL: meta.URL, Type: chronograf.InfluxDBv1Enterprise}, nil
+			case 3:
+				return chronograf.Source{ID: 3, URL: oss.URL, Type: chronograf.InfluxDBv2}, nil
+			}
+			return chronograf.Source{}, chronograf.ErrSourceNotFound
+		},
+	}
+	s := &Service{
+		Store:            &mocks.Store{SourcesStore: sources},
+		TimeSeriesClient: &InfluxClient{},
+		Logger:           log.New(log.DebugLevel),
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		method       string
+		params       httprouter.Params
+		wantStatus   int
+		wantBody     string
+		wantCommands []string
+	}{
+		{
+			name:       "1.x",
+			handler:    s.RunningQueries,
+			params:     httprouter.Params{{Key: "id", Value: "1"}},
+			wantStatus: http.StatusOK,
+			wantBody: `{"queries":[{"id":"3","qid":3,"database":"telegraf","duration":"12s","status":"running","query":"SELECT mean(usage_idle) FROM cpu",
+				"links":{"self":"/chronograf/v1/sources/1/queries/running/3"}}]}`,
+		},
+		{
+			name:       "Enterprise",
+			handler:    s.RunningQueries,
+			params:     httprouter.Params{{Key: "id", Value: "2"}},
+			wantStatus: http.StatusOK,
+			wantBody: `{"queries":[
+				{"id":"4-7","qid":7,"node":4,"database":"telegraf","duration":"12s","status":"running","query":"SELECT * FROM cpu","links":{"self":"/chronograf/v1/sources/2/queries/running/4-7"}},
+				{"id":"5-7","qid":7,"node":5,"database":"telegraf","duration":"12s","status":"running","query":"SELECT * FROM mem","links":{"self":"/chronograf/v1/sources/2/queries/running/5-7"}}
+			]}`,
+		},
+		{
+			name:       "2.x",
+			handler:    s.RunningQueries,
+			params:     httprouter.Params{{Key: "id", Value: "3"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "kill 1.x query",
+			handler:      s.KillQuery,
+			method:       "DELETE",
+			params:       httprouter.Params{{Key: "id", Value: "1"}, {Key: "qid", Value: "3"}},
+			wantStatus:   http.StatusNoContent,
+			wantCommands: []string{strings.TrimPrefix(oss.URL, "http://") + " KILL QUERY 3"},
+		},
+		{
+			name:         "kill Enterprise query on its data node",
+			handler:      s.KillQuery,
+			method:       "DELETE",
+			params:       httprouter.Params{{Key: "id", Value: "2"}, {Key: "qid", Value: "5-7"}},
+			wantStatus:   http.StatusNoContent,
+			wantCommands: []string{strings.TrimPrefix(dn2.URL, "http://") + " KILL QUERY 7"},
+		},
+		{
+			name:       "kill query of unknown data node",
+			handler:    s.KillQuery,
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "2"}, {Key: "qid", Value: "6-7"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "kill query that is not running",
+			handler:    s.KillQuery,
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "qid", Value: "4"}},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "kill invalid query",
+			handler:    s.KillQuery,
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "qid", Value: "all"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			commands = commands[:0]
+			mu.Unlock()
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if tt.wantCommands != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				if strings.Join(commands, "\n") != strings.Join(tt.wantCommands, "\n") {
+					t.Errorf("commands = %v, want %v", commands, tt.wantCommands)
+				}
+			}
+		})
+	}
+}
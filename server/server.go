@@ -25,6 +25,7 @@ import (
 
 	basicAuth "github.com/abbot/go-http-auth"
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
 	idgen "github.com/influxdata/chronograf/id"
 	"github.com/influxdata/chronograf/influx"
 	"github.com/influxdata/chronograf/kv"
@@ -84,6 +85,7 @@ type Server struct {
 	Develop            bool          `short:"d" long:"develop" description:"Run server in develop mode."`
 	BoltPath           string        `short:"b" long:"bolt-path" description:"Full path to boltDB file (e.g. './chronograf-v1.db')" env:"BOLT_PATH" default:"chronograf-v1.db"`
 	CannedPath         string        `short:"c" long:"canned-path" description:"Path to directory of pre-canned application layouts (/usr/share/chronograf/canned)" env:"CANNED_PATH" default:"canned"`
+	CatalogURL         string        `long:"catalog-url" description:"URL or path of the catalog of protoboard and layout packages, the location of its index.json or of the directory of the index" env:"CATALOG_URL"`
 	ProtoboardsPath    string        `long:"protoboards-path" description:"Path to directory of protoboards (/usr/share/chronograf/protoboards)" env:"PROTOBOARDS_PATH" default:"protoboards"`
 	ResourcesPath      string        `long:"resources-path" description:"Path to directory of pre-canned dashboards, sources, kapacitors, and organizations (/usr/share/chronograf/resources)" env:"RESOURCES_PATH" default:"canned"`
 	TokenSecret        string        `short:"t" long:"token-secret" description:"Secret to sign tokens" env:"TOKEN_SECRET"`
@@ -161,6 +163,16 @@ type Server struct {
 	TLSMinVersion string `long:"tls-min-version" description:"Minimum version of the TLS protocol that will be negotiated." default:"1.2" env:"TLS_MIN_VERSION"`
 	TLSMaxVersion string `long:"tls-max-version" description:"Maximum version of the TLS protocol that will be negotiated." env:"TLS_MAX_VERSION"`
 
+	DashboardsSyncDir      string        `long:"dashboards-sync-dir" description:"Path to a directory of YAML or JSON dashboard definitions that are synced into the database. Definitions within a sub-directory belong to the organization with the ID of the sub-directory." env:"DASHBOARDS_SYNC_DIR"`
+	DashboardsSyncMode     string        `long:"dashboards-sync-mode" value-name:"choice" choice:"refuse" choice:"drift" default:"refuse" description:"Whether changes to synced dashboards are refused or allowed and reported as drift" env:"DASHBOARDS_SYNC_MODE"`
+	DashboardsSyncInterval time.Duration `long:"dashboards-sync-interval" default:"30s" description:"Interval at which the dashboards sync directory is checked for changes" env:"DASHBOARDS_SYNC_INTERVAL"`
+	DashboardsSyncPrune    bool          `long:"dashboards-sync-prune" description:"Delete synced dashboards whose definition was removed from the dashboards sync directory" env:"DASHBOARDS_SYNC_PRUNE"`
+
+	QueryTimeout         time.Duration `long:"query-timeout" description:"Maximum duration of queries to sources that do not set their own. 0 means no timeout." env:"QUERY_TIMEOUT"`
+	MaxConcurrentQueries int           `long:"max-concurrent-queries" description:"Maximum number of in-flight queries to each source that does not set its own. 0 means no limit." env:"MAX_CONCURRENT_QUERIES"`
+	MaxResponseSize      int64         `long:"max-response-size" description:"Maximum size in bytes of query responses of sources that do not set their own. 0 means no limit." env:"MAX_RESPONSE_SIZE"`
+	MaxLiveQueries       int           `long:"max-live-queries" default:"10" description:"Maximum number of live dashboard queries streamed to each user at once. 0 means no limit." env:"MAX_LIVE_QUERIES"`
+
 	oauthClient http.Client
 }
 
@@ -754,6 +766,38 @@ func (s *Server) Serve(ctx context.Context) {
 			ClusteredClusterID:          s.InfluxDBClusteredClusterID,
 			TimeConditionExpr:           v3TimeConditionExpr,
 		})
+	service.QueryLimits = influx.QueryLimits{
+		Timeout:          s.QueryTimeout,
+		MaxConcurrent:    s.MaxConcurrentQueries,
+		MaxResponseBytes: s.MaxResponseSize,
+	}
+	service.TimeSeriesClient = &InfluxClient{QueryLimits: service.QueryLimits}
+	service.Live = &LiveQueries{MaxPerClient: s.MaxLiveQueries}
+	if service.ShareSecret, err = NewShareSecret(s.TokenSecret); err != nil {
+		logger.
+			WithField("component", "server").
+			Error("Unable to create key for dashboard shares: ", err)
+		return
+	}
+	if s.TokenSecret == "" {
+		logger.
+			WithField("component", "server").
+			Info("No token secret given, dashboard share links will become invalid when chronograf restarts")
+	}
+	if s.DashboardsSyncDir != "" {
+		service.DashboardSync = &DashboardSyncer{
+			Dir:      s.DashboardsSyncDir,
+			Mode:     s.DashboardsSyncMode,
+			Prune:    s.DashboardsSyncPrune,
+			Interval: s.DashboardsSyncInterval,
+			Store:    service.Store,
+			Logger:   logger,
+		}
+		go service.DashboardSync.Run(ctx)
+	}
+	if s.CatalogURL != "" {
+		service.Catalog = &catalog.Client{Location: s.CatalogURL}
+	}
 	service.SuperAdminProviderGroups = superAdminProviderGroups{
 		auth0: s.Auth0SuperAdminOrg,
 	}
@@ -938,7 +982,7 @@ func openService(ctx context.Context, db kv.Store, builder builders, logger chro
 		os.Exit(1)
 	}
 
-	layouts, err := builder.Layouts.Build()
+	layouts, err := builder.Layouts.Build(svc.LayoutsStore())
 	if err != nil {
 		logger.
 			WithField("component", "LayoutsStore").
@@ -959,6 +1003,7 @@ func openService(ctx context.Context, db kv.Store, builder builders, logger chro
 			ConfigStore:             svc.ConfigStore(),
 			MappingsStore:           svc.MappingsStore(),
 			OrganizationConfigStore: svc.OrganizationConfigStore(),
+			PackagesStore:           svc.PackagesStore(),
 		},
 		Logger:    logger,
 		UseAuth:   useAuth,
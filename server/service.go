@@ -2,9 +2,13 @@ package server
 
 import (
 	"context"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
 	"github.com/influxdata/chronograf/enterprise"
 	"github.com/influxdata/chronograf/influx"
 )
@@ -19,6 +23,11 @@ type Service struct {
 	Env                      chronograf.Environment
 	Databases                chronograf.Databases
 	V3Config                 chronograf.V3Config
+	ShareSecret              []byte             // ShareSecret signs the tokens of dashboard shares
+	DashboardSync            *DashboardSyncer   // DashboardSync is set if dashboards are synced from a directory
+	QueryLimits              influx.QueryLimits // QueryLimits are the default limits of the queries to sources
+	Catalog                  *catalog.Client    // Catalog is set if packages are installed from a catalog
+	Live                     *LiveQueries       // Live shares the polling of the live queries of dashboards
 }
 
 type superAdminProviderGroups struct {
@@ -42,22 +51,119 @@ func (s *Service) TimeSeries(src chronograf.Source) (chronograf.TimeSeries, erro
 	return s.TimeSeriesClient.New(src, s.Logger, s.V3Config)
 }
 
-// InfluxClient returns a new client to connect to OSS or Enterprise
-type InfluxClient struct{}
+// InfluxClient returns a new client to connect to OSS or Enterprise. The
+// clients of Enterprise sources are kept, so that the health of their data
+// nodes is monitored across requests.
+type InfluxClient struct {
+	HealthCheckInterval time.Duration      // HealthCheckInterval of Enterprise data nodes; zero uses the default
+	RefreshInterval     time.Duration      // RefreshInterval of the data nodes of Enterprise clusters; zero uses the default
+	QueryLimits         influx.QueryLimits // QueryLimits are the default limits of the queries to sources
+
+	mu       sync.Mutex
+	clusters map[int]*monitoredCluster
+}
+
+// clusterConnectTimeout bounds the discovery of the data nodes of a cluster
+const clusterConnectTimeout = 10 * time.Second
+
+// sourceForgetter is implemented by time series clients that keep the state
+// of sources across requests
+type sourceForgetter interface {
+	Forget(srcID int)
+}
+
+// forgetSource drops the state kept for a removed or changed source
+func (s *Service) forgetSource(srcID int) {
+	if f, ok := s.TimeSeriesClient.(sourceForgetter); ok {
+		f.Forget(srcID)
+	}
+}
+
+// monitoredCluster is the client of an Enterprise source whose data nodes
+// are monitored
+type monitoredCluster struct {
+	key    string
+	client *enterprise.Client
+	cancel context.CancelFunc
+}
 
 // New creates a client to connect to OSS or enterprise
 func (c *InfluxClient) New(src chronograf.Source, logger chronograf.Logger, v3Config chronograf.V3Config) (chronograf.TimeSeries, error) {
 	client := &influx.Client{
 		Logger:   logger,
 		V3Config: v3Config,
+		Limits:   c.QueryLimits,
 	}
 	if err := client.Connect(context.TODO(), &src); err != nil {
 		return nil, err
 	}
 	if src.Type == chronograf.InfluxDBv1Enterprise && src.MetaURL != "" {
-		tls := strings.Contains(src.MetaURL, "https")
-		insecure := src.InsecureSkipVerify
-		return enterprise.NewClientWithTimeSeries(logger, src.MetaURL, influx.DefaultAuthorization(&src), tls, insecure, client)
+		return c.cluster(src, logger, client)
 	}
 	return client, nil
 }
+
+// cluster returns the monitored client of an Enterprise source. Queries and
+// writes are sent to the source URL while no data node is healthy.
+func (c *InfluxClient) cluster(src chronograf.Source, logger chronograf.Logger, fallback chronograf.TimeSeries) (chronograf.TimeSeries, error) {
+	// a changed source gets a new client
+	key := strings.Join([]string{src.MetaURL, src.URL, src.Username, src.Password, src.SharedSecret, strconv.FormatBool(src.InsecureSkipVerify),
+		strconv.Itoa(src.QueryTimeout), strconv.Itoa(src.MaxConcurrentQueries), strconv.FormatInt(src.MaxResponseBytes, 10)}, "\x00")
+
+	c.mu.Lock()
+	if mc, ok := c.clusters[src.ID]; ok && mc.key == key {
+		c.mu.Unlock()
+		return mc.client, nil
+	}
+	c.mu.Unlock()
+
+	tls := strings.Contains(src.MetaURL, "https")
+	cl, err := enterprise.NewClientWithURL(src.MetaURL, influx.DefaultAuthorization(&src), tls, src.InsecureSkipVerify, logger)
+	if err != nil {
+		return nil, err
+	}
+	cl.Fallback = fallback
+	cl.Limits = c.QueryLimits
+	// the data nodes are discovered without the lock, so that an unreachable
+	// meta node does not block the clients of other sources
+	connectCtx, cancelConnect := context.WithTimeout(context.Background(), clusterConnectTimeout)
+	err = cl.Connect(connectCtx, &src)
+	cancelConnect()
+	if err != nil {
+		logger.
+			WithField("component", "enterprise").
+			WithField("source", src.ID).
+			Error("Unable to discover data nodes, using the source URL: ", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.clusters == nil {
+		c.clusters = map[int]*monitoredCluster{}
+	}
+	if mc, ok := c.clusters[src.ID]; ok {
+		// a concurrent request connected the same source first
+		if mc.key == key {
+			return mc.client, nil
+		}
+		mc.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go cl.Monitor(ctx, c.HealthCheckInterval, c.RefreshInterval)
+	c.clusters[src.ID] = &monitoredCluster{
+		key:    key,
+		client: cl,
+		cancel: cancel,
+	}
+	return cl, nil
+}
+
+// Forget stops monitoring the cluster of a removed or changed source
+func (c *InfluxClient) Forget(srcID int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if mc, ok := c.clusters[srcID]; ok {
+		mc.cancel()
+		delete(c.clusters, srcID)
+	}
+}
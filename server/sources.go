@@ -338,6 +338,8 @@ func (s *Service) RemoveSource(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.forgetSource(id)
+
 	// Remove all the associated kapacitors for this source
 	if err = s.removeSrcsKapa(ctx, id); err != nil {
 		unknownErrorWithMessage(w, err, s.Logger)
@@ -347,7 +349,8 @@ func (s *Service) RemoveSource(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// SourceHealth determines if the tsdb is running
+// SourceHealth determines if the tsdb is running. For Enterprise sources it
+// responds with the health of the data nodes of the cluster.
 func (s *Service) SourceHealth(w http.ResponseWriter, r *http.Request) {
 	id, err := paramID("id", r)
 	if err != nil {
@@ -377,9 +380,27 @@ func (s *Service) SourceHealth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Enterprise sources also report the health of their data nodes
+	if src.Type == chronograf.InfluxDBv1Enterprise && src.MetaURL != "" {
+		ts, err := s.TimeSeries(src)
+		if err != nil {
+			Error(w, http.StatusBadRequest, "Error contacting source", s.Logger)
+			return
+		}
+		if cluster, ok := ts.(*enterprise.Client); ok {
+			res := sourceHealthResponse{DataNodes: cluster.DataNodes()}
+			encodeJSON(w, http.StatusOK, res, s.Logger)
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusNoContent)
 }
 
+type sourceHealthResponse struct {
+	DataNodes []enterprise.DataNodeHealth `json:"dataNodes"`
+}
+
 // removeSrcsKapa will remove all kapacitors and kapacitor rules from the stores.
 // However, it will not remove the kapacitor tickscript from kapacitor itself.
 func (s *Service) removeSrcsKapa(ctx context.Context, srcID int) error {
@@ -461,6 +482,9 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	src.ClusterID = req.ClusterID
 	src.AccountID = req.AccountID
 	src.TagsCSVPath = req.TagsCSVPath
+	src.QueryTimeout = req.QueryTimeout
+	src.MaxConcurrentQueries = req.MaxConcurrentQueries
+	src.MaxResponseBytes = req.MaxResponseBytes
 
 	defaultOrg, err := s.Store.Organizations(ctx).DefaultOrganization(ctx)
 	if err != nil {
@@ -494,6 +518,7 @@ func (s *Service) UpdateSource(w http.ResponseWriter, r *http.Request) {
 			Error(w, http.StatusInternalServerError, msg, s.Logger)
 			return
 		}
+		s.forgetSource(id)
 	}
 	encodeJSON(w, http.StatusOK, newSourceResponse(context.Background(), src), s.Logger)
 }
@@ -534,6 +559,10 @@ func ValidSourceRequest(s *chronograf.Source, defaultOrgID string) error {
 		return fmt.Errorf("invalid URL; no URL scheme defined")
 	}
 
+	if s.QueryTimeout < 0 || s.MaxConcurrentQueries < 0 || s.MaxResponseBytes < 0 {
+		return fmt.Errorf("query limits must not be negative")
+	}
+
 	if s.Type == chronograf.InfluxDBv3Core || s.Type == chronograf.InfluxDBv3Enterprise {
 		if len(s.DatabaseToken) == 0 {
 			return fmt.Errorf("database token required")
@@ -2842,3 +2842,72 @@ func TestService_SourceRoles(t *testing.T) {
 		}
 	}
 }
+
+func TestService_SourceHealth(t *testing.T) {
+	// ts serves as data node and as meta node of an Enterprise cluster
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ping":
+			w.Header().Set("X-Influxdb-Version", "1.11.8-c1.11.8")
+			w.WriteHeader(http.StatusNoContent)
+		case "/show-cluster":
+			fmt.Fprintf(w, `{"data":[{"id":2,"httpAddr":%q}],"meta":[]}`, ts.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	tests := []struct {
+		name           string
+		src            chronograf.Source
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name:           "OSS source",
+			src:            chronograf.Source{ID: 1, URL: ts.URL, Type: chronograf.InfluxDBv1},
+			wantStatusCode: http.StatusNoContent,
+		},
+		{
+			name:           "Enterprise source reports data nodes",
+			src:            chronograf.Source{ID: 2, URL: ts.URL, MetaURL: ts.URL, Type: chronograf.InfluxDBv1Enterprise},
+			wantStatusCode: http.StatusOK,
+			wantBody:       fmt.Sprintf(`{"dataNodes":[{"id":2,"url":%q,"healthy":true,"lastCheck":"0001-01-01T00:00:00Z"}]}`+"\n", ts.URL),
+		},
+		{
+			name:           "unreachable source",
+			src:            chronograf.Source{ID: 3, URL: "http://127.0.0.1:1", Type: chronograf.InfluxDBv1},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return tt.src, nil
+						},
+					},
+				},
+				TimeSeriesClient: &InfluxClient{},
+				Logger:           log.New(log.DebugLevel),
+			}
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+			s.SourceHealth(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("SourceHealth() status = %v, want %v: %s", resp.StatusCode, tt.wantStatusCode, body)
+			}
+			if tt.wantBody != "" && string(body) != tt.wantBody {
+				t.Errorf("SourceHealth() body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/catalog"
+	"github.com/influxdata/chronograf/multistore"
 	"github.com/influxdata/chronograf/noop"
 	"github.com/influxdata/chronograf/organizations"
 	"github.com/influxdata/chronograf/roles"
@@ -93,6 +95,7 @@ type DataStore interface {
 	Dashboards(ctx context.Context) chronograf.DashboardsStore
 	Config(ctx context.Context) chronograf.ConfigStore
 	OrganizationConfig(ctx context.Context) chronograf.OrganizationConfigStore
+	Packages(ctx context.Context) chronograf.PackagesStore
 }
 
 // ensure that Store implements a DataStore
@@ -110,6 +113,7 @@ type Store struct {
 	OrganizationsStore      chronograf.OrganizationsStore
 	ConfigStore             chronograf.ConfigStore
 	OrganizationConfigStore chronograf.OrganizationConfigStore
+	PackagesStore           chronograf.PackagesStore
 }
 
 // Sources returns a noop.SourcesStore if the context has no organization specified
@@ -138,13 +142,33 @@ func (s *Store) Servers(ctx context.Context) chronograf.ServersStore {
 	return &noop.ServersStore{}
 }
 
-// Layouts returns all layouts in the underlying layouts store.
+// Layouts returns all layouts in the underlying layouts store and, if there
+// is an organization specified on context, the layouts of the packages
+// installed in the organization.
 func (s *Store) Layouts(ctx context.Context) chronograf.LayoutsStore {
+	if org, ok := hasOrganizationContext(ctx); ok && s.PackagesStore != nil {
+		return &multistore.Layouts{
+			Stores: []chronograf.LayoutsStore{
+				&catalog.LayoutsStore{Packages: s.PackagesStore, Organization: org},
+				s.LayoutsStore,
+			},
+		}
+	}
 	return s.LayoutsStore
 }
 
-// Protoboards returns all protoboards in the underlying protoboards store.
+// Protoboards returns all protoboards in the underlying protoboards store and,
+// if there is an organization specified on context, the protoboards of the
+// packages installed in the organization.
 func (s *Store) Protoboards(ctx context.Context) chronograf.ProtoboardsStore {
+	if org, ok := hasOrganizationContext(ctx); ok && s.PackagesStore != nil {
+		return &multistore.Protoboards{
+			Stores: []chronograf.ProtoboardsStore{
+				&catalog.ProtoboardsStore{Packages: s.PackagesStore, Organization: org},
+				s.ProtoboardsStore,
+			},
+		}
+	}
 	return s.ProtoboardsStore
 }
 
@@ -224,3 +248,12 @@ func (s *Store) Mappings(ctx context.Context) chronograf.MappingsStore {
 	}
 	return &noop.MappingsStore{}
 }
+
+// Packages returns the underlying PackagesStore, or a noop.PackagesStore if
+// packages are not stored.
+func (s *Store) Packages(ctx context.Context) chronograf.PackagesStore {
+	if s.PackagesStore == nil {
+		return &noop.PackagesStore{}
+	}
+	return s.PackagesStore
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+)
+
+type subscriptionLinks struct {
+	Self string `json:"self"` // Self link mapping to this resource
+}
+
+type subscriptionResponse struct {
+	chronograf.Subscription
+	Links subscriptionLinks `json:"links"` // Links are URI locations related to the subscription
+}
+
+func newSubscriptionResponse(srcID int, db string, sub chronograf.Subscription) subscriptionResponse {
+	base := "/chronograf/v1/sources"
+	return subscriptionResponse{
+		Subscription: sub,
+		Links: subscriptionLinks{
+			Self: fmt.Sprintf("%s/%d/dbs/%s/subscriptions/%s/%s", base, srcID, db, sub.RetentionPolicy, sub.Name),
+		},
+	}
+}
+
+type subscriptionsResponse struct {
+	Subscriptions []subscriptionResponse `json:"subscriptions"`
+}
+
+// Subscriptions lists the subscriptions of a database
+func (h *Service) Subscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	srcID, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	subs, err := dbsvc.AllSubscriptions(ctx, db)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+
+	res := subscriptionsResponse{
+		Subscriptions: make([]subscriptionResponse, len(subs)),
+	}
+	for i, sub := range subs {
+		res.Subscriptions[i] = newSubscriptionResponse(srcID, db, sub)
+	}
+	encodeJSON(w, http.StatusOK, res, h.Logger)
+}
+
+// NewSubscription creates a subscription of a retention policy of a database
+func (h *Service) NewSubscription(w http.ResponseWriter, r *http.Request) {
+	postedSub := &chronograf.Subscription{}
+	if err := json.NewDecoder(r.Body).Decode(postedSub); err != nil {
+		invalidJSON(w, h.Logger)
+		return
+	}
+	if err := ValidSubscriptionRequest(postedSub); err != nil {
+		invalidData(w, err, h.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	srcID, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	sub, err := dbsvc.CreateSubscription(ctx, db, postedSub)
+	if err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+	res := newSubscriptionResponse(srcID, db, *sub)
+	location(w, res.Links.Self)
+	encodeJSON(w, http.StatusCreated, res, h.Logger)
+}
+
+// DropSubscription removes a subscription from a retention policy of a database
+func (h *Service) DropSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	_, dbsvc, ok := h.sourceDatabases(ctx, w, r)
+	if !ok {
+		return
+	}
+
+	db := httprouter.GetParamFromContext(ctx, "db")
+	rp := httprouter.GetParamFromContext(ctx, "rp")
+	name := httprouter.GetParamFromContext(ctx, "name")
+	if err := dbsvc.DropSubscription(ctx, db, rp, name); err != nil {
+		Error(w, http.StatusBadRequest, err.Error(), h.Logger)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidSubscriptionRequest checks that a subscription has a name, a retention
+// policy, a mode of ALL or ANY and UDP or HTTP destinations
+func ValidSubscriptionRequest(sub *chronograf.Subscription) error {
+	if sub.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if sub.RetentionPolicy == "" {
+		return fmt.Errorf("retentionPolicy is required")
+	}
+	sub.Mode = strings.ToUpper(sub.Mode)
+	if sub.Mode != "ALL" && sub.Mode != "ANY" {
+		return fmt.Errorf("mode must be ALL or ANY")
+	}
+	if len(sub.Destinations) == 0 {
+		return fmt.Errorf("at least one destination is required")
+	}
+	for _, d := range sub.Destinations {
+		u, err := url.Parse(d)
+		if err != nil || u.Host == "" || !oneOf(u.Scheme, "udp", "http", "https") {
+			return fmt.Errorf("invalid destination %q: must be a udp, http or https URL", d)
+		}
+	}
+	return nil
+}
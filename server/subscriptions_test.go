@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestValidSubscriptionRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		sub      chronograf.Subscription
+		wantMode string
+		wantErr  bool
+	}{
+		{
+			name: "valid",
+			sub: chronograf.Subscription{
+				Name:            "kapacitor",
+				RetentionPolicy: "autogen",
+				Mode:            "any",
+				Destinations:    []string{"http://kapacitor:9092", "udp://kapacitor:9100"},
+			},
+			wantMode: "ANY",
+		},
+		{
+			name:    "missing retention policy",
+			sub:     chronograf.Subscription{Name: "kapacitor", Mode: "ALL", Destinations: []string{"http://kapacitor:9092"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			sub:     chronograf.Subscription{Name: "kapacitor", RetentionPolicy: "autogen", Mode: "SOME", Destinations: []string{"http://kapacitor:9092"}},
+			wantErr: true,
+		},
+		{
+			name:    "no destinations",
+			sub:     chronograf.Subscription{Name: "kapacitor", RetentionPolicy: "autogen", Mode: "ALL"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported destination scheme",
+			sub:     chronograf.Subscription{Name: "kapacitor", RetentionPolicy: "autogen", Mode: "ALL", Destinations: []string{"tcp://kapacitor:9092"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidSubscriptionRequest(&tt.sub)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidSubscriptionRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.sub.Mode != tt.wantMode {
+				t.Errorf("ValidSubscriptionRequest() mode = %s, want %s", tt.sub.Mode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestService_Subscriptions(t *testing.T) {
+	sub := chronograf.Subscription{
+		Name:            "kapacitor",
+		RetentionPolicy: "autogen",
+		Mode:            "ANY",
+		Destinations:    []string{"http://kapacitor:9092"},
+	}
+	tests := []struct {
+		name       string
+		handler    func(*Service) http.HandlerFunc
+		method     string
+		body       string
+		params     httprouter.Params
+		wantStatus int
+		wantBody   string
+		wantDrop   string
+	}{
+		{
+			name:       "list",
+			handler:    func(s *Service) http.HandlerFunc { return s.Subscriptions },
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"subscriptions":[{"name":"kapacitor","retentionPolicy":"autogen","mode":"ANY","destinations":["http://kapacitor:9092"],"links":{"self":"/chronograf/v1/sources/1/dbs/telegraf/subscriptions/autogen/kapacitor"}}]}`,
+		},
+		{
+			name:       "create",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewSubscription },
+			method:     "POST",
+			body:       `{"name":"kapacitor","retentionPolicy":"autogen","mode":"any","destinations":["http://kapacitor:9092"]}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"name":"kapacitor","retentionPolicy":"autogen","mode":"ANY","destinations":["http://kapacitor:9092"],"links":{"self":"/chronograf/v1/sources/1/dbs/telegraf/subscriptions/autogen/kapacitor"}}`,
+		},
+		{
+			name:       "create with invalid destination",
+			handler:    func(s *Service) http.HandlerFunc { return s.NewSubscription },
+			method:     "POST",
+			body:       `{"name":"kapacitor","retentionPolicy":"autogen","mode":"ANY","destinations":["kapacitor"]}`,
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "drop",
+			handler:    func(s *Service) http.HandlerFunc { return s.DropSubscription },
+			method:     "DELETE",
+			params:     httprouter.Params{{Key: "id", Value: "1"}, {Key: "db", Value: "telegraf"}, {Key: "rp", Value: "autogen"}, {Key: "name", Value: "kapacitor"}},
+			wantStatus: http.StatusNoContent,
+			wantDrop:   "telegraf/autogen/kapacitor",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dropped string
+			s := &Service{
+				Store: &mocks.Store{
+					SourcesStore: &mocks.SourcesStore{
+						GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+							return chronograf.Source{ID: ID}, nil
+						},
+					},
+				},
+				Databases: &mocks.Databases{
+					ConnectF: func(context.Context, *chronograf.Source) error { return nil },
+					AllSubscriptionsF: func(context.Context, string) ([]chronograf.Subscription, error) {
+						return []chronograf.Subscription{sub}, nil
+					},
+					CreateSubscriptionF: func(ctx context.Context, db string, s *chronograf.Subscription) (*chronograf.Subscription, error) {
+						return s, nil
+					},
+					DropSubscriptionF: func(ctx context.Context, db, rp, name string) error {
+						dropped = db + "/" + rp + "/" + name
+						return nil
+					},
+				},
+				Logger: log.New(log.DebugLevel),
+			}
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			r := httptest.NewRequest(method, "http://any.url", strings.NewReader(tt.body))
+			r = r.WithContext(httprouter.WithParams(context.Background(), tt.params))
+			w := httptest.NewRecorder()
+			tt.handler(s)(w, r)
+
+			resp := w.Result()
+			body, _ := ioutil.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			if tt.wantBody != "" {
+				if eq, _ := jsonEqual(string(body), tt.wantBody); !eq {
+					t.Errorf("body =\n%s\nwant\n%s", body, tt.wantBody)
+				}
+			}
+			if dropped != tt.wantDrop {
+				t.Errorf("dropped %q, want %q", dropped, tt.wantDrop)
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/flux"
+	"github.com/influxdata/chronograf/influx"
+	"github.com/influxdata/chronograf/roles"
+	"github.com/influxdata/influxql"
+)
+
+// relativeTime matches a time range bound relative to now, e.g. now() - 1h
+var relativeTime = regexp.MustCompile(`^now\(\)\s*-\s*([0-9a-zµ]+)$`)
+
+// templateValueTypes maps the type of a template to the type of its values
+var templateValueTypes = map[string]string{
+	"csv":          "csv",
+	"map":          "map",
+	"databases":    "database",
+	"measurements": "measurement",
+	"fieldKeys":    "fieldKey",
+	"tagKeys":      "tagKey",
+	"tagValues":    "tagValue",
+	"influxql":     "influxql",
+	"flux":         "flux",
+	"text":         "constant",
+}
+
+// ResolveTemplatesRequest specifies the time range and the selected values
+// used to resolve the templates of a dashboard
+type ResolveTemplatesRequest struct {
+	SourceID   string            `json:"sourceID,omitempty"`   // SourceID is the source used by templates and queries that do not specify one
+	Lower      string            `json:"lower,omitempty"`      // Lower is the lower bound of the time range; defaults to now() - 1h
+	Upper      string            `json:"upper,omitempty"`      // Upper is the upper bound of the time range; empty means now()
+	Selections map[string]string `json:"selections,omitempty"` // Selections are the values to select for template variables, e.g. {":host:": "server01"}
+}
+
+type resolvedTemplate struct {
+	chronograf.Template
+	Error string `json:"error,omitempty"` // Error is why the values of the template could not be resolved
+}
+
+type resolvedQuery struct {
+	Query          string `json:"query"`           // Query is the stored query of the cell
+	QueryTemplated string `json:"queryTemplated"`  // QueryTemplated is the query with all templates replaced
+	Type           string `json:"type"`            // Type is the language of the query
+	Source         string `json:"source"`          // Source is the link to the source the query is executed against
+	Error          string `json:"error,omitempty"` // Error is why the query could not be templated
+}
+
+type resolvedCell struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Queries []resolvedQuery `json:"queries"`
+}
+
+type resolveTemplatesLinks struct {
+	Self      string `json:"self"`      // Self link mapping to this resource
+	Dashboard string `json:"dashboard"` // Dashboard link to the dashboard whose templates were resolved
+}
+
+type resolveTemplatesResponse struct {
+	Templates []resolvedTemplate    `json:"templates"`
+	Cells     []resolvedCell        `json:"cells"`
+	Links     resolveTemplatesLinks `json:"links"`
+}
+
+// ResolveTemplates executes the queries of all templates of a dashboard in
+// the order of their dependencies, and returns their values together with the
+// queries of all cells rendered with the selected values.
+func (s *Service) ResolveTemplates(w http.ResponseWriter, r *http.Request) {
+	id, err := paramID("id", r)
+	if err != nil {
+		Error(w, http.StatusUnprocessableEntity, err.Error(), s.Logger)
+		return
+	}
+
+	ctx := r.Context()
+	d, err := s.Store.Dashboards(ctx).Get(ctx, chronograf.DashboardID(id))
+	if err != nil {
+		notFound(w, id, s.Logger)
+		return
+	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
+
+	var req ResolveTemplatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		invalidJSON(w, s.Logger)
+		return
+	}
+	if req.Lower == "" {
+		req.Lower = "now() - 1h"
+	}
+	if err := validTimeRange(req.Lower, req.Upper, time.Now()); err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	templates, cells, err := s.resolveDashboard(ctx, d, req)
+	if isReaderFluxError(err) {
+		Error(w, readerFluxErrorStatus(err), readerFluxErrorMessage(err), s.Logger)
+		return
+	} else if err != nil {
+		invalidData(w, err, s.Logger)
+		return
+	}
+
+	res := resolveTemplatesResponse{
+		Templates: templates,
+		Cells:     cells,
+		Links: resolveTemplatesLinks{
+			Self:      fmt.Sprintf("/chronograf/v1/dashboards/%d/templates/resolve", d.ID),
+			Dashboard: fmt.Sprintf("/chronograf/v1/dashboards/%d", d.ID),
+		},
+	}
+	encodeJSON(w, http.StatusOK, res, s.Logger)
+}
+
+// resolveDashboard resolves the templates of the dashboard in the order of
+// their dependencies and renders the queries of all cells with the selected
+// values. The time range of the request must be valid.
+func (s *Service) resolveDashboard(ctx context.Context, d chronograf.Dashboard, req ResolveTemplatesRequest) ([]resolvedTemplate, []resolvedCell, error) {
+	ordered, err := influx.ResolveOrder(d.Templates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	timeRange := influx.TimeRangeTemplates(req.Lower, req.Upper)
+	resolved := map[string]resolvedTemplate{}
+	vars := []chronograf.TemplateVar{}
+	for _, t := range ordered {
+		rt := resolvedTemplate{Template: t}
+		values, err := s.templateQueryValues(ctx, t, req.SourceID, append(vars, timeRange...))
+		if isReaderFluxError(err) {
+			return nil, nil, err
+		} else if err != nil {
+			rt.Error = err.Error()
+		} else {
+			rt.Values = resolveTemplateValues(t, values, req.Selections[t.Var])
+		}
+		resolved[t.Var] = rt
+		vars = append(vars, rt.TemplateVar)
+	}
+
+	templates := make([]resolvedTemplate, len(d.Templates))
+	for i, t := range d.Templates {
+		templates[i] = resolved[t.Var]
+	}
+	cells := make([]resolvedCell, len(d.Cells))
+	for i, cell := range d.Cells {
+		rc := resolvedCell{
+			ID:      cell.ID,
+			Name:    cell.Name,
+			Queries: make([]resolvedQuery, len(cell.Queries)),
+		}
+		for j, q := range cell.Queries {
+			rq := resolvedQuery{
+				Query:  q.Command,
+				Type:   q.Type,
+				Source: q.Source,
+			}
+			if rq.Source == "" && req.SourceID != "" {
+				rq.Source = "/chronograf/v1/sources/" + req.SourceID
+			}
+			var err error
+			if q.Type == "flux" {
+				rq.QueryTemplated = renderFluxQuery(q.Command, vars, req.Lower, req.Upper, now)
+			} else if rq.QueryTemplated, err = renderInfluxQLQuery(q.Command, vars, req.Lower, req.Upper, now); err != nil {
+				rq.Error = err.Error()
+			}
+			rc.Queries[j] = rq
+		}
+		cells[i] = rc
+	}
+	return templates, cells, nil
+}
+
+// templateQueryValues executes the query of the template, rendered with the
+// templates it depends on, and returns the resulting values. Templates
+// without query have no values to fetch.
+func (s *Service) templateQueryValues(ctx context.Context, t chronograf.Template, defaultSourceID string, templates []chronograf.TemplateVar) ([]string, error) {
+	if t.Query == nil || (t.Query.Command == "" && t.Query.Flux == "") {
+		return nil, nil
+	}
+
+	srcID, err := strconv.Atoi(t.SourceID)
+	if err != nil {
+		if srcID, err = strconv.Atoi(defaultSourceID); err != nil {
+			return nil, fmt.Errorf("no source to execute the template query")
+		}
+	}
+	src, err := s.Store.Sources(ctx).Get(ctx, srcID)
+	if err != nil {
+		return nil, fmt.Errorf("source %d not found", srcID)
+	}
+
+	query, err := influx.TemplateReplace(templateInternalQuery(t), templates)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Type == "flux" {
+		if role, ok := hasRoleContext(ctx); ok && role == roles.ReaderRoleName {
+			if err := readerFluxQueryReadOnly(query); err != nil {
+				return nil, err
+			}
+		}
+		u, err := url.ParseRequestURI(src.URL)
+		if err != nil {
+			return nil, err
+		}
+		client := &flux.Client{
+			URL:                u,
+			InsecureSkipVerify: src.InsecureSkipVerify,
+			Org:                src.Username, // v2 organization name is stored in username
+			Authorizer:         influx.DefaultAuthorization(&src),
+		}
+		return client.Values(ctx, query)
+	}
+
+	if err := enforceReaderInfluxQLReadOnly(ctx, query); err != nil {
+		return nil, err
+	}
+	ts, err := s.TimeSeries(src)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to source %d: %v", srcID, err)
+	}
+	if err := ts.Connect(ctx, &src); err != nil {
+		return nil, fmt.Errorf("unable to connect to source %d: %v", srcID, err)
+	}
+	q := chronograf.Query{Command: query}
+	setupQueryFromCommand(&q)
+	response, err := ts.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results, err := response.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return influx.MetaQueryValues(query, results)
+}
+
+// templateInternalQuery returns the query of the template with its database,
+// measurement and tag key filled in.
+func templateInternalQuery(t chronograf.Template) string {
+	switch t.Type {
+	case "influxql":
+		// custom meta queries may refer to templates named like the fields
+		// of the query, which are always empty for them
+		return t.Query.Command
+	case "flux":
+		return t.Query.Flux
+	}
+	q := t.Query.Command
+	q = strings.Replace(q, ":database:", `"`+t.Query.DB+`"`, 1)
+	q = strings.Replace(q, ":measurement:", `"`+t.Query.Measurement+`"`, 1)
+	q = strings.Replace(q, ":tagKey:", `"`+t.Query.TagKey+`"`, 1)
+	return q
+}
+
+// resolveTemplateValues creates the values of a template from the results of
+// its query. The selection is selected if it is one of the values; otherwise
+// the previously selected value is kept, falling back to the first value.
+func resolveTemplateValues(t chronograf.Template, values []string, selection string) []chronograf.TemplateValue {
+	switch t.Type {
+	case "text":
+		if selection != "" {
+			return []chronograf.TemplateValue{{Value: selection, Type: "constant", Selected: true}}
+		}
+		if len(t.Values) > 0 {
+			v := t.Values[0]
+			v.Selected = true
+			return []chronograf.TemplateValue{v}
+		}
+		return []chronograf.TemplateValue{{Value: "", Type: "constant", Selected: true}}
+	case "csv", "map":
+		if len(t.Values) == 0 {
+			return []chronograf.TemplateValue{}
+		}
+		selected := t.Values[0].Value
+		if v, ok := influx.SelectedTemplateValue(t.TemplateVar); ok {
+			selected = v.Value
+		}
+		for _, v := range t.Values {
+			if (t.Type == "map" && v.Key == selection) || (t.Type == "csv" && v.Value == selection) {
+				selected = v.Value
+				break
+			}
+		}
+		res := make([]chronograf.TemplateValue, len(t.Values))
+		for i, v := range t.Values {
+			v.Selected = v.Value == selected
+			res[i] = v
+		}
+		return res
+	case "influxql", "flux", "fieldKeys", "measurements", "tagKeys", "tagValues", "databases":
+		if len(values) == 0 {
+			return []chronograf.TemplateValue{}
+		}
+		selected := values[0]
+		if v, ok := influx.SelectedTemplateValue(t.TemplateVar); ok && oneOf(v.Value, values...) {
+			selected = v.Value
+		}
+		if selection != "" && oneOf(selection, values...) {
+			selected = selection
+		}
+		res := make([]chronograf.TemplateValue, len(values))
+		for i, v := range values {
+			res[i] = chronograf.TemplateValue{
+				Value:    v,
+				Type:     templateValueTypes[t.Type],
+				Selected: v == selected,
+			}
+		}
+		return res
+	}
+	return t.Values
+}
+
+// validTimeRange verifies that the bounds of a time range are timestamps or
+// relative to now() so that they can be used for both InfluxQL and Flux.
+func validTimeRange(lower, upper string, now time.Time) error {
+	if !isTimeRangeBound(lower) {
+		return fmt.Errorf("invalid lower bound %q: must be a timestamp or relative to now(), e.g. now() - 1h", lower)
+	}
+	if upper != "" && upper != "now()" && !isTimeRangeBound(upper) {
+		return fmt.Errorf("invalid upper bound %q: must be now(), a timestamp or relative to now()", upper)
+	}
+	if d, err := timeRangeDuration(lower, upper, now); err != nil || d <= 0 {
+		return fmt.Errorf("invalid time range %q to %q", lower, upper)
+	}
+	return nil
+}
+
+func isTimeRangeBound(bound string) bool {
+	if m := relativeTime.FindStringSubmatch(bound); m != nil {
+		_, err := influxql.ParseDuration(m[1])
+		return err == nil
+	}
+	_, err := time.Parse(time.RFC3339Nano, bound)
+	return err == nil
+}
+
+// timeRangeDuration returns the duration of a time range.
+func timeRangeDuration(lower, upper string, now time.Time) (time.Duration, error) {
+	q, err := influx.TemplateReplace(
+		`SELECT "v" FROM "m" WHERE time > :dashboardTime: AND time < :upperDashboardTime:`,
+		influx.TimeRangeTemplates(lower, upper),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return influx.ParseTime(q, now)
+}
+
+// fluxTimeRange returns the bounds of a time range as flux expressions.
+func fluxTimeRange(lower, upper string, now time.Time) (string, string) {
+	if m := relativeTime.FindStringSubmatch(lower); m != nil {
+		lower = "-" + m[1]
+	}
+	if m := relativeTime.FindStringSubmatch(upper); m != nil {
+		upper = "-" + m[1]
+	} else if upper == "" || upper == "now()" {
+		upper = now.UTC().Format(time.RFC3339Nano)
+	}
+	return lower, upper
+}
+
+// renderInfluxQLQuery replaces the templates and the time range within an
+// InfluxQL query. The :interval: is computed from the time range of the
+// rendered query, like the web client does.
+func renderInfluxQLQuery(command string, templates []chronograf.TemplateVar, lower, upper string, now time.Time) (string, error) {
+	all := make([]chronograf.TemplateVar, 0, len(templates)+2)
+	all = append(all, templates...)
+	command, err := influx.TemplateReplace(command, append(all, influx.TimeRangeTemplates(lower, upper)...))
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(command, influx.TemplateVarInterval) {
+		duration, err := influx.ParseTime(command, now)
+		if err != nil {
+			duration = time.Second
+		} else if duration < time.Millisecond {
+			duration = time.Millisecond
+		}
+		command = influx.ReplaceInterval(command, duration)
+	}
+	return command, nil
+}
+
+// renderFluxQuery prefixes a flux script with the variables for the
+// templates and the time range.
+func renderFluxQuery(script string, templates []chronograf.TemplateVar, lower, upper string, now time.Time) string {
+	duration, err := timeRangeDuration(lower, upper, now)
+	if err != nil {
+		duration = time.Second
+	}
+	fluxLower, fluxUpper := fluxTimeRange(lower, upper, now)
+	return influx.RenderFluxTemplates(script, fluxLower, fluxUpper, templates, duration)
+}
@@ -0,0 +1,266 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/mocks"
+	"github.com/influxdata/chronograf/roles"
+)
+
+func TestService_ResolveTemplates(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Organization: "default",
+		Cells: []chronograf.DashboardCell{
+			{
+				ID:   "c1",
+				Name: "CPU",
+				Queries: []chronograf.DashboardQuery{
+					{
+						Command: `SELECT mean("usage_idle") FROM :measurement: WHERE "host" = ':host:' AND "region" = :region: AND time > :dashboardTime: GROUP BY time(:interval:)`,
+						Type:    "influxql",
+					},
+				},
+			},
+		},
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{Var: ":host:"},
+				ID:          "t1",
+				Type:        "influxql",
+				Query: &chronograf.TemplateQuery{
+					Command: `SHOW TAG VALUES ON "telegraf" FROM :measurement: WITH KEY = "host"`,
+				},
+			},
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":measurement:",
+					Values: []chronograf.TemplateValue{
+						{Value: "mem", Type: "measurement", Selected: true},
+					},
+				},
+				ID:   "t2",
+				Type: "measurements",
+				Query: &chronograf.TemplateQuery{
+					Command: `SHOW MEASUREMENTS ON :database:`,
+					DB:      "telegraf",
+				},
+			},
+			{
+				TemplateVar: chronograf.TemplateVar{
+					Var: ":region:",
+					Values: []chronograf.TemplateValue{
+						{Value: "us-east", Type: "csv"},
+						{Value: "us-west", Type: "csv", Selected: true},
+					},
+				},
+				ID:   "t3",
+				Type: "csv",
+			},
+		},
+	}
+
+	queries := []string{}
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+					return dashboard, nil
+				},
+			},
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+					return chronograf.Source{ID: id, Organization: "default"}, nil
+				},
+			},
+		},
+		TimeSeriesClient: &mocks.TimeSeries{
+			ConnectF: func(context.Context, *chronograf.Source) error {
+				return nil
+			},
+			QueryF: func(ctx context.Context, q chronograf.Query) (chronograf.Response, error) {
+				queries = append(queries, q.Command)
+				if strings.HasPrefix(q.Command, "SHOW MEASUREMENTS") {
+					return mocks.NewResponse(`[{"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["mem"]]}]}]`, nil), nil
+				}
+				return mocks.NewResponse(`[{"series":[{"name":"mem","columns":["key","value"],"values":[["host","b"],["host","a"]]}]}]`, nil), nil
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(`{"sourceID":"1","selections":{":host:":"b"}}`))
+	ctx := dashboardACLContext(2, roles.ViewerRoleName)
+	s.ResolveTemplates(w, r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}})))
+
+	resp := w.Result()
+	body, _ := ioutil.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ResolveTemplates status = %d: %s", resp.StatusCode, body)
+	}
+	var got resolveTemplatesResponse
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	wantQueries := []string{
+		`SHOW MEASUREMENTS ON "telegraf"`,
+		`SHOW TAG VALUES ON "telegraf" FROM "mem" WITH KEY = "host"`,
+	}
+	if !reflect.DeepEqual(queries, wantQueries) {
+		t.Errorf("executed queries = %v, want %v", queries, wantQueries)
+	}
+
+	wantValues := map[string][]chronograf.TemplateValue{
+		":host:": {
+			{Value: "a", Type: "influxql"},
+			{Value: "b", Type: "influxql", Selected: true},
+		},
+		":measurement:": {
+			{Value: "cpu", Type: "measurement"},
+			{Value: "mem", Type: "measurement", Selected: true},
+		},
+		":region:": {
+			{Value: "us-east", Type: "csv"},
+			{Value: "us-west", Type: "csv", Selected: true},
+		},
+	}
+	if len(got.Templates) != len(dashboard.Templates) {
+		t.Fatalf("resolved %d templates, want %d", len(got.Templates), len(dashboard.Templates))
+	}
+	for i, tmpl := range got.Templates {
+		if tmpl.ID != dashboard.Templates[i].ID {
+			t.Errorf("template %d = %s, want %s", i, tmpl.ID, dashboard.Templates[i].ID)
+		}
+		if tmpl.Error != "" {
+			t.Errorf("template %s error = %s", tmpl.Var, tmpl.Error)
+		}
+		if !reflect.DeepEqual(tmpl.Values, wantValues[tmpl.Var]) {
+			t.Errorf("template %s values = %+v, want %+v", tmpl.Var, tmpl.Values, wantValues[tmpl.Var])
+		}
+	}
+
+	want := `SELECT mean("usage_idle") FROM "mem" WHERE "host" = 'b' AND "region" = us-west AND time > now() - 1h GROUP BY time(10000ms)`
+	if len(got.Cells) != 1 || len(got.Cells[0].Queries) != 1 {
+		t.Fatalf("resolved cells = %+v", got.Cells)
+	}
+	if q := got.Cells[0].Queries[0]; q.QueryTemplated != want || q.Source != "/chronograf/v1/sources/1" {
+		t.Errorf("resolved query = %+v, want %s", q, want)
+	}
+}
+
+func Test_resolveTemplateValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  chronograf.Template
+		values    []string
+		selection string
+		want      []chronograf.TemplateValue
+	}{
+		{
+			name: "previous selection is kept if still a value",
+			template: chronograf.Template{
+				TemplateVar: chronograf.TemplateVar{Values: []chronograf.TemplateValue{{Value: "b", Selected: true}}},
+				Type:        "databases",
+			},
+			values: []string{"a", "b"},
+			want: []chronograf.TemplateValue{
+				{Value: "a", Type: "database"},
+				{Value: "b", Type: "database", Selected: true},
+			},
+		},
+		{
+			name: "first value is selected if previous selection is gone",
+			template: chronograf.Template{
+				TemplateVar: chronograf.TemplateVar{Values: []chronograf.TemplateValue{{Value: "c", Selected: true}}},
+				Type:        "fieldKeys",
+			},
+			values:    []string{"a", "b"},
+			selection: "d",
+			want: []chronograf.TemplateValue{
+				{Value: "a", Type: "fieldKey", Selected: true},
+				{Value: "b", Type: "fieldKey"},
+			},
+		},
+		{
+			name: "map is selected by key",
+			template: chronograf.Template{
+				TemplateVar: chronograf.TemplateVar{Values: []chronograf.TemplateValue{
+					{Key: "east", Value: "'us-east'", Type: "map", Selected: true},
+					{Key: "west", Value: "'us-west'", Type: "map"},
+				}},
+				Type: "map",
+			},
+			selection: "west",
+			want: []chronograf.TemplateValue{
+				{Key: "east", Value: "'us-east'", Type: "map"},
+				{Key: "west", Value: "'us-west'", Type: "map", Selected: true},
+			},
+		},
+		{
+			name:      "text uses the selection",
+			template:  chronograf.Template{Type: "text"},
+			selection: "server01",
+			want:      []chronograf.TemplateValue{{Value: "server01", Type: "constant", Selected: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTemplateValues(tt.template, tt.values, tt.selection); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveTemplateValues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ResolveTemplatesReaderFlux(t *testing.T) {
+	dashboard := chronograf.Dashboard{
+		ID:           1,
+		Organization: "default",
+		Templates: []chronograf.Template{
+			{
+				TemplateVar: chronograf.TemplateVar{Var: ":bucket:"},
+				ID:          "t1",
+				Type:        "flux",
+				Query: &chronograf.TemplateQuery{
+					Flux: `from(bucket: "telegraf") |> range(start: -1h) |> to(bucket: "copy")`,
+				},
+			},
+		},
+	}
+	s := &Service{
+		Store: &mocks.Store{
+			DashboardsStore: &mocks.DashboardsStore{
+				GetF: func(ctx context.Context, id chronograf.DashboardID) (chronograf.Dashboard, error) {
+					return dashboard, nil
+				},
+			},
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, id int) (chronograf.Source, error) {
+					return chronograf.Source{ID: id, URL: "http://localhost:8086", Type: chronograf.InfluxDBv2}, nil
+				},
+			},
+		},
+		Logger: &mocks.TestLogger{},
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(`{"sourceID":"1"}`))
+	ctx := dashboardACLContext(2, roles.ReaderRoleName)
+	s.ResolveTemplates(w, r.WithContext(httprouter.WithParams(ctx, httprouter.Params{{Key: "id", Value: "1"}})))
+
+	if resp := w.Result(); resp.StatusCode != http.StatusForbidden {
+		body, _ := ioutil.ReadAll(resp.Body)
+		t.Errorf("ResolveTemplates status = %d, want %d: %s", resp.StatusCode, http.StatusForbidden, body)
+	}
+}
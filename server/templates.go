@@ -94,6 +94,10 @@ func (s *Service) Templates(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, d, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, d.ID, s.Logger)
+		return
+	}
 
 	res := templatesResponses{
 		Templates: newTemplateResponses(chronograf.DashboardID(id), d.Templates),
@@ -115,6 +119,13 @@ func (s *Service) NewTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 
 	var template chronograf.Template
 	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
@@ -165,6 +176,10 @@ func (s *Service) TemplateID(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessView) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	for _, t := range dash.Templates {
@@ -192,6 +207,13 @@ func (s *Service) RemoveTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	pos := -1
@@ -230,6 +252,13 @@ func (s *Service) ReplaceTemplate(w http.ResponseWriter, r *http.Request) {
 		notFound(w, id, s.Logger)
 		return
 	}
+	if !hasDashboardAccess(ctx, dash, chronograf.DashboardAccessEdit) {
+		dashboardForbidden(w, dash.ID, s.Logger)
+		return
+	}
+	if s.refuseManagedEdit(w, dash) {
+		return
+	}
 
 	tid := httprouter.GetParamFromContext(ctx, "tid")
 	pos := -1
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/util"
+)
+
+// writePrecisions maps the precisions of write requests to the precision
+// names of the InfluxDB 2 and InfluxDB 3 write APIs
+var writePrecisions = map[string]struct{ v2, v3 string }{
+	"n":  {"ns", "nanosecond"},
+	"ns": {"ns", "nanosecond"},
+	"u":  {"us", "microsecond"},
+	"us": {"us", "microsecond"},
+	"ms": {"ms", "millisecond"},
+	"s":  {"s", "second"},
+}
+
+// writeURL returns the write endpoint of the source for a write request with
+// the query parameters db, rp, precision and consistency. InfluxDB 3 Core and
+// Enterprise sources use the native /api/v3/write_lp API, which additionally
+// takes accept_partial and no_sync; other InfluxDB 3 sources write to their
+// bucket through the v2 API. The v query parameter selects the v2 API of
+// other sources.
+func writeURL(src *chronograf.Source, query url.Values) (*url.URL, error) {
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing source url: %v", err)
+	}
+	precision := query.Get("precision")
+	names, validPrecision := writePrecisions[precision]
+
+	version := query.Get("v")
+	params := url.Values{}
+	switch {
+	case src.Type == chronograf.InfluxDBv3Core || src.Type == chronograf.InfluxDBv3Enterprise:
+		u = util.AppendPath(u, "/api/v3/write_lp")
+		params.Set("db", query.Get("db"))
+		if precision != "" {
+			if !validPrecision {
+				return nil, fmt.Errorf("invalid precision %q: must be one of ns, us, ms or s", precision)
+			}
+			params.Set("precision", names.v3)
+		}
+		for _, p := range []string{"accept_partial", "no_sync"} {
+			if v := query.Get(p); v != "" {
+				if _, err := strconv.ParseBool(v); err != nil {
+					return nil, fmt.Errorf("invalid %s %q: must be true or false", p, v)
+				}
+				params.Set(p, v)
+			}
+		}
+	case chronograf.IsV3SrcType(src.Type) || strings.HasPrefix(version, "2"):
+		u = util.AppendPath(u, "/api/v2/write")
+		// v2 organization name is stored in username (org does not matter against v1 or v3)
+		params.Set("org", src.Username)
+		params.Set("bucket", query.Get("db"))
+		if precision != "" {
+			if !validPrecision {
+				return nil, fmt.Errorf("invalid precision %q: must be one of ns, us, ms or s", precision)
+			}
+			params.Set("precision", names.v2)
+		}
+	default:
+		u = util.AppendPath(u, "/write")
+		for k, v := range query {
+			if k != "v" {
+				params[k] = v
+			}
+		}
+	}
+	u.RawQuery = params.Encode()
+	return u, nil
+}
+
+// v3WriteError is the error of a rejected /api/v3/write_lp request
+type v3WriteError struct {
+	Error string `json:"error"`
+	Data  []struct {
+		OriginalLine string `json:"original_line"`
+		LineNumber   int    `json:"line_number"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"data"`
+}
+
+// writeErrorResponse is the error of a write with the errors of the rejected lines
+type writeErrorResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"message"`
+	Errors  []importError `json:"errors"`
+}
+
+// rewriteV3WriteError replaces the line errors of a rejected InfluxDB 3
+// write with the per line errors of chronograf. With accept_partial, the
+// valid lines of the write have been written.
+func rewriteV3WriteError(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	var v3Err v3WriteError
+	if err := json.Unmarshal(body, &v3Err); err != nil || len(v3Err.Data) == 0 {
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return nil
+	}
+
+	res := writeErrorResponse{
+		Code:    resp.StatusCode,
+		Message: v3Err.Error,
+		Errors:  make([]importError, len(v3Err.Data)),
+	}
+	for i, d := range v3Err.Data {
+		res.Errors[i] = importError{Line: d.LineNumber, Error: d.ErrorMessage}
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	resp.ContentLength = int64(len(b))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(b)))
+	resp.Header.Set("Content-Type", "application/json")
+	return nil
+}
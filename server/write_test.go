@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bouk/httprouter"
+	"github.com/influxdata/chronograf"
+	"github.com/influxdata/chronograf/log"
+	"github.com/influxdata/chronograf/mocks"
+)
+
+func TestWriteURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     chronograf.Source
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "InfluxDB 1",
+			src:   chronograf.Source{URL: "http://localhost:8086"},
+			query: "db=telegraf&rp=autogen&precision=s&consistency=all",
+			want:  "http://localhost:8086/write?consistency=all&db=telegraf&precision=s&rp=autogen",
+		},
+		{
+			name:  "InfluxDB 2",
+			src:   chronograf.Source{URL: "http://localhost:8086/ctx", Username: "org"},
+			query: "v=2.7.1&db=bucket&precision=u",
+			want:  "http://localhost:8086/ctx/api/v2/write?bucket=bucket&org=org&precision=us",
+		},
+		{
+			name:  "InfluxDB 3 Core",
+			src:   chronograf.Source{URL: "http://localhost:8181", Type: chronograf.InfluxDBv3Core},
+			query: "db=telegraf&rp=autogen&precision=ms&accept_partial=false&no_sync=true",
+			want:  "http://localhost:8181/api/v3/write_lp?accept_partial=false&db=telegraf&no_sync=true&precision=millisecond",
+		},
+		{
+			name:  "InfluxDB 3 Enterprise",
+			src:   chronograf.Source{URL: "http://localhost:8181", Type: chronograf.InfluxDBv3Enterprise},
+			query: "db=telegraf",
+			want:  "http://localhost:8181/api/v3/write_lp?db=telegraf",
+		},
+		{
+			name:  "InfluxDB Cloud Dedicated",
+			src:   chronograf.Source{URL: "https://cluster.a.influxdb.io", Type: chronograf.InfluxDBv3CloudDedicated},
+			query: "db=telegraf&precision=ns",
+			want:  "https://cluster.a.influxdb.io/api/v2/write?bucket=telegraf&org=&precision=ns",
+		},
+		{
+			name:    "invalid InfluxDB 3 precision",
+			src:     chronograf.Source{URL: "http://localhost:8181", Type: chronograf.InfluxDBv3Core},
+			query:   "db=telegraf&precision=h",
+			wantErr: true,
+		},
+		{
+			name:    "invalid accept_partial",
+			src:     chronograf.Source{URL: "http://localhost:8181", Type: chronograf.InfluxDBv3Core},
+			query:   "db=telegraf&accept_partial=maybe",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			got, err := writeURL(&tt.src, query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("writeURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("writeURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_Write_V3(t *testing.T) {
+	var gotAuth, gotQuery, gotBody string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotQuery = r.URL.RawQuery
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(b)
+		if r.URL.Path != "/api/v3/write_lp" {
+			t.Errorf("write path = %s, want /api/v3/write_lp", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"partial write of line protocol occurred","data":[{"original_line":"cpu value=","line_number":2,"error_message":"invalid field value"}]}`))
+	}))
+	defer upstream.Close()
+
+	s := &Service{
+		Store: &mocks.Store{
+			SourcesStore: &mocks.SourcesStore{
+				GetF: func(ctx context.Context, ID int) (chronograf.Source, error) {
+					return chronograf.Source{
+						ID:            1,
+						URL:           upstream.URL,
+						Type:          chronograf.InfluxDBv3Core,
+						DatabaseToken: "apiv3_token",
+					}, nil
+				},
+			},
+		},
+		Logger: log.New(log.DebugLevel),
+	}
+	lines := "cpu value=1\ncpu value=\n"
+	r := httptest.NewRequest("POST", "http://any.url?db=telegraf&precision=s&accept_partial=true", strings.NewReader(lines))
+	r = r.WithContext(httprouter.WithParams(context.Background(), httprouter.Params{{Key: "id", Value: "1"}}))
+	w := httptest.NewRecorder()
+	s.Write(w, r)
+
+	if gotAuth != "Bearer apiv3_token" {
+		t.Errorf("Authorization = %q, want the database token", gotAuth)
+	}
+	if gotQuery != "accept_partial=true&db=telegraf&precision=second" {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if gotBody != lines {
+		t.Errorf("body = %q, want %q", gotBody, lines)
+	}
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	var got writeErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Message != "partial write of line protocol occurred" || len(got.Errors) != 1 || got.Errors[0] != (importError{Line: 2, Error: "invalid field value"}) {
+		t.Errorf("Write() error = %+v, want the error of line 2", got)
+	}
+}